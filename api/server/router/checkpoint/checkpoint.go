@@ -32,5 +32,7 @@ func (r *checkpointRouter) initRoutes() {
 		router.NewGetRoute("/containers/{name:.*}/checkpoints", r.getContainerCheckpoints, router.Experimental),
 		router.NewPostRoute("/containers/{name:.*}/checkpoints", r.postContainerCheckpoint, router.Experimental),
 		router.NewDeleteRoute("/containers/{name}/checkpoints/{checkpoint}", r.deleteContainerCheckpoint, router.Experimental),
+		router.NewGetRoute("/containers/{name}/checkpoints/{checkpoint}/export", r.getContainerCheckpointExport, router.Experimental),
+		router.NewPostRoute("/checkpoints/import", r.postCheckpointImport, router.Experimental),
 	}
 }
@@ -63,3 +63,30 @@ func (s *checkpointRouter) deleteContainerCheckpoint(ctx context.Context, w http
 	w.WriteHeader(http.StatusNoContent)
 	return nil
 }
+
+func (s *checkpointRouter) getContainerCheckpointExport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	return s.backend.CheckpointExport(vars["name"], types.CheckpointExportOptions{
+		CheckpointDir: r.Form.Get("dir"),
+		CheckpointID:  vars["checkpoint"],
+	}, w)
+}
+
+func (s *checkpointRouter) postCheckpointImport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	created, err := s.backend.CheckpointImport(r.Body, types.CheckpointImportOptions{
+		Name: r.Form.Get("name"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusCreated, created)
+}
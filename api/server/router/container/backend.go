@@ -16,6 +16,8 @@ import (
 type execBackend interface {
 	ContainerExecCreate(name string, config *types.ExecConfig) (string, error)
 	ContainerExecInspect(id string) (*backend.ExecInspect, error)
+	ContainerExecKill(name string, sig uint64) error
+	ContainerExecList(name string) ([]types.ExecSummary, error)
 	ContainerExecResize(name string, height, width int) error
 	ContainerExecStart(ctx context.Context, name string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error
 	ExecExists(name string) (bool, error)
@@ -26,8 +28,10 @@ type copyBackend interface {
 	ContainerArchivePath(name string, path string) (content io.ReadCloser, stat *types.ContainerPathStat, err error)
 	ContainerCopy(name string, res string) (io.ReadCloser, error)
 	ContainerExport(name string, out io.Writer) error
+	ContainerExportChanges(name string, out io.Writer) error
 	ContainerExtractToDir(name, path string, copyUIDGID, noOverwriteDirNonDir bool, content io.Reader) error
 	ContainerStatPath(name string, path string) (stat *types.ContainerPathStat, err error)
+	ContainerSync(name, path string, manifest []container.ContainerSyncEntry, remove bool) (*container.ContainerSyncDiff, error)
 }
 
 // stateBackend includes functions to implement to provide container state lifecycle functionality.
@@ -46,13 +50,24 @@ type stateBackend interface {
 	ContainerWait(ctx context.Context, name string, condition containerpkg.WaitCondition) (<-chan containerpkg.StateStatus, error)
 }
 
+// snapshotBackend includes functions to implement to provide container snapshot functionality.
+type snapshotBackend interface {
+	ContainerSnapshotCreate(name string, config types.ContainerSnapshotCreateOptions) error
+	ContainerSnapshotDelete(name, snapshot string) error
+	ContainerSnapshotList(name string) ([]types.ContainerSnapshot, error)
+	ContainerSnapshotRollback(name, snapshot string) error
+}
+
 // monitorBackend includes functions to implement to provide containers monitoring functionality.
 type monitorBackend interface {
 	ContainerChanges(name string) ([]archive.Change, error)
+	ContainerChangeDetails(name string) ([]container.ContainerChangeDetail, error)
 	ContainerInspect(name string, size bool, version string) (interface{}, error)
 	ContainerLogs(ctx context.Context, name string, config *types.ContainerLogsOptions) (msgs <-chan *backend.LogMessage, tty bool, err error)
 	ContainerStats(ctx context.Context, name string, config *backend.ContainerStatsConfig) error
+	ContainerCapture(ctx context.Context, name string, config *backend.ContainerCaptureConfig) error
 	ContainerTop(name string, psArgs string) (*container.ContainerTopOKBody, error)
+	ContainerProcesses(name string, fields []string) (*container.ContainerProcessList, error)
 
 	Containers(config *types.ContainerListOptions) ([]*types.Container, error)
 }
@@ -77,6 +92,7 @@ type Backend interface {
 	execBackend
 	copyBackend
 	stateBackend
+	snapshotBackend
 	monitorBackend
 	attachBackend
 	systemBackend
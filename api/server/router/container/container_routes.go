@@ -7,7 +7,9 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/docker/docker/api/server/httputils"
 	"github.com/docker/docker/api/types"
@@ -115,6 +117,35 @@ func (s *containerRouter) getContainersStats(ctx context.Context, w http.Respons
 	return s.backend.ContainerStats(ctx, vars["name"], config)
 }
 
+func (s *containerRouter) getContainersCapture(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	config := &backend.ContainerCaptureConfig{
+		Network:   vars["network"],
+		Filter:    r.Form.Get("filter"),
+		OutStream: w,
+	}
+	if snapLen := r.Form.Get("snaplen"); snapLen != "" {
+		n, err := strconv.Atoi(snapLen)
+		if err != nil {
+			return errdefs.InvalidParameter(err)
+		}
+		config.SnapLen = n
+	}
+	if duration := r.Form.Get("duration"); duration != "" {
+		seconds, err := strconv.Atoi(duration)
+		if err != nil {
+			return errdefs.InvalidParameter(err)
+		}
+		config.Duration = time.Duration(seconds) * time.Second
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.tcpdump.pcap")
+	return s.backend.ContainerCapture(ctx, vars["name"], config)
+}
+
 func (s *containerRouter) getContainersLogs(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -156,6 +187,13 @@ func (s *containerRouter) getContainersLogs(ctx context.Context, w http.Response
 }
 
 func (s *containerRouter) getContainersExport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	if httputils.BoolValue(r, "changes") && versions.GreaterThanOrEqualTo(httputils.VersionFromContext(ctx), "1.37") {
+		return s.backend.ContainerExportChanges(vars["name"], w)
+	}
 	return s.backend.ContainerExport(vars["name"], w)
 }
 
@@ -379,6 +417,18 @@ func (s *containerRouter) postContainersWait(ctx context.Context, w http.Respons
 }
 
 func (s *containerRouter) getContainersChanges(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	if httputils.BoolValue(r, "details") && versions.GreaterThanOrEqualTo(httputils.VersionFromContext(ctx), "1.37") {
+		details, err := s.backend.ContainerChangeDetails(vars["name"])
+		if err != nil {
+			return err
+		}
+		return httputils.WriteJSON(w, http.StatusOK, details)
+	}
+
 	changes, err := s.backend.ContainerChanges(vars["name"])
 	if err != nil {
 		return err
@@ -400,6 +450,28 @@ func (s *containerRouter) getContainersTop(ctx context.Context, w http.ResponseW
 	return httputils.WriteJSON(w, http.StatusOK, procList)
 }
 
+func (s *containerRouter) getContainersProcesses(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	var fields []string
+	for _, f := range r.Form["fields"] {
+		for _, field := range strings.Split(f, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	procList, err := s.backend.ContainerProcesses(vars["name"], fields)
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, procList)
+}
+
 func (s *containerRouter) postContainerRename(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -433,6 +505,9 @@ func (s *containerRouter) postContainerUpdate(ctx context.Context, w http.Respon
 		Resources:     updateConfig.Resources,
 		RestartPolicy: updateConfig.RestartPolicy,
 	}
+	if versions.GreaterThanOrEqualTo(httputils.VersionFromContext(ctx), "1.37") {
+		hostConfig.StorageOpt = updateConfig.StorageOpt
+	}
 
 	name := vars["name"]
 	resp, err := s.backend.ContainerUpdate(name, hostConfig)
@@ -443,6 +518,56 @@ func (s *containerRouter) postContainerUpdate(ctx context.Context, w http.Respon
 	return httputils.WriteJSON(w, http.StatusOK, resp)
 }
 
+func (s *containerRouter) postContainerSnapshot(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var options types.ContainerSnapshotCreateOptions
+
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&options); err != nil {
+		return err
+	}
+
+	if err := s.backend.ContainerSnapshotCreate(vars["name"], options); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	return nil
+}
+
+func (s *containerRouter) getContainerSnapshots(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	snapshots, err := s.backend.ContainerSnapshotList(vars["name"])
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, snapshots)
+}
+
+func (s *containerRouter) postContainerSnapshotRollback(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := s.backend.ContainerSnapshotRollback(vars["name"], vars["snapshot"]); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *containerRouter) deleteContainerSnapshot(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := s.backend.ContainerSnapshotDelete(vars["name"], vars["snapshot"]); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
 func (s *containerRouter) postContainersCreate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -11,6 +11,7 @@ import (
 
 	"github.com/docker/docker/api/server/httputils"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/versions"
 	gddohttputil "github.com/golang/gddo/httputil"
 )
@@ -138,3 +139,25 @@ func (s *containerRouter) putContainersArchive(ctx context.Context, w http.Respo
 
 	return s.backend.ContainerExtractToDir(v.Name, v.Path, copyUIDGID, noOverwriteDirNonDir, r.Body)
 }
+
+func (s *containerRouter) postContainersSync(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	v, err := httputils.ArchiveFormValues(r, vars)
+	if err != nil {
+		return err
+	}
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var manifest []container.ContainerSyncEntry
+	if err := json.NewDecoder(r.Body).Decode(&manifest); err != nil {
+		return err
+	}
+
+	diff, err := s.backend.ContainerSync(v.Name, v.Path, manifest, httputils.BoolValue(r, "delete"))
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, diff)
+}
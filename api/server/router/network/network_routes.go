@@ -305,6 +305,23 @@ func (n *networkRouter) postNetworkDisconnect(ctx context.Context, w http.Respon
 	return n.backend.DisconnectContainerFromNetwork(disconnect.Container, vars["id"], disconnect.Force)
 }
 
+func (n *networkRouter) postNetworkEndpointUpdate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	var endpointConfig network.EndpointSettings
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&endpointConfig); err != nil {
+		return err
+	}
+
+	return n.backend.UpdateContainerEndpoint(vars["container"], vars["id"], &endpointConfig)
+}
+
 func (n *networkRouter) deleteNetwork(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
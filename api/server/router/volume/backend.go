@@ -2,6 +2,7 @@ package volume // import "github.com/docker/docker/api/server/router/volume"
 
 import (
 	"context"
+	"io"
 
 	// TODO return types need to be refactored into pkg
 	"github.com/docker/docker/api/types"
@@ -16,4 +17,9 @@ type Backend interface {
 	VolumeCreate(name, driverName string, opts, labels map[string]string) (*types.Volume, error)
 	VolumeRm(name string, force bool) error
 	VolumesPrune(ctx context.Context, pruneFilters filters.Args) (*types.VolumesPruneReport, error)
+	VolumeExport(name string, compress, force bool, out io.Writer) error
+	VolumeImport(name string, force bool, content io.Reader) error
+	VolumeClone(name, driverName, from, snapshot string, opts, labels map[string]string) (*types.Volume, error)
+	VolumeSnapshotCreate(name string, options types.VolumeSnapshotCreateOptions) error
+	VolumeSnapshotDelete(name, snapshot string) error
 }
@@ -26,11 +26,17 @@ func (r *volumeRouter) initRoutes() {
 	r.routes = []router.Route{
 		// GET
 		router.NewGetRoute("/volumes", r.getVolumesList),
+		// the export route must come first or it gets masked
+		router.NewGetRoute("/volumes/{name:.*}/export", r.getVolumeExport),
 		router.NewGetRoute("/volumes/{name:.*}", r.getVolumeByName),
 		// POST
 		router.NewPostRoute("/volumes/create", r.postVolumesCreate),
 		router.NewPostRoute("/volumes/prune", r.postVolumesPrune, router.WithCancel),
+		router.NewPostRoute("/volumes/{name:.*}/import", r.postVolumeImport),
+		router.NewPostRoute("/volumes/{name:.*}/snapshots", r.postVolumeSnapshot),
 		// DELETE
+		// the snapshot route must come first or it gets masked
+		router.NewDeleteRoute("/volumes/{name}/snapshots/{snapshot}", r.deleteVolumeSnapshot),
 		router.NewDeleteRoute("/volumes/{name:.*}", r.deleteVolumes),
 	}
 }
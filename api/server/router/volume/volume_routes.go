@@ -8,7 +8,9 @@ import (
 	"net/http"
 
 	"github.com/docker/docker/api/server/httputils"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/versions"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/errdefs"
 )
@@ -54,7 +56,15 @@ func (v *volumeRouter) postVolumesCreate(ctx context.Context, w http.ResponseWri
 		return err
 	}
 
-	volume, err := v.backend.VolumeCreate(req.Name, req.Driver, req.DriverOpts, req.Labels)
+	var (
+		volume *types.Volume
+		err    error
+	)
+	if req.From != "" && versions.GreaterThanOrEqualTo(httputils.VersionFromContext(ctx), "1.37") {
+		volume, err = v.backend.VolumeClone(req.Name, req.Driver, req.From, req.FromSnapshot, req.DriverOpts, req.Labels)
+	} else {
+		volume, err = v.backend.VolumeCreate(req.Name, req.Driver, req.DriverOpts, req.Labels)
+	}
 	if err != nil {
 		return err
 	}
@@ -89,3 +99,57 @@ func (v *volumeRouter) postVolumesPrune(ctx context.Context, w http.ResponseWrit
 	}
 	return httputils.WriteJSON(w, http.StatusOK, pruneReport)
 }
+
+func (v *volumeRouter) getVolumeExport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	compress := httputils.BoolValue(r, "compress")
+	force := httputils.BoolValue(r, "force")
+	return v.backend.VolumeExport(vars["name"], compress, force, w)
+}
+
+func (v *volumeRouter) postVolumeImport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	force := httputils.BoolValue(r, "force")
+	if err := v.backend.VolumeImport(vars["name"], force, r.Body); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (v *volumeRouter) postVolumeSnapshot(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var options types.VolumeSnapshotCreateOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		if err == io.EOF {
+			return errdefs.InvalidParameter(errors.New("got EOF while reading request body"))
+		}
+		return err
+	}
+
+	if err := v.backend.VolumeSnapshotCreate(vars["name"], options); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusCreated)
+	return nil
+}
+
+func (v *volumeRouter) deleteVolumeSnapshot(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := v.backend.VolumeSnapshotDelete(vars["name"], vars["snapshot"]); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -77,6 +77,16 @@ type ContainerStatsConfig struct {
 	Version   string
 }
 
+// ContainerCaptureConfig holds information for configuring the runtime
+// behavior of a backend.ContainerCapture() call.
+type ContainerCaptureConfig struct {
+	Network   string
+	Filter    string
+	SnapLen   int
+	Duration  time.Duration
+	OutStream io.Writer
+}
+
 // ExecInspect holds information about a running process started
 // with docker exec.
 type ExecInspect struct {
@@ -91,6 +101,7 @@ type ExecInspect struct {
 	ContainerID   string
 	DetachKeys    []byte
 	Pid           int
+	TimedOut      bool
 }
 
 // ExecProcessConfig holds information about the exec process
@@ -21,3 +21,14 @@ type ThrottleDevice struct {
 func (t *ThrottleDevice) String() string {
 	return fmt.Sprintf("%s:%d", t.Path, t.Rate)
 }
+
+// LatencyDevice is a structure that holds device:latency_target pair, the
+// target being in microseconds
+type LatencyDevice struct {
+	Path   string
+	Target uint64
+}
+
+func (l *LatencyDevice) String() string {
+	return fmt.Sprintf("%s:%d", l.Path, l.Target)
+}
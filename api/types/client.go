@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"io"
 	"net"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
@@ -28,6 +29,40 @@ type CheckpointDeleteOptions struct {
 	CheckpointDir string
 }
 
+// CheckpointExportOptions holds parameters to export a checkpoint of a container
+type CheckpointExportOptions struct {
+	CheckpointID  string
+	CheckpointDir string
+}
+
+// CheckpointImportOptions holds parameters to import a container from an
+// exported checkpoint
+type CheckpointImportOptions struct {
+	Name string
+}
+
+// ContainerSyncOptions holds parameters to synchronize a directory of a
+// container
+type ContainerSyncOptions struct {
+	Delete bool
+}
+
+// ContainerSnapshotCreateOptions holds parameters to create a snapshot of the
+// writable layer of a container
+type ContainerSnapshotCreateOptions struct {
+	Name string
+}
+
+// ContainerCaptureOptions holds parameters to capture the network traffic of
+// a container.
+type ContainerCaptureOptions struct {
+	// Filter is a classic BPF program selecting the captured packets, as
+	// output by tcpdump -ddd with its lines separated by commas.
+	Filter   string
+	SnapLen  int
+	Duration time.Duration
+}
+
 // ContainerAttachOptions holds parameters to attach to a container.
 type ContainerAttachOptions struct {
 	Stream     bool
@@ -377,6 +412,24 @@ type PluginInstallOptions struct {
 	Args                  []string
 }
 
+// VolumeExportOptions holds parameters to export the content of a volume.
+type VolumeExportOptions struct {
+	Compress bool // compress the archive with gzip
+	Force    bool // export even if running containers mount the volume read-write
+}
+
+// VolumeImportOptions holds parameters to import the content of a volume.
+type VolumeImportOptions struct {
+	Force bool // import even if running containers mount the volume
+}
+
+// VolumeSnapshotCreateOptions holds parameters to create a snapshot of a
+// volume.
+type VolumeSnapshotCreateOptions struct {
+	Name  string
+	Force bool // snapshot even if running containers mount the volume read-write
+}
+
 // SwarmUnlockKeyResponse contains the response for Engine API:
 // GET /swarm/unlockkey
 type SwarmUnlockKeyResponse struct {
@@ -39,6 +39,7 @@ type ExecConfig struct {
 	Env          []string // Environment variables
 	WorkingDir   string   // Working directory
 	Cmd          []string // Execution commands and args
+	Timeout      int      `json:",omitempty"` // Timeout (in seconds) after which the process tree is killed
 }
 
 // PluginRmConfig holds arguments for plugin remove.
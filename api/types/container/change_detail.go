@@ -0,0 +1,16 @@
+package container // import "github.com/docker/docker/api/types/container"
+
+import "os"
+
+// ContainerChangeDetail is a change to the filesystem of a container, with
+// the metadata of the changed file, in response to ContainerChanges with
+// details. Deleted files only have a Path and a Kind.
+type ContainerChangeDetail struct {
+	Path string
+	Kind uint8
+
+	Size     int64       `json:",omitempty"`
+	Mode     os.FileMode `json:",omitempty"`
+	Linkname string      `json:",omitempty"` // target of a symbolic link
+	Digest   string      `json:",omitempty"` // digest of the content of a regular file
+}
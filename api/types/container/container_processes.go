@@ -0,0 +1,67 @@
+package container // import "github.com/docker/docker/api/types/container"
+
+// Optional fields of a ProcessInfo, which are only filled in when requested
+// from the ContainerProcesses operation.
+const (
+	ProcessFieldArgs   = "args"   // command line arguments
+	ProcessFieldCPU    = "cpu"    // CPU time and usage
+	ProcessFieldMemory = "memory" // resident and virtual memory size
+	ProcessFieldFDs    = "fds"    // number of open file descriptors
+	ProcessFieldNSPID  = "nspid"  // PID in the container's PID namespace
+	ProcessFieldUser   = "user"   // real user and group IDs
+)
+
+// ProcessFields lists all the optional fields of a ProcessInfo.
+var ProcessFields = []string{
+	ProcessFieldArgs,
+	ProcessFieldCPU,
+	ProcessFieldMemory,
+	ProcessFieldFDs,
+	ProcessFieldNSPID,
+	ProcessFieldUser,
+}
+
+// ContainerProcessList is the response of the ContainerProcesses operation.
+type ContainerProcessList struct {
+	// Processes holds the processes of the container whose parent is not
+	// in the container, usually only its init process. The other processes
+	// are found in the Children of their parent.
+	Processes []ProcessInfo
+}
+
+// ProcessInfo describes a process running in a container.
+type ProcessInfo struct {
+	// PID is the process ID on the host.
+	PID int
+	// PPID is the ID of the parent process on the host.
+	PPID int
+	// Command is the name of the executable of the process.
+	Command string
+	// State is the state of the process, for example "running" or
+	// "sleeping".
+	State string
+
+	// NSPID is the process ID in the PID namespace of the container.
+	NSPID int `json:",omitempty"`
+	// Args is the command line of the process.
+	Args []string `json:",omitempty"`
+	// UID is the real user ID of the process.
+	UID *int `json:",omitempty"`
+	// GID is the real group ID of the process.
+	GID *int `json:",omitempty"`
+	// CPUTime is the CPU time, user and system, used by the process, in
+	// nanoseconds.
+	CPUTime *uint64 `json:",omitempty"`
+	// CPUPercent is the CPU time used by the process divided by its
+	// running time, in percent of one CPU.
+	CPUPercent *float64 `json:",omitempty"`
+	// MemoryRSS is the resident set size of the process, in bytes.
+	MemoryRSS *uint64 `json:",omitempty"`
+	// MemoryVirtual is the virtual memory size of the process, in bytes.
+	MemoryVirtual *uint64 `json:",omitempty"`
+	// FDs is the number of file descriptors the process has open.
+	FDs *int `json:",omitempty"`
+
+	// Children are the processes whose parent is this process.
+	Children []ProcessInfo `json:",omitempty"`
+}
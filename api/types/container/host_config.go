@@ -336,6 +336,11 @@ type Resources struct {
 	PidsLimit            int64           // Setting pids limit for a container
 	Ulimits              []*units.Ulimit // List of ulimits to be set in the container
 
+	// Applicable to Linux with the cgroup v2 unified hierarchy
+	BlkioDeviceLatencyTarget []*blkiodev.LatencyDevice `json:",omitempty"` // IO latency target of devices (in microseconds)
+	MemoryHigh               int64                     `json:",omitempty"` // Memory usage throttle limit (in bytes)
+	MemoryLow                int64                     `json:",omitempty"` // Memory protected from reclaim (in bytes)
+
 	// Applicable to Windows
 	CPUCount           int64  `json:"CpuCount"`   // CPU count
 	CPUPercent         int64  `json:"CpuPercent"` // CPU percent
@@ -349,6 +354,7 @@ type UpdateConfig struct {
 	// Contains container's resources (cgroups, ulimits)
 	Resources
 	RestartPolicy RestartPolicy
+	StorageOpt    map[string]string `json:",omitempty"` // Storage driver options of the container, only "size" can be updated
 }
 
 // HostConfig the non-portable Config structure of a container.
@@ -0,0 +1,24 @@
+package container // import "github.com/docker/docker/api/types/container"
+
+import "os"
+
+// ContainerSyncEntry is a file of the manifest of a directory to synchronize
+// to a directory of a container. Path is relative to the directory, with
+// slash separators, and the manifest lists the parent directories of its
+// files.
+type ContainerSyncEntry struct {
+	Path string
+	Mode os.FileMode
+
+	Size     int64  `json:",omitempty"`
+	Linkname string `json:",omitempty"` // target of a symbolic link
+	Digest   string `json:",omitempty"` // digest of the content of a regular file
+}
+
+// ContainerSyncDiff is the difference between a directory of a container and
+// the manifest of a directory to synchronize to it, in response to
+// ContainerSync. The paths are relative to the directory.
+type ContainerSyncDiff struct {
+	Changed    []string // files of the manifest to copy to the container
+	Extraneous []string // files of the container which are not in the manifest
+}
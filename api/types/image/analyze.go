@@ -0,0 +1,52 @@
+package image // import "github.com/docker/docker/api/types/image"
+
+// AnalyzeResponse is the response of the ImageAnalyze operation. It breaks
+// down where the bytes of an image are stored.
+type AnalyzeResponse struct {
+	// ID is the ID of the image.
+	ID string `json:"Id"`
+	// Size is the total size of the layers of the image, in bytes.
+	Size int64
+	// WastedSize is the number of bytes in the layers of the image that
+	// are not visible in the image filesystem, because a later layer
+	// overwrote or deleted the files holding them.
+	WastedSize int64
+	// Layers holds the analysis of each layer of the image, from the
+	// bottom-most to the top-most.
+	Layers []LayerAnalysis
+}
+
+// LayerAnalysis is the analysis of a single layer of an image.
+type LayerAnalysis struct {
+	// ChainID is the ID of the layer in the layer store.
+	ChainID string
+	// DiffID is the digest of the uncompressed layer content.
+	DiffID string
+	// Size is the size of the layer content, in bytes.
+	Size int64
+	// WastedSize is the number of bytes of this layer that are hidden by
+	// later layers of the image.
+	WastedSize int64
+	// SharedBy lists the IDs of the other local images that use this
+	// layer.
+	SharedBy []string
+	// Files is the root of the file tree of the layer.
+	Files *FileNode
+}
+
+// FileNode is an entry in the file tree of a layer.
+type FileNode struct {
+	// Name is the base name of the entry.
+	Name string
+	// Size is the size of a file, or the total size of the files below a
+	// directory, in bytes.
+	Size int64
+	// WastedSize is the number of bytes of the entry, or of the files
+	// below it, that are hidden by later layers of the image.
+	WastedSize int64
+	// Whiteout is set if the entry deletes the file or directory of the
+	// same name from the lower layers.
+	Whiteout bool `json:",omitempty"`
+	// Children are the entries of a directory.
+	Children []*FileNode `json:",omitempty"`
+}
@@ -23,6 +23,9 @@ type ImageSummary struct {
 	// Required: true
 	Labels map[string]string `json:"Labels"`
 
+	// last used
+	LastUsed int64 `json:"LastUsed,omitempty"`
+
 	// parent Id
 	// Required: true
 	ParentID string `json:"ParentId"`
@@ -43,6 +46,9 @@ type ImageSummary struct {
 	// Required: true
 	Size int64 `json:"Size"`
 
+	// use count
+	UseCount int64 `json:"UseCount,omitempty"`
+
 	// virtual size
 	// Required: true
 	VirtualSize int64 `json:"VirtualSize"`
@@ -17,6 +17,8 @@ const (
 	TypeTmpfs Type = "tmpfs"
 	// TypeNamedPipe is the type for mounting Windows named pipes
 	TypeNamedPipe Type = "npipe"
+	// TypeImage is the type for mounting the root filesystem of an image
+	TypeImage Type = "image"
 )
 
 // Mount represents a mount (volume).
@@ -25,6 +27,7 @@ type Mount struct {
 	// Source specifies the name of the mount. Depending on mount type, this
 	// may be a volume name or a host path, or even ignored.
 	// Source is not supported for tmpfs (must be an empty value)
+	// Source is an image reference for image mounts
 	Source      string      `json:",omitempty"`
 	Target      string      `json:",omitempty"`
 	ReadOnly    bool        `json:",omitempty"`
@@ -33,6 +36,7 @@ type Mount struct {
 	BindOptions   *BindOptions   `json:",omitempty"`
 	VolumeOptions *VolumeOptions `json:",omitempty"`
 	TmpfsOptions  *TmpfsOptions  `json:",omitempty"`
+	ImageOptions  *ImageOptions  `json:",omitempty"`
 }
 
 // Propagation represents the propagation of a mount.
@@ -87,6 +91,12 @@ type VolumeOptions struct {
 	NoCopy       bool              `json:",omitempty"`
 	Labels       map[string]string `json:",omitempty"`
 	DriverConfig *Driver           `json:",omitempty"`
+	// UID and GID set the owner, and Mode the permissions, of the root of
+	// the volume and of the data copied to it from the image, when the
+	// volume is used for the first time.
+	UID  *int        `json:",omitempty"`
+	GID  *int        `json:",omitempty"`
+	Mode os.FileMode `json:",omitempty"`
 }
 
 // Driver represents a volume driver.
@@ -95,6 +105,13 @@ type Driver struct {
 	Options map[string]string `json:",omitempty"`
 }
 
+// ImageOptions defines options specific to mounts of type "image".
+type ImageOptions struct {
+	// Subpath is the path, relative to the root filesystem of the image, of
+	// the directory to mount instead of the whole root filesystem.
+	Subpath string `json:",omitempty"`
+}
+
 // TmpfsOptions defines options specific to mounts of type "tmpfs".
 type TmpfsOptions struct {
 	// Size sets the size of the tmpfs, in bytes.
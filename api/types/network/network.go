@@ -59,6 +59,28 @@ type EndpointSettings struct {
 	GlobalIPv6PrefixLen int
 	MacAddress          string
 	DriverOpts          map[string]string
+	// Bandwidth limits and egress policy of the endpoint, only supported
+	// on networks of the bridge driver
+	Bandwidth    *EndpointBandwidth    `json:",omitempty"`
+	EgressPolicy *EndpointEgressPolicy `json:",omitempty"`
+}
+
+// EndpointBandwidth represents the bandwidth limits of an endpoint, in bytes
+// per second. A zero rate is unlimited.
+type EndpointBandwidth struct {
+	// IngressRate limits the traffic received by the container
+	IngressRate int64 `json:",omitempty"`
+	// EgressRate limits the traffic sent by the container
+	EgressRate int64 `json:",omitempty"`
+}
+
+// EndpointEgressPolicy represents the destinations, in CIDR notation, the
+// container is allowed or denied to send traffic to through an endpoint.
+// Denied destinations take precedence over allowed ones, and all the other
+// destinations are denied if any is allowed.
+type EndpointEgressPolicy struct {
+	Allow []string `json:",omitempty"`
+	Deny  []string `json:",omitempty"`
 }
 
 // Task carries the information about one backend task
@@ -93,6 +115,18 @@ func (es *EndpointSettings) Copy() *EndpointSettings {
 		aliases := make([]string, 0, len(es.Aliases))
 		epCopy.Aliases = append(aliases, es.Aliases...)
 	}
+
+	if es.Bandwidth != nil {
+		bandwidth := *es.Bandwidth
+		epCopy.Bandwidth = &bandwidth
+	}
+
+	if es.EgressPolicy != nil {
+		epCopy.EgressPolicy = &EndpointEgressPolicy{
+			Allow: append([]string(nil), es.EgressPolicy.Allow...),
+			Deny:  append([]string(nil), es.EgressPolicy.Deny...),
+		}
+	}
 	return &epCopy
 }
 
@@ -13,6 +13,11 @@ type ThrottlingData struct {
 	ThrottledPeriods uint64 `json:"throttled_periods"`
 	// Aggregate time the container was throttled for in nanoseconds.
 	ThrottledTime uint64 `json:"throttled_time"`
+	// Number of periods in which the container used its burst allowance.
+	Bursts uint64 `json:"bursts,omitempty"`
+	// Aggregate time the container ran beyond its quota, using its burst
+	// allowance, in nanoseconds.
+	BurstTime uint64 `json:"burst_time,omitempty"`
 }
 
 // CPUUsage stores All CPU stats aggregated since container inception.
@@ -69,6 +74,8 @@ type MemoryStats struct {
 	// number of times memory usage hits limits.
 	Failcnt uint64 `json:"failcnt,omitempty"`
 	Limit   uint64 `json:"limit,omitempty"`
+	// counters of the memory events of the cgroup.
+	Events *MemoryEvents `json:"events,omitempty"`
 
 	// Windows Memory Stats
 	// See https://technet.microsoft.com/en-us/magazine/ff382715.aspx
@@ -81,6 +88,23 @@ type MemoryStats struct {
 	PrivateWorkingSet uint64 `json:"privateworkingset,omitempty"`
 }
 
+// MemoryEvents holds the number of times memory events occurred in the
+// cgroup of a container. Only Max and OOMKill are reported with cgroup v1.
+// Not used on Windows.
+type MemoryEvents struct {
+	// Number of times the cgroup was reclaimed below its low boundary.
+	Low uint64 `json:"low"`
+	// Number of times the cgroup was throttled for exceeding its high
+	// boundary.
+	High uint64 `json:"high"`
+	// Number of times the cgroup usage was about to exceed its limit.
+	Max uint64 `json:"max"`
+	// Number of times the cgroup hit its limit and reclaim failed.
+	OOM uint64 `json:"oom"`
+	// Number of processes of the cgroup killed by the OOM killer.
+	OOMKill uint64 `json:"oom_kill"`
+}
+
 // BlkioStatEntry is one small entity to store a piece of Blkio stats
 // Not used on Windows.
 type BlkioStatEntry struct {
@@ -104,6 +128,47 @@ type BlkioStats struct {
 	IoMergedRecursive       []BlkioStatEntry `json:"io_merged_recursive"`
 	IoTimeRecursive         []BlkioStatEntry `json:"io_time_recursive"`
 	SectorsRecursive        []BlkioStatEntry `json:"sectors_recursive"`
+	// average latency ("avg_lat") and sampling window ("win") of the IO
+	// latency controller per device, in nanoseconds. cgroup v2 only.
+	IoLatencyRecursive []BlkioStatEntry `json:"io_latency_recursive,omitempty"`
+}
+
+// HugetlbStats stores the usage of the huge pages of one page size.
+// Not used on Windows.
+type HugetlbStats struct {
+	// current usage, in bytes
+	Usage uint64 `json:"usage,omitempty"`
+	// maximum usage ever recorded, in bytes. cgroup v1 only.
+	MaxUsage uint64 `json:"max_usage,omitempty"`
+	// number of allocations which failed because of the limit
+	Failcnt uint64 `json:"failcnt,omitempty"`
+}
+
+// PSIData is the pressure stall information of one kind, "some" or "full".
+type PSIData struct {
+	// Percentage of time stalled over the last 10, 60 and 300 seconds.
+	Avg10  float64 `json:"avg10"`
+	Avg60  float64 `json:"avg60"`
+	Avg300 float64 `json:"avg300"`
+	// Total time stalled, in microseconds.
+	Total uint64 `json:"total"`
+}
+
+// PSIStats stores the pressure stall information of one resource. Some is
+// the time at least one task was stalled on the resource, Full the time all
+// the tasks were stalled at once.
+type PSIStats struct {
+	Some PSIData  `json:"some"`
+	Full *PSIData `json:"full,omitempty"`
+}
+
+// PressureStats stores the pressure stall information (PSI) of the cgroup
+// of a container. Only reported when the kernel supports PSI.
+// Not used on Windows.
+type PressureStats struct {
+	CPU    *PSIStats `json:"cpu,omitempty"`
+	Memory *PSIStats `json:"memory,omitempty"`
+	IO     *PSIStats `json:"io,omitempty"`
 }
 
 // StorageStats is the disk I/O stats for read/write on Windows.
@@ -156,8 +221,10 @@ type Stats struct {
 	PreRead time.Time `json:"preread"`
 
 	// Linux specific stats, not populated on Windows.
-	PidsStats  PidsStats  `json:"pids_stats,omitempty"`
-	BlkioStats BlkioStats `json:"blkio_stats,omitempty"`
+	PidsStats     PidsStats               `json:"pids_stats,omitempty"`
+	BlkioStats    BlkioStats              `json:"blkio_stats,omitempty"`
+	HugetlbStats  map[string]HugetlbStats `json:"hugetlb_stats,omitempty"`
+	PressureStats *PressureStats          `json:"pressure_stats,omitempty"`
 
 	// Windows specific stats, not populated on Linux.
 	NumProcs     uint32       `json:"num_procs"`
@@ -50,7 +50,9 @@ type ImageInspect struct {
 
 // ImageMetadata contains engine-local data about the image
 type ImageMetadata struct {
-	LastTagTime time.Time `json:",omitempty"`
+	LastTagTime  time.Time `json:",omitempty"`
+	LastUsedTime time.Time `json:",omitempty"`
+	UseCount     int64     `json:",omitempty"`
 }
 
 // Container contains response of Engine API:
@@ -92,6 +94,19 @@ type ContainerPathStat struct {
 	LinkTarget string      `json:"linkTarget"`
 }
 
+// ExecSummary contains response of Engine API:
+// GET "/containers/{name:.*}/execs"
+type ExecSummary struct {
+	ID         string
+	Running    bool
+	ExitCode   *int
+	Pid        int
+	Entrypoint string
+	Arguments  []string
+	Created    time.Time
+	TimedOut   bool
+}
+
 // ContainerStats contains response of Engine API:
 // GET "/stats"
 type ContainerStats struct {
@@ -171,6 +186,7 @@ type Info struct {
 	SystemTime         string
 	LoggingDriver      string
 	CgroupDriver       string
+	CgroupVersion      string `json:",omitempty"`
 	NEventsListener    int
 	KernelVersion      string
 	OperatingSystem    string
@@ -499,6 +515,13 @@ type Checkpoint struct {
 	Name string // Name is the name of the checkpoint
 }
 
+// ContainerSnapshot represents the details of a snapshot of the writable
+// layer of a container
+type ContainerSnapshot struct {
+	Name    string    // Name is the name of the snapshot
+	Created time.Time // Created is the time the snapshot was taken
+}
+
 // Runtime describes an OCI runtime
 type Runtime struct {
 	Path string   `json:"path"`
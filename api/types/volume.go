@@ -45,6 +45,9 @@ type Volume struct {
 
 	// usage data
 	UsageData *VolumeUsageData `json:"UsageData,omitempty"`
+
+	// usage history
+	UsageHistory *VolumeUsageHistory `json:"UsageHistory,omitempty"`
 }
 
 // VolumeUsageData Usage details about the volume. This information is used by the
@@ -67,3 +70,37 @@ type VolumeUsageData struct {
 	// Required: true
 	Size int64 `json:"Size"`
 }
+
+// VolumeUsageHistory Usage history of the volume, recorded by the daemon. This
+// information is used by the `GET /volumes/{name}` endpoint, and omitted in
+// other endpoints.
+//
+// swagger:model VolumeUsageHistory
+type VolumeUsageHistory struct {
+
+	// The containers which most recently mounted the volume, most recent first.
+	Consumers []VolumeConsumer `json:"Consumers"`
+
+	// ID of the container for which the volume was created, if any.
+	CreatedBy string `json:"CreatedBy,omitempty"`
+
+	// Date/Time the volume was last mounted, if any.
+	LastMounted string `json:"LastMounted,omitempty"`
+
+	// Date/Time the volume was last unmounted, if any.
+	LastUnmounted string `json:"LastUnmounted,omitempty"`
+}
+
+// VolumeConsumer A container in the usage history of a volume.
+//
+// swagger:model VolumeConsumer
+type VolumeConsumer struct {
+
+	// ID of the container.
+	// Required: true
+	Container string `json:"Container"`
+
+	// Date/Time the container last mounted the volume.
+	// Required: true
+	LastMounted string `json:"LastMounted"`
+}
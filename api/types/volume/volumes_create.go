@@ -19,6 +19,12 @@ type VolumesCreateBody struct {
 	// Required: true
 	DriverOpts map[string]string `json:"DriverOpts"`
 
+	// Name of an existing volume to create the volume as a copy of. The volume is created with the driver of this volume, which must support clones.
+	From string `json:"From,omitempty"`
+
+	// Name of a snapshot of the volume `From` to create the volume as a copy of, instead of its current content.
+	FromSnapshot string `json:"FromSnapshot,omitempty"`
+
 	// User-defined key/value metadata.
 	// Required: true
 	Labels map[string]string `json:"Labels"`
@@ -0,0 +1,41 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+)
+
+// ContainerCapture captures the network traffic of the interface of a
+// container on a network, and returns it in the pcap file format as an
+// io.ReadCloser. The capture ends when its duration elapses, or when the
+// stream is closed. It's up to the caller to close the stream.
+//
+// The duration of the capture is sent in seconds, so durations of less than a
+// second are rejected.
+func (cli *Client) ContainerCapture(ctx context.Context, containerID, networkID string, options types.ContainerCaptureOptions) (io.ReadCloser, error) {
+	query := url.Values{}
+	if options.Filter != "" {
+		query.Set("filter", options.Filter)
+	}
+	if options.SnapLen != 0 {
+		query.Set("snaplen", strconv.Itoa(options.SnapLen))
+	}
+	if options.Duration != 0 {
+		if options.Duration > 0 && options.Duration < time.Second {
+			return nil, errors.Errorf("invalid capture duration %s: must be at least 1s", options.Duration)
+		}
+		query.Set("duration", strconv.Itoa(int(options.Duration.Seconds())))
+	}
+
+	resp, err := cli.get(ctx, "/containers/"+containerID+"/networks/"+networkID+"/capture", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.body, nil
+}
@@ -0,0 +1,79 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestContainerCaptureError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ContainerCapture(context.Background(), "nothing", "nothing", types.ContainerCaptureOptions{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerCaptureSubSecondDuration(t *testing.T) {
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			return nil, fmt.Errorf("unexpected request to %s", r.URL)
+		}),
+	}
+	_, err := client.ContainerCapture(context.Background(), "nothing", "nothing", types.ContainerCaptureOptions{Duration: 500 * time.Millisecond})
+	if err == nil || err.Error() != "invalid capture duration 500ms: must be at least 1s" {
+		t.Fatalf("expected an invalid capture duration error, got %v", err)
+	}
+}
+
+func TestContainerCapture(t *testing.T) {
+	expectedURL := "/containers/container_id/networks/network_id/capture"
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			if r.Method != "GET" {
+				return nil, fmt.Errorf("expected GET method, got %s", r.Method)
+			}
+			query := r.URL.Query()
+			for key, expected := range map[string]string{
+				"filter":   "1,6 0 0 0",
+				"snaplen":  "96",
+				"duration": "30",
+			} {
+				if actual := query.Get(key); actual != expected {
+					return nil, fmt.Errorf("%s not set in URL query properly. Expected '%s', got %s", key, expected, actual)
+				}
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("response"))),
+			}, nil
+		}),
+	}
+	body, err := client.ContainerCapture(context.Background(), "container_id", "network_id", types.ContainerCaptureOptions{
+		Filter:   "1,6 0 0 0",
+		SnapLen:  96,
+		Duration: 30 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "response" {
+		t.Fatalf("expected response to contain 'response', got %s", string(content))
+	}
+}
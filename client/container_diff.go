@@ -21,3 +21,21 @@ func (cli *Client) ContainerDiff(ctx context.Context, containerID string) ([]con
 	ensureReaderClosed(serverResp)
 	return changes, err
 }
+
+// ContainerDiffDetails shows differences in a container filesystem since it
+// was started, with the size, mode and content digest of the changed files.
+func (cli *Client) ContainerDiffDetails(ctx context.Context, containerID string) ([]container.ContainerChangeDetail, error) {
+	var changes []container.ContainerChangeDetail
+
+	query := url.Values{}
+	query.Set("details", "1")
+
+	serverResp, err := cli.get(ctx, "/containers/"+containerID+"/changes", query, nil)
+	if err != nil {
+		return changes, err
+	}
+
+	err = json.NewDecoder(serverResp.body).Decode(&changes)
+	ensureReaderClosed(serverResp)
+	return changes, err
+}
@@ -59,3 +59,48 @@ func TestContainerDiff(t *testing.T) {
 		t.Fatalf("expected an array of 2 changes, got %v", changes)
 	}
 }
+
+func TestContainerDiffDetails(t *testing.T) {
+	expectedURL := "/containers/container_id/changes"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if details := req.URL.Query().Get("details"); details != "1" {
+				return nil, fmt.Errorf("details not set in URL query properly. Expected '1', got %s", details)
+			}
+			b, err := json.Marshal([]container.ContainerChangeDetail{
+				{
+					Kind:   1,
+					Path:   "/path/1",
+					Size:   4,
+					Mode:   0644,
+					Digest: "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+				},
+				{
+					Kind: 2,
+					Path: "/path/2",
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	changes, err := client.ContainerDiffDetails(context.Background(), "container_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("expected an array of 2 changes, got %v", changes)
+	}
+	if changes[0].Size != 4 || changes[0].Digest == "" {
+		t.Fatalf("expected the details of the first change, got %v", changes[0])
+	}
+}
@@ -3,6 +3,7 @@ package client // import "github.com/docker/docker/client"
 import (
 	"context"
 	"encoding/json"
+	"net/url"
 
 	"github.com/docker/docker/api/types"
 )
@@ -52,3 +53,26 @@ func (cli *Client) ContainerExecInspect(ctx context.Context, execID string) (typ
 	ensureReaderClosed(resp)
 	return response, err
 }
+
+// ContainerExecKill sends a signal to a running exec process in the docker host.
+func (cli *Client) ContainerExecKill(ctx context.Context, execID, signal string) error {
+	query := url.Values{}
+	query.Set("signal", signal)
+
+	resp, err := cli.post(ctx, "/exec/"+execID+"/kill", query, nil, nil)
+	ensureReaderClosed(resp)
+	return err
+}
+
+// ContainerExecList returns the running and recently finished exec processes of a container.
+func (cli *Client) ContainerExecList(ctx context.Context, container string) ([]types.ExecSummary, error) {
+	var execs []types.ExecSummary
+	resp, err := cli.get(ctx, "/containers/"+container+"/execs", nil, nil)
+	if err != nil {
+		return execs, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&execs)
+	ensureReaderClosed(resp)
+	return execs, err
+}
@@ -154,3 +154,86 @@ func TestContainerExecInspect(t *testing.T) {
 		t.Fatalf("expected ContainerID `container_id`, got %s", inspect.ContainerID)
 	}
 }
+
+func TestContainerExecKillError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	err := client.ContainerExecKill(context.Background(), "nothing", "SIGKILL")
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerExecKill(t *testing.T) {
+	expectedURL := "/exec/exec_id/kill"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			signal := req.URL.Query().Get("signal")
+			if signal != "SIGTERM" {
+				return nil, fmt.Errorf("signal not set in URL query properly. Expected 'SIGTERM', got %s", signal)
+			}
+			return &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+			}, nil
+		}),
+	}
+
+	if err := client.ContainerExecKill(context.Background(), "exec_id", "SIGTERM"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestContainerExecListError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ContainerExecList(context.Background(), "nothing")
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerExecList(t *testing.T) {
+	expectedURL := "/containers/container_id/execs"
+	exitCode := 137
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			b, err := json.Marshal([]types.ExecSummary{
+				{ID: "exec_1", ExitCode: &exitCode, TimedOut: true},
+				{ID: "exec_2", Running: true, Pid: 42},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	execs, err := client.ContainerExecList(context.Background(), "container_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(execs) != 2 {
+		t.Fatalf("expected 2 execs, got %v", execs)
+	}
+	if execs[0].ID != "exec_1" || execs[0].ExitCode == nil || *execs[0].ExitCode != 137 || !execs[0].TimedOut {
+		t.Fatalf("unexpected first exec: %+v", execs[0])
+	}
+	if execs[1].ID != "exec_2" || !execs[1].Running || execs[1].Pid != 42 {
+		t.Fatalf("unexpected second exec: %+v", execs[1])
+	}
+}
@@ -17,3 +17,19 @@ func (cli *Client) ContainerExport(ctx context.Context, containerID string) (io.
 
 	return serverResp.body, nil
 }
+
+// ContainerExportChanges retrieves the changes to the filesystem of a
+// container as a layer tar stream, in which deleted files are whiteout
+// files, and returns it as an io.ReadCloser. It's up to the caller to close
+// the stream.
+func (cli *Client) ContainerExportChanges(ctx context.Context, containerID string) (io.ReadCloser, error) {
+	query := url.Values{}
+	query.Set("changes", "1")
+
+	serverResp, err := cli.get(ctx, "/containers/"+containerID+"/export", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return serverResp.body, nil
+}
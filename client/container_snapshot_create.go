@@ -0,0 +1,15 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+)
+
+// ContainerSnapshotCreate saves the writable layer of the given stopped
+// container to a snapshot with the given name
+func (cli *Client) ContainerSnapshotCreate(ctx context.Context, container string, options types.ContainerSnapshotCreateOptions) error {
+	resp, err := cli.post(ctx, "/containers/"+container+"/snapshots", nil, options, nil)
+	ensureReaderClosed(resp)
+	return err
+}
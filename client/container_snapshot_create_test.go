@@ -0,0 +1,61 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestContainerSnapshotCreateError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	err := client.ContainerSnapshotCreate(context.Background(), "nothing", types.ContainerSnapshotCreateOptions{
+		Name: "snapshot",
+	})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerSnapshotCreate(t *testing.T) {
+	expectedURL := "/containers/container_id/snapshots"
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+
+			options := &types.ContainerSnapshotCreateOptions{}
+			if err := json.NewDecoder(req.Body).Decode(options); err != nil {
+				return nil, err
+			}
+			if options.Name != "snapshot" {
+				return nil, fmt.Errorf("expected Name to be 'snapshot', got %s", options.Name)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+			}, nil
+		}),
+	}
+
+	err := client.ContainerSnapshotCreate(context.Background(), "container_id", types.ContainerSnapshotCreateOptions{
+		Name: "snapshot",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
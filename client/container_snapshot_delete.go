@@ -0,0 +1,11 @@
+package client // import "github.com/docker/docker/client"
+
+import "context"
+
+// ContainerSnapshotDelete deletes the snapshot with the given name of the
+// writable layer of the given container
+func (cli *Client) ContainerSnapshotDelete(ctx context.Context, container, snapshot string) error {
+	resp, err := cli.delete(ctx, "/containers/"+container+"/snapshots/"+snapshot, nil, nil)
+	ensureReaderClosed(resp)
+	return err
+}
@@ -0,0 +1,23 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+)
+
+// ContainerSnapshotList returns the snapshots of the writable layer of the
+// given container, oldest first
+func (cli *Client) ContainerSnapshotList(ctx context.Context, container string) ([]types.ContainerSnapshot, error) {
+	var snapshots []types.ContainerSnapshot
+
+	resp, err := cli.get(ctx, "/containers/"+container+"/snapshots", nil, nil)
+	if err != nil {
+		return snapshots, wrapResponseError(err, resp, "container", container)
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&snapshots)
+	ensureReaderClosed(resp)
+	return snapshots, err
+}
@@ -0,0 +1,11 @@
+package client // import "github.com/docker/docker/client"
+
+import "context"
+
+// ContainerSnapshotRollback restores the writable layer of the given stopped
+// container from the snapshot with the given name
+func (cli *Client) ContainerSnapshotRollback(ctx context.Context, container, snapshot string) error {
+	resp, err := cli.post(ctx, "/containers/"+container+"/snapshots/"+snapshot+"/rollback", nil, nil, nil)
+	ensureReaderClosed(resp)
+	return err
+}
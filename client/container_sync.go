@@ -0,0 +1,34 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"path/filepath"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+)
+
+// ContainerSync compares the directory at the given path in the container
+// with the manifest of a local directory. It returns the files of the
+// manifest to copy with CopyToContainer, and the files of the container
+// which are not in the manifest, removed if options.Delete is set.
+func (cli *Client) ContainerSync(ctx context.Context, containerID, path string, manifest []container.ContainerSyncEntry, options types.ContainerSyncOptions) (container.ContainerSyncDiff, error) {
+	var diff container.ContainerSyncDiff
+
+	query := url.Values{}
+	query.Set("path", filepath.ToSlash(path)) // Normalize the paths used in the API.
+	if options.Delete {
+		query.Set("delete", "1")
+	}
+
+	resp, err := cli.post(ctx, "/containers/"+containerID+"/sync", query, manifest, nil)
+	if err != nil {
+		return diff, wrapResponseError(err, resp, "container:path", containerID+":"+path)
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&diff)
+	ensureReaderClosed(resp)
+	return diff, err
+}
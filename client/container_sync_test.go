@@ -0,0 +1,89 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestContainerSyncError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ContainerSync(context.Background(), "container_id", "/dir", nil, types.ContainerSyncOptions{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerSyncNotFound(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusNotFound, "Not found")),
+	}
+	_, err := client.ContainerSync(context.Background(), "container_id", "/dir", nil, types.ContainerSyncOptions{})
+	if err == nil || !IsErrNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestContainerSync(t *testing.T) {
+	expectedURL := "/containers/container_id/sync"
+	manifest := []container.ContainerSyncEntry{
+		{Path: "file", Mode: 0644, Size: 4, Digest: "sha256:abcd"},
+	}
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			query := req.URL.Query()
+			if path := query.Get("path"); path != "/dir" {
+				return nil, fmt.Errorf("path not set in URL query properly, expected '/dir', got %s", path)
+			}
+			if remove := query.Get("delete"); remove != "1" {
+				return nil, fmt.Errorf("delete not set in URL query properly, expected '1', got %s", remove)
+			}
+			var entries []container.ContainerSyncEntry
+			if err := json.NewDecoder(req.Body).Decode(&entries); err != nil {
+				return nil, err
+			}
+			if len(entries) != 1 || entries[0] != manifest[0] {
+				return nil, fmt.Errorf("expected manifest %v, got %v", manifest, entries)
+			}
+			content, err := json.Marshal(container.ContainerSyncDiff{
+				Changed:    []string{"file"},
+				Extraneous: []string{"old"},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}),
+	}
+
+	diff, err := client.ContainerSync(context.Background(), "container_id", "/dir", manifest, types.ContainerSyncOptions{Delete: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0] != "file" {
+		t.Fatalf("expected changed file, got %v", diff.Changed)
+	}
+	if len(diff.Extraneous) != 1 || diff.Extraneous[0] != "old" {
+		t.Fatalf("expected extraneous old, got %v", diff.Extraneous)
+	}
+}
@@ -26,3 +26,23 @@ func (cli *Client) ContainerTop(ctx context.Context, containerID string, argumen
 	ensureReaderClosed(resp)
 	return response, err
 }
+
+// ContainerProcesses returns the tree of the processes running in a container.
+// The optional fields of the processes listed in fields, such as
+// container.ProcessFieldCPU, are filled in as well.
+func (cli *Client) ContainerProcesses(ctx context.Context, containerID string, fields []string) (container.ContainerProcessList, error) {
+	var response container.ContainerProcessList
+	query := url.Values{}
+	if len(fields) > 0 {
+		query.Set("fields", strings.Join(fields, ","))
+	}
+
+	resp, err := cli.get(ctx, "/containers/"+containerID+"/processes", query, nil)
+	if err != nil {
+		return response, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&response)
+	ensureReaderClosed(resp)
+	return response, err
+}
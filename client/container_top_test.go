@@ -72,3 +72,60 @@ func TestContainerTop(t *testing.T) {
 		t.Fatalf("Titles: expected %v, got %v", expectedTitles, processList.Titles)
 	}
 }
+
+func TestContainerProcessesError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ContainerProcesses(context.Background(), "nothing", nil)
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerProcesses(t *testing.T) {
+	expectedURL := "/containers/container_id/processes"
+	fds := 3
+	expectedProcesses := []container.ProcessInfo{
+		{
+			PID:     100,
+			PPID:    1,
+			Command: "sh",
+			State:   "sleeping",
+			FDs:     &fds,
+			Children: []container.ProcessInfo{
+				{PID: 101, PPID: 100, Command: "sleep", State: "sleeping", FDs: &fds},
+			},
+		},
+	}
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			fields := req.URL.Query().Get("fields")
+			if fields != "fds,args" {
+				return nil, fmt.Errorf("fields not set in URL query properly. Expected 'fds,args', got %v", fields)
+			}
+
+			b, err := json.Marshal(container.ContainerProcessList{Processes: expectedProcesses})
+			if err != nil {
+				return nil, err
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	processList, err := client.ContainerProcesses(context.Background(), "container_id", []string{container.ProcessFieldFDs, container.ProcessFieldArgs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expectedProcesses, processList.Processes) {
+		t.Fatalf("Processes: expected %v, got %v", expectedProcesses, processList.Processes)
+	}
+}
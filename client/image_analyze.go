@@ -0,0 +1,22 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/docker/docker/api/types/image"
+)
+
+// ImageAnalyze returns a breakdown of the size of an image by layer and file.
+func (cli *Client) ImageAnalyze(ctx context.Context, imageID string) (image.AnalyzeResponse, error) {
+	var analysis image.AnalyzeResponse
+	serverResp, err := cli.get(ctx, "/images/"+imageID+"/analyze", url.Values{}, nil)
+	if err != nil {
+		return analysis, wrapResponseError(err, serverResp, "image", imageID)
+	}
+
+	err = json.NewDecoder(serverResp.body).Decode(&analysis)
+	ensureReaderClosed(serverResp)
+	return analysis, err
+}
@@ -0,0 +1,62 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+)
+
+func TestImageAnalyzeError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ImageAnalyze(context.Background(), "nothing")
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server error, got %v", err)
+	}
+}
+
+func TestImageAnalyze(t *testing.T) {
+	expectedURL := "/images/image_id/analyze"
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(r.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			b, err := json.Marshal(image.AnalyzeResponse{
+				ID:         "image_id",
+				Size:       30,
+				WastedSize: 10,
+				Layers: []image.LayerAnalysis{
+					{ChainID: "chain1", Size: 20, WastedSize: 10, SharedBy: []string{"image_id2"}},
+					{ChainID: "chain2", Size: 10},
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+	analysis, err := client.ImageAnalyze(context.Background(), "image_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(analysis.Layers) != 2 {
+		t.Fatalf("expected 2 layers, got %v", analysis.Layers)
+	}
+	if analysis.WastedSize != 10 {
+		t.Fatalf("expected 10 wasted bytes, got %d", analysis.WastedSize)
+	}
+}
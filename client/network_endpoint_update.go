@@ -0,0 +1,18 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types/network"
+)
+
+// NetworkEndpointUpdate updates the bandwidth limits and the egress policy of
+// the endpoint of a container on a network.
+func (cli *Client) NetworkEndpointUpdate(ctx context.Context, networkID, containerID string, config *network.EndpointSettings) error {
+	if config == nil {
+		config = &network.EndpointSettings{}
+	}
+	resp, err := cli.post(ctx, "/networks/"+networkID+"/endpoints/"+containerID+"/update", nil, config, nil)
+	ensureReaderClosed(resp)
+	return err
+}
@@ -0,0 +1,67 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/network"
+)
+
+func TestNetworkEndpointUpdateError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	err := client.NetworkEndpointUpdate(context.Background(), "network_id", "container_id", nil)
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestNetworkEndpointUpdate(t *testing.T) {
+	expectedURL := "/networks/network_id/endpoints/container_id/update"
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+
+			var config network.EndpointSettings
+			if err := json.NewDecoder(req.Body).Decode(&config); err != nil {
+				return nil, err
+			}
+
+			if config.Bandwidth == nil || config.Bandwidth.EgressRate != 1024 {
+				return nil, fmt.Errorf("expected an egress rate of 1024, got %v", config.Bandwidth)
+			}
+
+			if config.EgressPolicy == nil || len(config.EgressPolicy.Deny) != 1 || config.EgressPolicy.Deny[0] != "10.0.0.0/8" {
+				return nil, fmt.Errorf("expected 10.0.0.0/8 to be denied, got %v", config.EgressPolicy)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+			}, nil
+		}),
+	}
+
+	err := client.NetworkEndpointUpdate(context.Background(), "network_id", "container_id", &network.EndpointSettings{
+		Bandwidth:    &network.EndpointBandwidth{EgressRate: 1024},
+		EgressPolicy: &network.EndpointEgressPolicy{Deny: []string{"10.0.0.0/8"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,27 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+	"io"
+	"net/url"
+
+	"github.com/docker/docker/api/types"
+)
+
+// VolumeExport retrieves a tar archive of the content of a volume and
+// returns it as an io.ReadCloser. It's up to the caller to close the stream.
+func (cli *Client) VolumeExport(ctx context.Context, volumeID string, options types.VolumeExportOptions) (io.ReadCloser, error) {
+	query := url.Values{}
+	if options.Compress {
+		query.Set("compress", "1")
+	}
+	if options.Force {
+		query.Set("force", "1")
+	}
+
+	resp, err := cli.get(ctx, "/volumes/"+volumeID+"/export", query, nil)
+	if err != nil {
+		return nil, wrapResponseError(err, resp, "volume", volumeID)
+	}
+	return resp.body, nil
+}
@@ -0,0 +1,15 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+)
+
+// VolumeSnapshotCreate saves the content of the given volume to a snapshot
+// with the given name, from which volumes can be created
+func (cli *Client) VolumeSnapshotCreate(ctx context.Context, volumeID string, options types.VolumeSnapshotCreateOptions) error {
+	resp, err := cli.post(ctx, "/volumes/"+volumeID+"/snapshots", nil, options, nil)
+	ensureReaderClosed(resp)
+	return err
+}
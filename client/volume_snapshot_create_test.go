@@ -0,0 +1,61 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestVolumeSnapshotCreateError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	err := client.VolumeSnapshotCreate(context.Background(), "nothing", types.VolumeSnapshotCreateOptions{
+		Name: "snapshot",
+	})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestVolumeSnapshotCreate(t *testing.T) {
+	expectedURL := "/volumes/volume_id/snapshots"
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+
+			options := &types.VolumeSnapshotCreateOptions{}
+			if err := json.NewDecoder(req.Body).Decode(options); err != nil {
+				return nil, err
+			}
+			if options.Name != "snapshot" {
+				return nil, fmt.Errorf("expected Name to be 'snapshot', got %s", options.Name)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+			}, nil
+		}),
+	}
+
+	err := client.VolumeSnapshotCreate(context.Background(), "volume_id", types.VolumeSnapshotCreateOptions{
+		Name: "snapshot",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
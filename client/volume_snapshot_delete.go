@@ -0,0 +1,11 @@
+package client // import "github.com/docker/docker/client"
+
+import "context"
+
+// VolumeSnapshotDelete deletes the snapshot with the given name of the given
+// volume
+func (cli *Client) VolumeSnapshotDelete(ctx context.Context, volumeID, snapshot string) error {
+	resp, err := cli.delete(ctx, "/volumes/"+volumeID+"/snapshots/"+snapshot, nil, nil)
+	ensureReaderClosed(resp)
+	return err
+}
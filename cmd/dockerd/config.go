@@ -66,6 +66,19 @@ func installCommonConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	flags.BoolVar(&conf.Experimental, "experimental", false, "Enable experimental features")
 
 	flags.StringVar(&conf.MetricsAddress, "metrics-addr", "", "Set default address and port to serve the metrics api on")
+	flags.Var(opts.NewNamedListOptsRef("metrics-container-labels", &conf.MetricsContainerLabels, nil), "metrics-container-label", "Container label to add to the per-container metrics")
+	flags.StringVar(&conf.SignaturePolicy, "signature-policy", "", "Path to the image signature policy file")
+	flags.StringVar(&conf.VolumeEncryptionKeyFile, "volume-encryption-key-file", "", "Path to the key file of the encrypted local volumes")
+	flags.IntVar(&conf.ImageGCConfig.HighWatermark, "image-gc-high-watermark", 0, "Disk usage percentage above which unused images are removed (0 to disable)")
+	flags.IntVar(&conf.ImageGCConfig.LowWatermark, "image-gc-low-watermark", 0, "Disk usage percentage at which the removal of unused images stops")
+	flags.StringVar(&conf.ImageGCConfig.MinAge, "image-gc-min-age", "", "Minimum duration an image must be unused before it can be removed")
+	flags.StringVar(&conf.ImageGCConfig.Interval, "image-gc-interval", "5m", "Interval between two disk usage checks of the image garbage collector")
+	flags.Var(opts.NewNamedListOptsRef("image-gc-keep-labels", &conf.ImageGCConfig.KeepLabels, nil), "image-gc-keep-label", "Do not remove images with this label (key or key=value)")
+	flags.Var(opts.NewNamedListOptsRef("image-gc-keep-references", &conf.ImageGCConfig.KeepReferences, nil), "image-gc-keep-reference", "Do not remove images with this reference or from this repository")
+	flags.StringVar(&conf.VolumeLifecycleConfig.AnonymousTTL, "volume-anonymous-ttl", "", "Duration after which an unreferenced anonymous volume is removed (empty to keep them)")
+	flags.StringVar(&conf.VolumeLifecycleConfig.ProjectLabel, "volume-project-label", "com.docker.compose.project", "Label grouping volumes in projects for volume-project-max-size")
+	flags.StringVar(&conf.VolumeLifecycleConfig.ProjectMaxSize, "volume-project-max-size", "", "Maximum total size of the volumes of a project, above which unreferenced volumes are removed")
+	flags.StringVar(&conf.VolumeLifecycleConfig.ReaperInterval, "volume-reaper-interval", "5m", "Interval between two runs of the volume reaper")
 
 	flags.Var(opts.NewNamedListOptsRef("node-generic-resources", &conf.NodeGenericResources, opts.ValidateSingleGenericResource), "node-generic-resource", "Advertise user-defined resource")
 
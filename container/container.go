@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/containerd/containerd/cio"
+	apitypes "github.com/docker/docker/api/types"
 	containertypes "github.com/docker/docker/api/types/container"
 	mounttypes "github.com/docker/docker/api/types/mount"
 	networktypes "github.com/docker/docker/api/types/network"
@@ -96,9 +97,10 @@ type Container struct {
 	HasBeenStartedBefore   bool
 	HasBeenManuallyStopped bool // used for unless-stopped restart policy
 	MountPoints            map[string]*volumemounts.MountPoint
-	HostConfig             *containertypes.HostConfig `json:"-"` // do not serialize the host config in the json, otherwise we'll make the container unportable
-	ExecCommands           *exec.Store                `json:"-"`
-	DependencyStore        agentexec.DependencyGetter `json:"-"`
+	Snapshots              []apitypes.ContainerSnapshot // snapshots of the RWLayer, oldest first
+	HostConfig             *containertypes.HostConfig   `json:"-"` // do not serialize the host config in the json, otherwise we'll make the container unportable
+	ExecCommands           *exec.Store                  `json:"-"`
+	DependencyStore        agentexec.DependencyGetter   `json:"-"`
 	SecretReferences       []*swarmtypes.SecretReference
 	ConfigReferences       []*swarmtypes.ConfigReference
 	// logDriver for closing
@@ -463,10 +465,16 @@ func (container *Container) AddMountPointWithVolume(destination string, vol volu
 	}
 }
 
-// UnmountVolumes unmounts all volumes
+// UnmountVolumes unmounts all volumes, and the images of image mounts
 func (container *Container) UnmountVolumes(volumeEventLog func(name, action string, attributes map[string]string)) error {
 	var errors []string
 	for _, volumeMount := range container.MountPoints {
+		if volumeMount.Image != nil {
+			if err := volumeMount.Cleanup(); err != nil {
+				errors = append(errors, err.Error())
+			}
+			continue
+		}
 		if volumeMount.Volume == nil {
 			continue
 		}
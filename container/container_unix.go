@@ -120,18 +120,26 @@ func (container *Container) NetworkMounts() []Mount {
 	return mounts
 }
 
-// CopyImagePathContent copies files in destination to the volume.
-func (container *Container) CopyImagePathContent(v volume.Volume, destination string) error {
-	rootfs, err := container.GetResourcePath(destination)
-	if err != nil {
-		return err
-	}
-
-	if _, err = ioutil.ReadDir(rootfs); err != nil {
-		if os.IsNotExist(err) {
-			return nil
+// InitializeVolume prepares the volume for its first use by the container,
+// when it is empty. If copyData is true, the files in destination are
+// copied to the volume. The owner of the volume and of the copied files is
+// then set to uid and gid, unless they are -1, and the permissions of the
+// volume to perm, unless it is 0.
+func (container *Container) InitializeVolume(v volume.Volume, destination string, copyData bool, uid, gid int, perm os.FileMode) error {
+	var rootfs string
+	if copyData {
+		p, err := container.GetResourcePath(destination)
+		if err != nil {
+			return err
 		}
-		return err
+		if _, err := ioutil.ReadDir(p); err == nil {
+			rootfs = p
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if rootfs == "" && uid == -1 && gid == -1 && perm == 0 {
+		return nil
 	}
 
 	id := stringid.GenerateNonCryptoID()
@@ -145,10 +153,50 @@ func (container *Container) CopyImagePathContent(v volume.Volume, destination st
 			logrus.Warnf("error while unmounting volume %s: %v", v.Name(), err)
 		}
 	}()
-	if err := label.Relabel(path, container.MountLabel, true); err != nil && err != unix.ENOTSUP {
+
+	if rootfs != "" {
+		if err := label.Relabel(path, container.MountLabel, true); err != nil && err != unix.ENOTSUP {
+			return err
+		}
+	}
+
+	dstList, err := ioutil.ReadDir(path)
+	if err != nil {
 		return err
 	}
-	return copyExistingContents(rootfs, path)
+	if len(dstList) != 0 {
+		// the volume was already used, do not copy nor change its ownership
+		return nil
+	}
+
+	if rootfs != "" {
+		if err := fs.CopyDir(path, rootfs); err != nil {
+			return err
+		}
+	}
+	return setVolumeOwnership(path, uid, gid, perm)
+}
+
+// setVolumeOwnership sets the owner of the files in path to uid and gid,
+// unless they are -1, and the permissions of path to perm, unless it is 0.
+func setVolumeOwnership(path string, uid, gid int, perm os.FileMode) error {
+	if uid != -1 || gid != -1 {
+		err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			return os.Lchown(p, uid, gid)
+		})
+		if err != nil {
+			return errors.Wrap(err, "error while setting the owner of the volume")
+		}
+	}
+	if perm != 0 {
+		if err := os.Chmod(path, perm); err != nil {
+			return errors.Wrap(err, "error while setting the permissions of the volume")
+		}
+	}
+	return nil
 }
 
 // ShmResourcePath returns path to shm
@@ -336,6 +384,15 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 	if resources.KernelMemory != 0 {
 		cResources.KernelMemory = resources.KernelMemory
 	}
+	if resources.MemoryHigh != 0 {
+		cResources.MemoryHigh = resources.MemoryHigh
+	}
+	if resources.MemoryLow != 0 {
+		cResources.MemoryLow = resources.MemoryLow
+	}
+	if len(resources.BlkioDeviceLatencyTarget) > 0 {
+		cResources.BlkioDeviceLatencyTarget = resources.BlkioDeviceLatencyTarget
+	}
 	if resources.CPURealtimePeriod != 0 {
 		cResources.CPURealtimePeriod = resources.CPURealtimePeriod
 	}
@@ -387,20 +444,6 @@ func (container *Container) DetachAndUnmount(volumeEventLog func(name, action st
 	return container.UnmountVolumes(volumeEventLog)
 }
 
-// copyExistingContents copies from the source to the destination and
-// ensures the ownership is appropriately set.
-func copyExistingContents(source, destination string) error {
-	dstList, err := ioutil.ReadDir(destination)
-	if err != nil {
-		return err
-	}
-	if len(dstList) != 0 {
-		// destination is not empty, do not copy
-		return nil
-	}
-	return fs.CopyDir(destination, source)
-}
-
 // TmpfsMounts returns the list of tmpfs mounts
 func (container *Container) TmpfsMounts() ([]Mount, error) {
 	parser := volumemounts.NewParser(container.OS)
@@ -158,7 +158,7 @@ func (daemon *Daemon) containerStatPath(container *container.Container, path str
 	defer daemon.Unmount(container)
 
 	err = daemon.mountVolumes(container)
-	defer container.DetachAndUnmount(daemon.LogVolumeEvent)
+	defer container.DetachAndUnmount(daemon.logVolumeMountEvent)
 	if err != nil {
 		return nil, err
 	}
@@ -196,7 +196,7 @@ func (daemon *Daemon) containerArchivePath(container *container.Container, path
 	defer func() {
 		if err != nil {
 			// unmount any volumes
-			container.DetachAndUnmount(daemon.LogVolumeEvent)
+			container.DetachAndUnmount(daemon.logVolumeMountEvent)
 			// unmount the container's rootfs
 			daemon.Unmount(container)
 		}
@@ -245,7 +245,7 @@ func (daemon *Daemon) containerArchivePath(container *container.Container, path
 
 	content = ioutils.NewReadCloserWrapper(data, func() error {
 		err := data.Close()
-		container.DetachAndUnmount(daemon.LogVolumeEvent)
+		container.DetachAndUnmount(daemon.logVolumeMountEvent)
 		daemon.Unmount(container)
 		container.Unlock()
 		return err
@@ -272,7 +272,7 @@ func (daemon *Daemon) containerExtractToDir(container *container.Container, path
 	defer daemon.Unmount(container)
 
 	err = daemon.mountVolumes(container)
-	defer container.DetachAndUnmount(daemon.LogVolumeEvent)
+	defer container.DetachAndUnmount(daemon.logVolumeMountEvent)
 	if err != nil {
 		return err
 	}
@@ -398,7 +398,7 @@ func (daemon *Daemon) containerCopy(container *container.Container, resource str
 	defer func() {
 		if err != nil {
 			// unmount any volumes
-			container.DetachAndUnmount(daemon.LogVolumeEvent)
+			container.DetachAndUnmount(daemon.logVolumeMountEvent)
 			// unmount the container's rootfs
 			daemon.Unmount(container)
 		}
@@ -439,7 +439,7 @@ func (daemon *Daemon) containerCopy(container *container.Container, resource str
 
 	reader := ioutils.NewReadCloserWrapper(archive, func() error {
 		err := archive.Close()
-		container.DetachAndUnmount(daemon.LogVolumeEvent)
+		container.DetachAndUnmount(daemon.logVolumeMountEvent)
 		daemon.Unmount(container)
 		container.Unlock()
 		return err
@@ -0,0 +1,217 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/backend"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxCaptureSnapLen is the maximum and default number of bytes of the
+	// packets kept by a capture, the same as tcpdump.
+	maxCaptureSnapLen = 262144
+	// defaultCaptureDuration and maxCaptureDuration are the default and
+	// maximum durations of a capture.
+	defaultCaptureDuration = time.Minute
+	maxCaptureDuration     = 10 * time.Minute
+	// maxBPFInstructions is the maximum length of a BPF program accepted by
+	// the kernel.
+	maxBPFInstructions = 4096
+
+	pcapMagic        = 0xa1b2c3d4
+	pcapLinkTypeEth  = 1
+	pcapVersionMajor = 2
+	pcapVersionMinor = 4
+)
+
+// bpfInstruction is an instruction of a classic BPF program.
+type bpfInstruction struct {
+	Op uint16
+	Jt uint8
+	Jf uint8
+	K  uint32
+}
+
+// packetSource is a source of captured packets.
+type packetSource interface {
+	// ReadPacket reads the next packet into buf, returning the number of
+	// bytes read and the length of the packet before being truncated to
+	// the size of buf.
+	ReadPacket(ctx context.Context, buf []byte) (n, length int, err error)
+	Close() error
+}
+
+// ContainerCapture writes a pcap capture of the network traffic of the
+// interface of the container on the given network to the output stream of the
+// config, until the duration of the capture elapses or the context is
+// cancelled.
+func (daemon *Daemon) ContainerCapture(ctx context.Context, name string, config *backend.ContainerCaptureConfig) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	snapLen := config.SnapLen
+	if snapLen == 0 {
+		snapLen = maxCaptureSnapLen
+	}
+	if snapLen < 0 || snapLen > maxCaptureSnapLen {
+		return errdefs.InvalidParameter(fmt.Errorf("invalid snap length %d: must be between 1 and %d", snapLen, maxCaptureSnapLen))
+	}
+	duration := config.Duration
+	if duration == 0 {
+		duration = defaultCaptureDuration
+	}
+	if duration < 0 || duration > maxCaptureDuration {
+		return errdefs.InvalidParameter(fmt.Errorf("invalid capture duration %s: must be at most %s", duration, maxCaptureDuration))
+	}
+	filter, err := parseBPFProgram(config.Filter)
+	if err != nil {
+		return errdefs.InvalidParameter(errors.Wrap(err, "invalid capture filter"))
+	}
+
+	if !container.IsRunning() {
+		return errNotRunning(container.ID)
+	}
+	n, err := daemon.FindNetwork(config.Network)
+	if err != nil {
+		return err
+	}
+	container.Lock()
+	settings, ok := container.NetworkSettings.Networks[n.Name()]
+	sandboxID := container.NetworkSettings.SandboxID
+	var macAddress string
+	if ok && settings.EndpointSettings != nil {
+		macAddress = settings.MacAddress
+	}
+	container.Unlock()
+	if macAddress == "" {
+		return errdefs.NotFound(fmt.Errorf("container %s is not connected to the network %s", container.ID, n.Name()))
+	}
+	mac, err := net.ParseMAC(macAddress)
+	if err != nil {
+		return err
+	}
+	sb, err := daemon.netController.SandboxByID(sandboxID)
+	if err != nil {
+		return err
+	}
+
+	capture, err := openCapture(sb.Key(), mac, filter)
+	if err != nil {
+		return err
+	}
+	defer capture.Close()
+
+	wf := ioutils.NewWriteFlusher(config.OutStream)
+	defer wf.Close()
+	pw, err := newPcapWriter(wf, snapLen)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+	buf := make([]byte, snapLen)
+	for {
+		size, length, err := capture.ReadPacket(ctx, buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if err := pw.WritePacket(time.Now(), buf[:size], length); err != nil {
+			// the client is gone
+			return nil
+		}
+	}
+}
+
+// parseBPFProgram parses a classic BPF program in the format output by
+// tcpdump -ddd, also used by the bpf match of iptables: the number of
+// instructions followed by the instructions, made of their four fields
+// separated by spaces, separated by commas or newlines.
+func parseBPFProgram(s string) ([]bpfInstruction, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	lines := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' })
+	count, err := strconv.Atoi(strings.TrimSpace(lines[0]))
+	if err != nil {
+		return nil, errors.Errorf("invalid instruction count %q", lines[0])
+	}
+	if count < 1 || count > maxBPFInstructions {
+		return nil, errors.Errorf("invalid instruction count %d: must be between 1 and %d", count, maxBPFInstructions)
+	}
+	if len(lines)-1 != count {
+		return nil, errors.Errorf("expected %d instructions, got %d", count, len(lines)-1)
+	}
+
+	program := make([]bpfInstruction, count)
+	for i, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			return nil, errors.Errorf("invalid instruction %q", line)
+		}
+		var values [4]uint64
+		for j, bits := range []int{16, 8, 8, 32} {
+			v, err := strconv.ParseUint(fields[j], 10, bits)
+			if err != nil {
+				return nil, errors.Errorf("invalid instruction %q", line)
+			}
+			values[j] = v
+		}
+		program[i] = bpfInstruction{
+			Op: uint16(values[0]),
+			Jt: uint8(values[1]),
+			Jf: uint8(values[2]),
+			K:  uint32(values[3]),
+		}
+	}
+	return program, nil
+}
+
+// pcapWriter writes packets in the pcap file format, with microsecond
+// timestamps.
+type pcapWriter struct {
+	w io.Writer
+}
+
+// newPcapWriter writes the header of a capture of Ethernet packets truncated
+// to snapLen bytes to w, and returns a pcapWriter writing the packets to w.
+func newPcapWriter(w io.Writer, snapLen int) (*pcapWriter, error) {
+	header := make([]byte, 24)
+	binary.LittleEndian.PutUint32(header[0:], pcapMagic)
+	binary.LittleEndian.PutUint16(header[4:], pcapVersionMajor)
+	binary.LittleEndian.PutUint16(header[6:], pcapVersionMinor)
+	binary.LittleEndian.PutUint32(header[16:], uint32(snapLen))
+	binary.LittleEndian.PutUint32(header[20:], pcapLinkTypeEth)
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return &pcapWriter{w: w}, nil
+}
+
+// WritePacket writes the packet data captured at time ts, which was length
+// bytes long before being truncated.
+func (pw *pcapWriter) WritePacket(ts time.Time, data []byte, length int) error {
+	record := make([]byte, 16, 16+len(data))
+	binary.LittleEndian.PutUint32(record[0:], uint32(ts.Unix()))
+	binary.LittleEndian.PutUint32(record[4:], uint32(ts.Nanosecond()/int(time.Microsecond)))
+	binary.LittleEndian.PutUint32(record[8:], uint32(len(data)))
+	binary.LittleEndian.PutUint32(record[12:], uint32(length))
+	_, err := pw.w.Write(append(record, data...))
+	return err
+}
@@ -0,0 +1,146 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"net"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+)
+
+// captureReadTimeout is the time a read of a capture socket waits for a
+// packet before checking whether the capture is done.
+const captureReadTimeout = 200 * time.Millisecond
+
+// packetSocket is a packetSource reading the packets of an interface with an
+// AF_PACKET socket.
+type packetSocket struct {
+	fd int
+}
+
+// openCapture opens an AF_PACKET socket in the network namespace of the
+// sandbox, capturing the packets of its interface with the given MAC address
+// which are accepted by the BPF program filter.
+func openCapture(sandboxKey string, mac net.HardwareAddr, filter []bpfInstruction) (packetSource, error) {
+	sbNs, err := netns.GetFromPath(sandboxKey)
+	if err != nil {
+		return nil, err
+	}
+	defer sbNs.Close()
+	h, err := netlink.NewHandleAt(sbNs, syscall.NETLINK_ROUTE)
+	if err != nil {
+		return nil, err
+	}
+	defer h.Delete()
+	link, err := linkByHardwareAddr(h, mac)
+	if err != nil {
+		return nil, err
+	}
+
+	fd, err := packetSocketAt(sbNs)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while creating capture socket")
+	}
+	s := &packetSocket{fd: fd}
+	if err := s.setup(link.Attrs().Index, filter); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// packetSocketAt creates an AF_PACKET socket receiving no packets in the
+// network namespace ns. The socket stays in the namespace it is created in.
+func packetSocketAt(ns netns.NsHandle) (int, error) {
+	type result struct {
+		fd  int
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		runtime.LockOSThread()
+		origNs, err := netns.Get()
+		if err != nil {
+			runtime.UnlockOSThread()
+			ch <- result{err: err}
+			return
+		}
+		defer origNs.Close()
+		if err := netns.Set(ns); err != nil {
+			runtime.UnlockOSThread()
+			ch <- result{err: err}
+			return
+		}
+		fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, 0)
+		// The thread is left locked, and so terminated with the goroutine,
+		// if it cannot go back to the namespace of the daemon.
+		if rErr := netns.Set(origNs); rErr != nil {
+			logrus.WithError(rErr).Error("failed to restore the network namespace of the daemon after creating a capture socket")
+		} else {
+			runtime.UnlockOSThread()
+		}
+		ch <- result{fd: fd, err: err}
+	}()
+	r := <-ch
+	return r.fd, r.err
+}
+
+// setup attaches the BPF program filter to the socket, then binds it to the
+// interface with the given index.
+func (s *packetSocket) setup(ifIndex int, filter []bpfInstruction) error {
+	if len(filter) > 0 {
+		program := make([]syscall.SockFilter, len(filter))
+		for i, ins := range filter {
+			program[i] = syscall.SockFilter{Code: ins.Op, Jt: ins.Jt, Jf: ins.Jf, K: ins.K}
+		}
+		if err := syscall.AttachLsf(s.fd, program); err != nil {
+			return errdefs.InvalidParameter(errors.Wrap(err, "invalid capture filter"))
+		}
+	}
+	tv := unix.NsecToTimeval(captureReadTimeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(s.fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		return err
+	}
+	return unix.Bind(s.fd, &unix.SockaddrLinklayer{
+		Protocol: htons(unix.ETH_P_ALL),
+		Ifindex:  ifIndex,
+	})
+}
+
+// ReadPacket reads the next packet, until the context is done.
+func (s *packetSocket) ReadPacket(ctx context.Context, buf []byte) (int, int, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, 0, err
+		}
+		// With MSG_TRUNC, the length of the packet is returned even if it
+		// does not fit in buf.
+		length, _, err := unix.Recvfrom(s.fd, buf, unix.MSG_TRUNC)
+		switch err {
+		case nil:
+			if length < len(buf) {
+				return length, length, nil
+			}
+			return len(buf), length, nil
+		case unix.EAGAIN, unix.EINTR:
+		default:
+			return 0, 0, err
+		}
+	}
+}
+
+// Close closes the socket.
+func (s *packetSocket) Close() error {
+	return unix.Close(s.fd)
+}
+
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
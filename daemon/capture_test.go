@@ -0,0 +1,70 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+func TestParseBPFProgram(t *testing.T) {
+	// tcpdump -ddd icmp
+	icmp := []bpfInstruction{
+		{Op: 40, K: 12},
+		{Op: 21, Jf: 3, K: 2048},
+		{Op: 48, K: 23},
+		{Op: 21, Jf: 1, K: 1},
+		{Op: 6, K: 262144},
+		{Op: 6},
+	}
+	for _, s := range []string{
+		"6,40 0 0 12,21 0 3 2048,48 0 0 23,21 0 1 1,6 0 0 262144,6 0 0 0",
+		"6\n40 0 0 12\n21 0 3 2048\n48 0 0 23\n21 0 1 1\n6 0 0 262144\n6 0 0 0\n",
+	} {
+		program, err := parseBPFProgram(s)
+		assert.NilError(t, err)
+		assert.Check(t, is.DeepEqual(program, icmp))
+	}
+
+	program, err := parseBPFProgram("")
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(program, 0))
+
+	for s, expected := range map[string]string{
+		"icmp":               `invalid instruction count "icmp"`,
+		"0":                  "invalid instruction count 0: must be between 1 and 4096",
+		"2,6 0 0 0":          "expected 2 instructions, got 1",
+		"1,6 0 0":            `invalid instruction "6 0 0"`,
+		"1,6 0 256 0":        `invalid instruction "6 0 256 0"`,
+		"1,65536 0 0 0":      `invalid instruction "65536 0 0 0"`,
+		"1,6 0 0 4294967296": `invalid instruction "6 0 0 4294967296"`,
+	} {
+		_, err := parseBPFProgram(s)
+		assert.Check(t, is.Error(err, expected), s)
+	}
+}
+
+func TestPcapWriter(t *testing.T) {
+	var buf bytes.Buffer
+	pw, err := newPcapWriter(&buf, 4)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(buf.Bytes(), []byte{
+		0xd4, 0xc3, 0xb2, 0xa1, 2, 0, 4, 0,
+		0, 0, 0, 0, 0, 0, 0, 0,
+		4, 0, 0, 0, 1, 0, 0, 0,
+	}))
+
+	buf.Reset()
+	ts := time.Unix(1500000000, 123456789)
+	assert.NilError(t, pw.WritePacket(ts, []byte{1, 2, 3, 4}, 60))
+	record := buf.Bytes()
+	assert.Assert(t, is.Len(record, 20))
+	assert.Check(t, is.Equal(binary.LittleEndian.Uint32(record[0:]), uint32(1500000000)))
+	assert.Check(t, is.Equal(binary.LittleEndian.Uint32(record[4:]), uint32(123456)))
+	assert.Check(t, is.Equal(binary.LittleEndian.Uint32(record[8:]), uint32(4)))
+	assert.Check(t, is.Equal(binary.LittleEndian.Uint32(record[12:]), uint32(60)))
+	assert.Check(t, is.DeepEqual(record[16:], []byte{1, 2, 3, 4}))
+}
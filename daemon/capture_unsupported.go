@@ -0,0 +1,14 @@
+// +build !linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"net"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+)
+
+func openCapture(sandboxKey string, mac net.HardwareAddr, filter []bpfInstruction) (packetSource, error) {
+	return nil, errdefs.NotImplemented(errors.New("network traffic capture is not supported on this platform"))
+}
@@ -0,0 +1,256 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	pblkiodev "github.com/docker/docker/api/types/blkiodev"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/reexec"
+	"github.com/docker/docker/pkg/sysinfo"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// The runtime spec cannot express the cgroup v2 interface files, so on hosts
+// using the cgroup v2 unified hierarchy the resources of a container are
+// translated by the daemon. They are written to the cgroup of the container by
+// a prestart hook of its spec, before its process is started, and by the
+// daemon when they are updated.
+
+// cgroup2HookName is the name of the reexec of the daemon writing the cgroup
+// v2 settings of a container, as a prestart hook.
+const cgroup2HookName = "docker-cgroup2-resources"
+
+func init() {
+	reexec.Register(cgroup2HookName, cgroup2HookMain)
+}
+
+// cgroup2Setting is a value written to an interface file of a cgroup v2.
+type cgroup2Setting struct {
+	file  string
+	value string
+}
+
+// cpuSharesToWeight converts CPU shares, in the [2, 262144] range of cgroup
+// v1, to a cpu.weight in the [1, 10000] range of cgroup v2.
+func cpuSharesToWeight(shares uint64) uint64 {
+	if shares < 2 {
+		shares = 2
+	}
+	return 1 + ((shares-2)*9999)/262142
+}
+
+// blkioWeightToIOWeight converts a block IO weight, in the [10, 1000] range
+// of cgroup v1, to an io.weight in the [1, 10000] range of cgroup v2.
+func blkioWeightToIOWeight(weight uint16) uint64 {
+	if weight < 10 {
+		weight = 10
+	}
+	return 1 + (uint64(weight)-10)*9999/990
+}
+
+// cgroup2Settings translates the resources of a container to the settings
+// of its cgroup v2. Resources left to their zero value are not set.
+func cgroup2Settings(r containertypes.Resources) ([]cgroup2Setting, error) {
+	var settings []cgroup2Setting
+	set := func(file, value string) {
+		settings = append(settings, cgroup2Setting{file: file, value: value})
+	}
+
+	// cpu and cpuset controllers
+	cpu, err := getCPUResources(r)
+	if err != nil {
+		return nil, err
+	}
+	if r.CPUShares > 0 {
+		set("cpu.weight", strconv.FormatUint(cpuSharesToWeight(uint64(r.CPUShares)), 10))
+	}
+	if cpu.Quota != nil || cpu.Period != nil {
+		quota := "max"
+		if cpu.Quota != nil && *cpu.Quota > 0 {
+			quota = strconv.FormatInt(*cpu.Quota, 10)
+		}
+		period := uint64(100000)
+		if cpu.Period != nil && *cpu.Period > 0 {
+			period = *cpu.Period
+		}
+		set("cpu.max", fmt.Sprintf("%s %d", quota, period))
+	}
+	if cpu.Cpus != "" {
+		set("cpuset.cpus", cpu.Cpus)
+	}
+	if cpu.Mems != "" {
+		set("cpuset.mems", cpu.Mems)
+	}
+
+	// memory controller. The swap limit of cgroup v1 includes the memory,
+	// the one of cgroup v2 does not.
+	if r.Memory > 0 {
+		set("memory.max", strconv.FormatInt(r.Memory, 10))
+	}
+	switch {
+	case r.MemorySwap == -1:
+		set("memory.swap.max", "max")
+	case r.MemorySwap > 0 && r.Memory > 0:
+		set("memory.swap.max", strconv.FormatInt(r.MemorySwap-r.Memory, 10))
+	}
+	if r.MemoryHigh > 0 {
+		set("memory.high", strconv.FormatInt(r.MemoryHigh, 10))
+	}
+	// memory.low is the closest equivalent of the soft limit of cgroup v1.
+	if r.MemoryLow > 0 {
+		set("memory.low", strconv.FormatInt(r.MemoryLow, 10))
+	} else if r.MemoryReservation > 0 {
+		set("memory.low", strconv.FormatInt(r.MemoryReservation, 10))
+	}
+
+	// pids controller
+	if r.PidsLimit > 0 {
+		set("pids.max", strconv.FormatInt(r.PidsLimit, 10))
+	}
+
+	// io controller
+	if r.BlkioWeight > 0 {
+		set("io.weight", fmt.Sprintf("default %d", blkioWeightToIOWeight(r.BlkioWeight)))
+	}
+	weightDevices, err := getBlkioWeightDevices(r)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range weightDevices {
+		set("io.weight", fmt.Sprintf("%d:%d %d", d.Major, d.Minor, blkioWeightToIOWeight(*d.Weight)))
+	}
+
+	limits := make(map[string][]string)
+	for _, t := range []struct {
+		key  string
+		devs []*pblkiodev.ThrottleDevice
+	}{
+		{"rbps", r.BlkioDeviceReadBps},
+		{"wbps", r.BlkioDeviceWriteBps},
+		{"riops", r.BlkioDeviceReadIOps},
+		{"wiops", r.BlkioDeviceWriteIOps},
+	} {
+		devs, err := getBlkioThrottleDevices(t.devs)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range devs {
+			dev := fmt.Sprintf("%d:%d", d.Major, d.Minor)
+			limits[dev] = append(limits[dev], fmt.Sprintf("%s=%d", t.key, d.Rate))
+		}
+	}
+	devs := make([]string, 0, len(limits))
+	for dev := range limits {
+		devs = append(devs, dev)
+	}
+	sort.Strings(devs)
+	for _, dev := range devs {
+		set("io.max", dev+" "+strings.Join(limits[dev], " "))
+	}
+
+	for _, l := range r.BlkioDeviceLatencyTarget {
+		var stat unix.Stat_t
+		if err := unix.Stat(l.Path, &stat); err != nil {
+			return nil, err
+		}
+		set("io.latency", fmt.Sprintf("%d:%d target=%d", stat.Rdev/256, stat.Rdev%256, l.Target))
+	}
+
+	return settings, nil
+}
+
+// setCgroup2Resources adds a prestart hook writing the cgroup v2 settings of
+// the resources r to the cgroup of the container to the spec s. The runtime
+// fails to start the container if the hook fails.
+func setCgroup2Resources(s *specs.Spec, r containertypes.Resources) error {
+	settings, err := cgroup2Settings(r)
+	if err != nil {
+		return err
+	}
+	if len(settings) == 0 {
+		return nil
+	}
+	args := []string{cgroup2HookName}
+	for _, setting := range settings {
+		args = append(args, setting.file+"="+setting.value)
+	}
+	if s.Hooks == nil {
+		s.Hooks = &specs.Hooks{}
+	}
+	s.Hooks.Prestart = append(s.Hooks.Prestart, specs.Hook{
+		Path: filepath.Join("/proc", strconv.Itoa(os.Getpid()), "exe"),
+		Args: args,
+	})
+	return nil
+}
+
+// parseCgroup2HookArgs parses the file=value arguments of the prestart hook
+// to cgroup v2 settings.
+func parseCgroup2HookArgs(args []string) ([]cgroup2Setting, error) {
+	settings := make([]cgroup2Setting, 0, len(args))
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || strings.ContainsRune(kv[0], '/') {
+			return nil, errors.Errorf("invalid cgroup setting %q", arg)
+		}
+		settings = append(settings, cgroup2Setting{file: kv[0], value: kv[1]})
+	}
+	return settings, nil
+}
+
+// cgroup2HookMain is the prestart hook writing the cgroup v2 settings of its
+// arguments to the cgroup of the container, whose state is read from stdin.
+func cgroup2HookMain() {
+	if err := runCgroup2Hook(); err != nil {
+		fmt.Fprint(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func runCgroup2Hook() error {
+	settings, err := parseCgroup2HookArgs(os.Args[1:])
+	if err != nil {
+		return err
+	}
+	var state specs.State
+	if err := json.NewDecoder(os.Stdin).Decode(&state); err != nil {
+		return errors.Wrap(err, "failed to decode the container state")
+	}
+	return writeCgroup2Settings(state.Pid, settings)
+}
+
+// applyCgroup2Resources writes the cgroup v2 settings of the resources r to
+// the cgroup of the process pid.
+func applyCgroup2Resources(pid int, r containertypes.Resources) error {
+	settings, err := cgroup2Settings(r)
+	if err != nil {
+		return err
+	}
+	return writeCgroup2Settings(pid, settings)
+}
+
+// writeCgroup2Settings writes the cgroup v2 settings to the cgroup of the
+// process pid.
+func writeCgroup2Settings(pid int, settings []cgroup2Setting) error {
+	paths, err := cgroups.ParseCgroupFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(sysinfo.Cgroup2Root, paths[""])
+	for _, s := range settings {
+		if err := ioutil.WriteFile(filepath.Join(dir, s.file), []byte(s.value), 0); err != nil {
+			return errors.Wrapf(err, "failed to set %s to %q", s.file, s.value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+func TestCgroup2Settings(t *testing.T) {
+	settings, err := cgroup2Settings(containertypes.Resources{
+		CPUShares:         1024,
+		NanoCPUs:          1500000000,
+		CpusetCpus:        "0-1",
+		Memory:            512 * 1024 * 1024,
+		MemorySwap:        768 * 1024 * 1024,
+		MemoryHigh:        384 * 1024 * 1024,
+		MemoryReservation: 128 * 1024 * 1024,
+		PidsLimit:         100,
+		BlkioWeight:       500,
+	})
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(settings, []cgroup2Setting{
+		{file: "cpu.weight", value: "39"},
+		{file: "cpu.max", value: "150000 100000"},
+		{file: "cpuset.cpus", value: "0-1"},
+		{file: "memory.max", value: "536870912"},
+		{file: "memory.swap.max", value: "268435456"},
+		{file: "memory.high", value: "402653184"},
+		{file: "memory.low", value: "134217728"},
+		{file: "pids.max", value: "100"},
+		{file: "io.weight", value: "default 4950"},
+	}, cmp.AllowUnexported(cgroup2Setting{})))
+}
+
+func TestCgroup2SettingsMemoryLow(t *testing.T) {
+	settings, err := cgroup2Settings(containertypes.Resources{
+		CPUPeriod:         50000,
+		MemorySwap:        -1,
+		MemoryLow:         64 * 1024 * 1024,
+		MemoryReservation: 128 * 1024 * 1024,
+	})
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(settings, []cgroup2Setting{
+		{file: "cpu.max", value: "max 50000"},
+		{file: "memory.swap.max", value: "max"},
+		{file: "memory.low", value: "67108864"},
+	}, cmp.AllowUnexported(cgroup2Setting{})))
+}
+
+func TestSetCgroup2Resources(t *testing.T) {
+	s := &specs.Spec{}
+	assert.NilError(t, setCgroup2Resources(s, containertypes.Resources{}))
+	assert.Check(t, is.Nil(s.Hooks))
+
+	s.Hooks = &specs.Hooks{Prestart: []specs.Hook{{Path: "/bin/true"}}}
+	assert.NilError(t, setCgroup2Resources(s, containertypes.Resources{
+		MemoryHigh: 384 * 1024 * 1024,
+		PidsLimit:  100,
+	}))
+	assert.Assert(t, is.Len(s.Hooks.Prestart, 2))
+	hook := s.Hooks.Prestart[1]
+	assert.Check(t, is.Equal(hook.Path, filepath.Join("/proc", strconv.Itoa(os.Getpid()), "exe")))
+	assert.Check(t, is.DeepEqual(hook.Args, []string{cgroup2HookName, "memory.high=402653184", "pids.max=100"}))
+
+	settings, err := parseCgroup2HookArgs(hook.Args[1:])
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(settings, []cgroup2Setting{
+		{file: "memory.high", value: "402653184"},
+		{file: "pids.max", value: "100"},
+	}, cmp.AllowUnexported(cgroup2Setting{})))
+}
+
+func TestParseCgroup2HookArgs(t *testing.T) {
+	settings, err := parseCgroup2HookArgs([]string{"io.max=8:0 rbps=1048576 wiops=100", "cpu.max=max 100000"})
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(settings, []cgroup2Setting{
+		{file: "io.max", value: "8:0 rbps=1048576 wiops=100"},
+		{file: "cpu.max", value: "max 100000"},
+	}, cmp.AllowUnexported(cgroup2Setting{})))
+
+	for _, arg := range []string{"memory.high", "=100", "../memory.high=100"} {
+		_, err := parseCgroup2HookArgs([]string{arg})
+		assert.Check(t, is.ErrorContains(err, "invalid cgroup setting"), arg)
+	}
+}
+
+func TestWeightConversions(t *testing.T) {
+	assert.Check(t, is.Equal(cpuSharesToWeight(2), uint64(1)))
+	assert.Check(t, is.Equal(cpuSharesToWeight(262144), uint64(10000)))
+	assert.Check(t, is.Equal(blkioWeightToIOWeight(10), uint64(1)))
+	assert.Check(t, is.Equal(blkioWeightToIOWeight(1000), uint64(10000)))
+}
@@ -2,10 +2,14 @@ package daemon // import "github.com/docker/docker/daemon"
 
 import (
 	"errors"
+	"os"
 	"runtime"
 	"time"
 
+	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/containerfs"
+	digest "github.com/opencontainers/go-digest"
 )
 
 // ContainerChanges returns a list of container fs changes
@@ -32,3 +36,116 @@ func (daemon *Daemon) ContainerChanges(name string) ([]archive.Change, error) {
 	containerActions.WithValues("changes").UpdateSince(start)
 	return c, nil
 }
+
+// ContainerChangeDetails returns the list of container fs changes, with the
+// size, mode and content digest of the changed files.
+func (daemon *Daemon) ContainerChangeDetails(name string) ([]containertypes.ContainerChangeDetail, error) {
+	start := time.Now()
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if runtime.GOOS == "windows" && container.IsRunning() {
+		return nil, errors.New("Windows does not support diff of a running container")
+	}
+
+	// The container is not kept locked while the digests are computed.
+	container.Lock()
+	rwlayer := container.RWLayer
+	if rwlayer == nil {
+		container.Unlock()
+		return nil, errors.New("RWLayer of container " + name + " is unexpectedly nil")
+	}
+	c, err := rwlayer.Changes()
+	if err != nil {
+		container.Unlock()
+		return nil, err
+	}
+	root, err := rwlayer.Mount(container.GetMountLabel())
+	container.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	defer rwlayer.Unmount()
+
+	details, err := changeDetails(root, c)
+	if err != nil {
+		return nil, err
+	}
+	containerActions.WithValues("changes").UpdateSince(start)
+	return details, nil
+}
+
+// changeDetails adds to the changes of the filesystem root the metadata of
+// the changed files. Files which no longer exist are reported without their
+// metadata.
+func changeDetails(root containerfs.ContainerFS, changes []archive.Change) ([]containertypes.ContainerChangeDetail, error) {
+	details := make([]containertypes.ContainerChangeDetail, 0, len(changes))
+	for _, c := range changes {
+		d := containertypes.ContainerChangeDetail{
+			Path: c.Path,
+			Kind: uint8(c.Kind),
+		}
+		if c.Kind != archive.ChangeDelete {
+			if err := statChange(root, &d); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+		}
+		details = append(details, d)
+	}
+	return details, nil
+}
+
+// statChange sets the metadata of the changed file of d. The parent
+// directories of the file are resolved in the scope of root, and the file
+// itself is not followed if it is a symbolic link.
+func statChange(root containerfs.ContainerFS, d *containertypes.ContainerChangeDetail) error {
+	dir, err := root.ResolveScopedPath(root.Dir(d.Path), false)
+	if err != nil {
+		return err
+	}
+	p := root.Join(dir, root.Base(d.Path))
+	fi, err := root.Lstat(p)
+	if err != nil {
+		return err
+	}
+	d.Size = fi.Size()
+	d.Mode = fi.Mode()
+
+	switch {
+	case fi.Mode()&os.ModeSymlink != 0:
+		d.Linkname, err = root.Readlink(p)
+		return err
+	case !fi.Mode().IsRegular():
+		return nil
+	}
+
+	dgst, err := fileDigest(root, p, fi, digest.Canonical)
+	if err != nil {
+		return err
+	}
+	d.Digest = dgst.String()
+	return nil
+}
+
+// fileDigest returns the digest with the given algorithm of the content of
+// the regular file p of root, whose information is fi.
+func fileDigest(root containerfs.ContainerFS, p string, fi os.FileInfo, algorithm digest.Algorithm) (digest.Digest, error) {
+	f, err := root.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	// Make sure the file was not replaced since it was stat'ed.
+	if sf, ok := f.(interface{ Stat() (os.FileInfo, error) }); ok {
+		ofi, err := sf.Stat()
+		if err != nil {
+			return "", err
+		}
+		if !os.SameFile(fi, ofi) {
+			return "", errors.New("file " + p + " changed while computing its digest")
+		}
+	}
+	return algorithm.FromReader(f)
+}
@@ -0,0 +1,50 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/containerfs"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+func TestChangeDetails(t *testing.T) {
+	dir, err := ioutil.TempDir("", "changes-")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NilError(t, os.Mkdir(filepath.Join(dir, "etc"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "etc", "hostname"), []byte("test"), 0644))
+	assert.NilError(t, os.Symlink("/etc/passwd", filepath.Join(dir, "etc", "link")))
+	// Symbolic links are resolved in the scope of the root, this one to
+	// a directory which does not exist in the root.
+	assert.NilError(t, os.Symlink(filepath.Join(dir, "etc"), filepath.Join(dir, "escape")))
+
+	details, err := changeDetails(containerfs.NewLocalContainerFS(dir), []archive.Change{
+		{Path: "/etc", Kind: archive.ChangeModify},
+		{Path: "/etc/hostname", Kind: archive.ChangeAdd},
+		{Path: "/etc/link", Kind: archive.ChangeAdd},
+		{Path: "/etc/motd", Kind: archive.ChangeDelete},
+		{Path: "/etc/removed", Kind: archive.ChangeAdd},
+		{Path: "/escape/hostname", Kind: archive.ChangeAdd},
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(details, 6))
+	assert.Check(t, is.Equal(details[0].Mode, os.ModeDir|0755))
+	assert.Check(t, is.DeepEqual(details[1], containertypes.ContainerChangeDetail{
+		Path:   "/etc/hostname",
+		Kind:   uint8(archive.ChangeAdd),
+		Size:   4,
+		Mode:   0644,
+		Digest: "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	}))
+	assert.Check(t, is.Equal(details[2].Linkname, "/etc/passwd"))
+	assert.Check(t, is.DeepEqual(details[3], containertypes.ContainerChangeDetail{Path: "/etc/motd", Kind: uint8(archive.ChangeDelete)}))
+	assert.Check(t, is.DeepEqual(details[4], containertypes.ContainerChangeDetail{Path: "/etc/removed", Kind: uint8(archive.ChangeAdd)}))
+	assert.Check(t, is.DeepEqual(details[5], containertypes.ContainerChangeDetail{Path: "/escape/hostname", Kind: uint8(archive.ChangeAdd)}))
+}
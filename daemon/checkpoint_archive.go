@@ -0,0 +1,349 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/chrootarchive"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// A checkpoint archive holds, in this order, the configuration of the
+// container, the files of the checkpoint, and the changes to the filesystem
+// of the container, as found in its read-write layer.
+const (
+	checkpointArchiveConfig     = "container.json"
+	checkpointArchiveCheckpoint = "checkpoint/"
+	checkpointArchiveRootfs     = "rootfs/"
+)
+
+// checkpointArchiveContainer is the configuration of the container of a
+// checkpoint archive.
+type checkpointArchiveContainer struct {
+	Checkpoint string
+	ImageID    string
+	Config     *containertypes.Config
+	HostConfig *containertypes.HostConfig
+}
+
+// CheckpointExport writes to out a tar archive of a checkpoint of a stopped
+// container, from which CheckpointImport recreates the container.
+func (daemon *Daemon) CheckpointExport(name string, config types.CheckpointExportOptions, out io.Writer) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	checkpointDir, err := getCheckpointDir(config.CheckpointDir, config.CheckpointID, name, container.ID, container.CheckpointDir(), false)
+	if err != nil {
+		return errdefs.NotFound(err)
+	}
+
+	container.Lock()
+	if container.Running {
+		container.Unlock()
+		return errdefs.Conflict(fmt.Errorf("cannot export checkpoint %s of running container %s, its filesystem could change", config.CheckpointID, name))
+	}
+	data, err := json.Marshal(checkpointArchiveContainer{
+		Checkpoint: config.CheckpointID,
+		ImageID:    container.ImageID.String(),
+		Config:     container.Config,
+		HostConfig: container.HostConfig,
+	})
+	container.Unlock()
+	if err != nil {
+		return err
+	}
+
+	rwlayer, err := daemon.imageService.GetLayerByID(container.ID, container.OS)
+	if err != nil {
+		return err
+	}
+	defer daemon.imageService.ReleaseLayer(rwlayer, container.OS)
+
+	changes, err := rwlayer.TarStream()
+	if err != nil {
+		return err
+	}
+	defer changes.Close()
+
+	tw := tar.NewWriter(out)
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     checkpointArchiveConfig,
+		Mode:     0600,
+		Size:     int64(len(data)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return err
+	}
+	if err := writeCheckpointFiles(tw, checkpointDir); err != nil {
+		return err
+	}
+	if err := copyLayerChanges(tw, changes, checkpointArchiveRootfs); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	daemon.LogContainerEvent(container, "checkpoint-export")
+	return nil
+}
+
+// writeCheckpointFiles writes the files of the checkpoint directory dir to
+// the checkpoint archive tw.
+func writeCheckpointFiles(tw *tar.Writer, dir string) error {
+	return filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		if !fi.Mode().IsRegular() && !fi.IsDir() {
+			return fmt.Errorf("unexpected file %s in checkpoint", rel)
+		}
+		hdr, err := tar.FileInfoHeader(fi, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = checkpointArchiveCheckpoint + filepath.ToSlash(rel)
+		if fi.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+}
+
+// CheckpointImport creates a container from a checkpoint archive written by
+// CheckpointExport, and starts it from the checkpoint of the archive. The
+// image of the container must already be present. If the container fails to
+// start, it is left created for inspection.
+func (daemon *Daemon) CheckpointImport(in io.Reader, config types.CheckpointImportOptions) (containertypes.ContainerCreateCreatedBody, error) {
+	tr := tar.NewReader(in)
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != checkpointArchiveConfig {
+		return containertypes.ContainerCreateCreatedBody{}, errdefs.InvalidParameter(errors.New("invalid checkpoint archive: missing container configuration"))
+	}
+	var cfg checkpointArchiveContainer
+	if err := json.NewDecoder(tr).Decode(&cfg); err != nil {
+		return containertypes.ContainerCreateCreatedBody{}, errdefs.InvalidParameter(errors.Wrap(err, "invalid checkpoint archive"))
+	}
+	if cfg.Config == nil || !validCheckpointNamePattern.MatchString(cfg.Checkpoint) {
+		return containertypes.ContainerCreateCreatedBody{}, errdefs.InvalidParameter(errors.New("invalid checkpoint archive: invalid container configuration"))
+	}
+
+	// The checkpoint can only be restored on the filesystem it was taken
+	// from, keep the image name only if it refers to the same image.
+	img, err := daemon.imageService.GetImage(cfg.ImageID)
+	if err != nil {
+		return containertypes.ContainerCreateCreatedBody{}, errors.Wrapf(err, "image %s of the checkpointed container must be pulled first", cfg.Config.Image)
+	}
+	if named, err := daemon.imageService.GetImage(cfg.Config.Image); err != nil || named.ID() != img.ID() {
+		cfg.Config.Image = img.ID().String()
+	}
+
+	created, err := daemon.ContainerCreate(types.ContainerCreateConfig{
+		Name:       config.Name,
+		Config:     cfg.Config,
+		HostConfig: checkpointHostConfig(cfg.HostConfig),
+	})
+	if err != nil {
+		return created, err
+	}
+
+	if err := daemon.extractCheckpointArchive(created.ID, cfg.Checkpoint, tr); err != nil {
+		if rmErr := daemon.ContainerRm(created.ID, &types.ContainerRmConfig{ForceRemove: true, RemoveVolume: true}); rmErr != nil {
+			logrus.WithError(rmErr).WithField("container", created.ID).Error("failed to remove container of invalid checkpoint archive")
+		}
+		return containertypes.ContainerCreateCreatedBody{}, err
+	}
+
+	if err := daemon.ContainerStart(created.ID, nil, cfg.Checkpoint, ""); err != nil {
+		return created, errors.Wrapf(err, "container %s was imported but could not be started from checkpoint %s", created.ID, cfg.Checkpoint)
+	}
+	return created, nil
+}
+
+// checkpointHostConfig returns the host configuration to create the container
+// of a checkpoint archive with. The archive is not trusted: the settings which
+// refer to the host the archive was exported from, or give the container
+// access to the host, such as bind mounts, devices, added capabilities, security
+// options and namespaces, are left to the defaults of the daemon.
+func checkpointHostConfig(hc *containertypes.HostConfig) *containertypes.HostConfig {
+	if hc == nil {
+		return nil
+	}
+	resources := hc.Resources
+	resources.CgroupParent = ""
+	resources.Devices = nil
+	resources.DeviceCgroupRules = nil
+	resources.BlkioWeightDevice = nil
+	resources.BlkioDeviceReadBps = nil
+	resources.BlkioDeviceWriteBps = nil
+	resources.BlkioDeviceReadIOps = nil
+	resources.BlkioDeviceWriteIOps = nil
+	resources.BlkioDeviceLatencyTarget = nil
+	return &containertypes.HostConfig{
+		Resources:      resources,
+		CapDrop:        hc.CapDrop,
+		RestartPolicy:  hc.RestartPolicy,
+		AutoRemove:     hc.AutoRemove,
+		ReadonlyRootfs: hc.ReadonlyRootfs,
+		Tmpfs:          hc.Tmpfs,
+		ShmSize:        hc.ShmSize,
+		Init:           hc.Init,
+	}
+}
+
+// extractCheckpointArchive extracts the checkpoint and the filesystem
+// changes of the checkpoint archive tr to the container with the given ID.
+func (daemon *Daemon) extractCheckpointArchive(id, checkpoint string, tr *tar.Reader) error {
+	container, err := daemon.GetContainer(id)
+	if err != nil {
+		return err
+	}
+	checkpointDir := filepath.Join(container.CheckpointDir(), checkpoint)
+	if err := os.MkdirAll(checkpointDir, 0700); err != nil {
+		return err
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errdefs.InvalidParameter(errors.Wrap(err, "invalid checkpoint archive"))
+		}
+		if strings.HasPrefix(hdr.Name, checkpointArchiveRootfs) {
+			// The filesystem changes come last, and are applied as a
+			// layer from the rest of the archive.
+			return daemon.applyRootfsChanges(container.ID, hdr, tr)
+		}
+		p, err := checkpointArchivePath(checkpointDir, hdr.Name)
+		if err != nil {
+			return errdefs.InvalidParameter(err)
+		}
+		if err := extractCheckpointFile(p, hdr, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// checkpointArchivePath returns the path in the checkpoint directory dir of
+// the checkpoint file name of a checkpoint archive.
+func checkpointArchivePath(dir, name string) (string, error) {
+	if !strings.HasPrefix(name, checkpointArchiveCheckpoint) {
+		return "", fmt.Errorf("invalid checkpoint archive: unexpected file %s", name)
+	}
+	rel := path.Clean("/" + strings.TrimPrefix(name, checkpointArchiveCheckpoint))
+	if rel == "/" {
+		return "", fmt.Errorf("invalid checkpoint archive: unexpected file %s", name)
+	}
+	return filepath.Join(dir, filepath.FromSlash(rel)), nil
+}
+
+func extractCheckpointFile(p string, hdr *tar.Header, r io.Reader) error {
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(p, 0700)
+	case tar.TypeReg, tar.TypeRegA:
+		if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(hdr.Mode)&0600)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(f, r)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		return err
+	default:
+		return errdefs.InvalidParameter(fmt.Errorf("invalid checkpoint archive: unexpected file type of %s", hdr.Name))
+	}
+}
+
+// applyRootfsChanges applies the filesystem changes of a checkpoint archive
+// to the container with the given ID. hdr is the header of the first change,
+// and tr the archive positioned at its content.
+func (daemon *Daemon) applyRootfsChanges(id string, hdr *tar.Header, tr *tar.Reader) error {
+	container, err := daemon.GetContainer(id)
+	if err != nil {
+		return err
+	}
+	if err := daemon.Mount(container); err != nil {
+		return err
+	}
+	defer daemon.Unmount(container)
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(copyRootfsChanges(tar.NewWriter(pw), hdr, tr))
+	}()
+	defer pr.Close()
+
+	_, err = chrootarchive.ApplyUncompressedLayer(container.BaseFS.Path(), pr, &archive.TarOptions{
+		UIDMaps: daemon.idMappings.UIDs(),
+		GIDMaps: daemon.idMappings.GIDs(),
+	})
+	return err
+}
+
+// copyRootfsChanges copies the filesystem changes of a checkpoint archive,
+// starting with hdr, from tr to tw without their path prefix.
+func copyRootfsChanges(tw *tar.Writer, hdr *tar.Header, tr *tar.Reader) error {
+	for {
+		if !strings.HasPrefix(hdr.Name, checkpointArchiveRootfs) {
+			return fmt.Errorf("invalid checkpoint archive: unexpected file %s", hdr.Name)
+		}
+		hdr.Name = strings.TrimPrefix(hdr.Name, checkpointArchiveRootfs)
+		if hdr.Typeflag == tar.TypeLink {
+			hdr.Linkname = strings.TrimPrefix(hdr.Linkname, checkpointArchiveRootfs)
+		}
+		if hdr.Name != "" {
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := io.Copy(tw, tr); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if hdr, err = tr.Next(); err == io.EOF {
+			return tw.Close()
+		} else if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+func TestCheckpointArchivePath(t *testing.T) {
+	dir := filepath.FromSlash("/var/lib/docker/containers/id/checkpoints/cp")
+
+	p, err := checkpointArchivePath(dir, "checkpoint/images/pages-1.img")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(p, filepath.Join(dir, "images", "pages-1.img")))
+
+	p, err = checkpointArchivePath(dir, "checkpoint/../../../etc/passwd")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(p, filepath.Join(dir, "etc", "passwd")))
+
+	_, err = checkpointArchivePath(dir, "checkpoint/")
+	assert.Check(t, is.ErrorContains(err, "unexpected file"))
+
+	_, err = checkpointArchivePath(dir, "etc/passwd")
+	assert.Check(t, is.ErrorContains(err, "unexpected file"))
+}
+
+func TestCheckpointHostConfig(t *testing.T) {
+	hc := checkpointHostConfig(&containertypes.HostConfig{
+		Binds:       []string{"/:/host"},
+		Privileged:  true,
+		CapAdd:      []string{"SYS_ADMIN"},
+		CapDrop:     []string{"NET_RAW"},
+		NetworkMode: "host",
+		PidMode:     "host",
+		SecurityOpt: []string{"seccomp=unconfined"},
+		Resources: containertypes.Resources{
+			Memory:       1 << 30,
+			CgroupParent: "/system.slice",
+			Devices:      []containertypes.DeviceMapping{{PathOnHost: "/dev/sda", PathInContainer: "/dev/sda", CgroupPermissions: "rwm"}},
+		},
+		RestartPolicy: containertypes.RestartPolicy{Name: "always"},
+	})
+	assert.Check(t, is.DeepEqual(hc, &containertypes.HostConfig{
+		CapDrop:       []string{"NET_RAW"},
+		Resources:     containertypes.Resources{Memory: 1 << 30},
+		RestartPolicy: containertypes.RestartPolicy{Name: "always"},
+	}))
+
+	assert.Check(t, is.Nil(checkpointHostConfig(nil)))
+}
+
+func TestRootfsChangesRoundTrip(t *testing.T) {
+	var changes bytes.Buffer
+	tw := tar.NewWriter(&changes)
+	for _, hdr := range []*tar.Header{
+		{Name: "etc/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "etc/hostname", Typeflag: tar.TypeReg, Mode: 0644, Size: 4},
+		{Name: "etc/hostname.link", Typeflag: tar.TypeLink, Linkname: "etc/hostname"},
+		{Name: "etc/.wh.motd", Typeflag: tar.TypeReg},
+	} {
+		assert.NilError(t, tw.WriteHeader(hdr))
+		if hdr.Size > 0 {
+			_, err := tw.Write([]byte("test"))
+			assert.NilError(t, err)
+		}
+	}
+	assert.NilError(t, tw.Close())
+	expected := changes.Bytes()
+
+	var archive bytes.Buffer
+	tw = tar.NewWriter(&archive)
+	assert.NilError(t, copyLayerChanges(tw, bytes.NewReader(expected), checkpointArchiveRootfs))
+	assert.NilError(t, tw.Close())
+
+	var names []string
+	tr := tar.NewReader(bytes.NewReader(archive.Bytes()))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+		names = append(names, hdr.Name)
+		if hdr.Typeflag == tar.TypeLink {
+			assert.Check(t, is.Equal(hdr.Linkname, "rootfs/etc/hostname"))
+		}
+	}
+	assert.Check(t, is.DeepEqual(names, []string{"rootfs/etc/", "rootfs/etc/hostname", "rootfs/etc/hostname.link", "rootfs/etc/.wh.motd"}))
+
+	tr = tar.NewReader(bytes.NewReader(archive.Bytes()))
+	hdr, err := tr.Next()
+	assert.NilError(t, err)
+	var restored bytes.Buffer
+	assert.NilError(t, copyRootfsChanges(tar.NewWriter(&restored), hdr, tr))
+	actual, err := ioutil.ReadAll(&restored)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(actual, expected))
+}
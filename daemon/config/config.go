@@ -12,12 +12,14 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"time"
 
 	daemondiscovery "github.com/docker/docker/daemon/discovery"
 	"github.com/docker/docker/opts"
 	"github.com/docker/docker/pkg/authorization"
 	"github.com/docker/docker/pkg/discovery"
 	"github.com/docker/docker/registry"
+	units "github.com/docker/go-units"
 	"github.com/imdario/mergo"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
@@ -77,6 +79,48 @@ type NetworkConfig struct {
 	DefaultAddressPools opts.PoolsOpt `json:"default-address-pools,omitempty"`
 }
 
+// ImageGCConfig stores the configuration of the image garbage collector.
+// It includes json tags to deserialize configuration from a file
+// using the same names that the flags in the command line use.
+type ImageGCConfig struct {
+	// HighWatermark is the disk usage, in percent of the filesystem
+	// holding the data-root, above which unused images are evicted.
+	// Garbage collection is disabled when it is 0.
+	HighWatermark int `json:"image-gc-high-watermark,omitempty"`
+	// LowWatermark is the disk usage, in percent, at which eviction stops.
+	LowWatermark int `json:"image-gc-low-watermark,omitempty"`
+	// MinAge is the duration an image must have been unused before it
+	// can be evicted.
+	MinAge string `json:"image-gc-min-age,omitempty"`
+	// Interval is the duration between two disk usage checks.
+	Interval string `json:"image-gc-interval,omitempty"`
+	// KeepLabels protects images carrying one of the labels, given as
+	// "key" or "key=value", from eviction.
+	KeepLabels []string `json:"image-gc-keep-labels,omitempty"`
+	// KeepReferences protects images referenced by one of the references
+	// from eviction. A repository name without tag or digest protects all
+	// the images of the repository.
+	KeepReferences []string `json:"image-gc-keep-references,omitempty"`
+}
+
+// VolumeLifecycleConfig stores the lifecycle policies of the volume reaper.
+// It includes json tags to deserialize configuration from a file
+// using the same names that the flags in the command line use.
+type VolumeLifecycleConfig struct {
+	// AnonymousTTL is the duration after which an anonymous volume is
+	// removed once no container references it. Anonymous volumes are kept
+	// when it is empty.
+	AnonymousTTL string `json:"volume-anonymous-ttl,omitempty"`
+	// ProjectLabel is the label whose value groups volumes in projects.
+	ProjectLabel string `json:"volume-project-label,omitempty"`
+	// ProjectMaxSize is the maximum total size of the volumes of a project,
+	// such as "10GB". Unreferenced volumes of a project over its maximum
+	// size are removed, least recently used first.
+	ProjectMaxSize string `json:"volume-project-max-size,omitempty"`
+	// ReaperInterval is the duration between two runs of the reaper.
+	ReaperInterval string `json:"volume-reaper-interval,omitempty"`
+}
+
 // CommonTLSOptions defines TLS configuration for the daemon server.
 // It includes json tags to deserialize configuration from a file
 // using the same names that the flags in the command line use.
@@ -177,7 +221,22 @@ type CommonConfig struct {
 
 	MetricsAddress string `json:"metrics-addr"`
 
+	// MetricsContainerLabels are the container labels added as labels to
+	// the per-container metrics.
+	MetricsContainerLabels []string `json:"metrics-container-labels,omitempty"`
+
+	// SignaturePolicy is the path to the signature policy file. When set,
+	// images from the repositories covered by the policy must be signed
+	// by one of the trusted keys to be pulled or run.
+	SignaturePolicy string `json:"signature-policy,omitempty"`
+
+	// VolumeEncryptionKeyFile is the path to the file of the key wrapping
+	// the keys of the encrypted local volumes.
+	VolumeEncryptionKeyFile string `json:"volume-encryption-key-file,omitempty"`
+
 	LogConfig
+	ImageGCConfig
+	VolumeLifecycleConfig
 	BridgeConfig // bridgeConfig holds bridge network specific configuration.
 	NetworkConfig
 	registry.ServiceOptions
@@ -540,6 +599,14 @@ func Validate(config *Config) error {
 		return err
 	}
 
+	if err := ValidateImageGC(config.ImageGCConfig); err != nil {
+		return err
+	}
+
+	if err := ValidateVolumeLifecycle(config.VolumeLifecycleConfig); err != nil {
+		return err
+	}
+
 	if defaultRuntime := config.GetDefaultRuntimeName(); defaultRuntime != "" && defaultRuntime != StockRuntimeName {
 		runtimes := config.GetAllRuntimes()
 		if _, ok := runtimes[defaultRuntime]; !ok {
@@ -551,6 +618,56 @@ func Validate(config *Config) error {
 	return config.ValidatePlatformConfig()
 }
 
+// ValidateImageGC validates the configuration of the image garbage
+// collector.
+func ValidateImageGC(config ImageGCConfig) error {
+	if config.HighWatermark == 0 {
+		return nil
+	}
+	if config.HighWatermark < 0 || config.HighWatermark > 100 {
+		return fmt.Errorf("invalid image-gc-high-watermark: %d: must be between 1 and 100", config.HighWatermark)
+	}
+	if config.LowWatermark <= 0 || config.LowWatermark >= config.HighWatermark {
+		return fmt.Errorf("invalid image-gc-low-watermark: %d: must be between 1 and image-gc-high-watermark (%d)", config.LowWatermark, config.HighWatermark)
+	}
+	for name, value := range map[string]string{"image-gc-min-age": config.MinAge, "image-gc-interval": config.Interval} {
+		if value == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err != nil || d < 0 {
+			return fmt.Errorf("invalid %s: %s", name, value)
+		}
+	}
+	for _, label := range config.KeepLabels {
+		if strings.TrimSpace(label) == "" || strings.HasPrefix(label, "=") {
+			return fmt.Errorf("invalid image-gc-keep-labels: %q", label)
+		}
+	}
+	return nil
+}
+
+// ValidateVolumeLifecycle validates the lifecycle policies of the volume
+// reaper.
+func ValidateVolumeLifecycle(config VolumeLifecycleConfig) error {
+	for name, value := range map[string]string{"volume-anonymous-ttl": config.AnonymousTTL, "volume-reaper-interval": config.ReaperInterval} {
+		if value == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err != nil || d < 0 {
+			return fmt.Errorf("invalid %s: %s", name, value)
+		}
+	}
+	if config.ProjectMaxSize != "" {
+		if size, err := units.RAMInBytes(config.ProjectMaxSize); err != nil || size < 0 {
+			return fmt.Errorf("invalid volume-project-max-size: %s", config.ProjectMaxSize)
+		}
+		if config.ProjectLabel == "" {
+			return fmt.Errorf("volume-project-max-size requires a volume-project-label")
+		}
+	}
+	return nil
+}
+
 // ModifiedDiscoverySettings returns whether the discovery configuration has been modified or not.
 func ModifiedDiscoverySettings(config *Config, backendType, advertise string, clusterOpts map[string]string) bool {
 	if config.ClusterStore != backendType || config.ClusterAdvertise != advertise {
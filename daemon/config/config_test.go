@@ -310,6 +310,55 @@ func TestValidateConfigurationErrors(t *testing.T) {
 				},
 			},
 		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					ImageGCConfig: ImageGCConfig{HighWatermark: 101, LowWatermark: 80},
+				},
+			},
+		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					ImageGCConfig: ImageGCConfig{HighWatermark: 90},
+				},
+			},
+		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					ImageGCConfig: ImageGCConfig{HighWatermark: 90, LowWatermark: 90},
+				},
+			},
+		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					ImageGCConfig: ImageGCConfig{HighWatermark: 90, LowWatermark: 80, MinAge: "1 day"},
+				},
+			},
+		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					VolumeLifecycleConfig: VolumeLifecycleConfig{AnonymousTTL: "-1h"},
+				},
+			},
+		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					VolumeLifecycleConfig: VolumeLifecycleConfig{ProjectLabel: "project", ProjectMaxSize: "ten"},
+				},
+			},
+		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					VolumeLifecycleConfig: VolumeLifecycleConfig{ProjectMaxSize: "10g"},
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		err := Validate(tc.config)
@@ -381,6 +430,20 @@ func TestValidateConfiguration(t *testing.T) {
 				},
 			},
 		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					ImageGCConfig: ImageGCConfig{HighWatermark: 90, LowWatermark: 80, MinAge: "24h", Interval: "1m"},
+				},
+			},
+		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					VolumeLifecycleConfig: VolumeLifecycleConfig{AnonymousTTL: "24h", ProjectLabel: "project", ProjectMaxSize: "10g", ReaperInterval: "1m"},
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		err := Validate(tc.config)
@@ -607,10 +607,13 @@ func hasUserDefinedIPAddress(epConfig *networktypes.EndpointSettings) bool {
 }
 
 // User specified ip address is acceptable only for networks with user specified subnets.
-func validateNetworkingConfig(n libnetwork.Network, epConfig *networktypes.EndpointSettings) error {
+func validateNetworkingConfig(n libnetwork.Network, epConfig *networktypes.EndpointSettings, iptables bool) error {
 	if n == nil || epConfig == nil {
 		return nil
 	}
+	if err := validateEndpointPolicy(n, epConfig, iptables); err != nil {
+		return err
+	}
 	if !hasUserDefinedIPAddress(epConfig) {
 		return nil
 	}
@@ -683,7 +686,7 @@ func (daemon *Daemon) updateNetworkConfig(container *container.Container, n libn
 		}
 	}
 
-	if err := validateNetworkingConfig(n, endpointConfig); err != nil {
+	if err := validateNetworkingConfig(n, endpointConfig, daemon.managesIPTables()); err != nil {
 		return err
 	}
 
@@ -787,9 +790,19 @@ func (daemon *Daemon) connectToNetwork(container *container.Container, idOrName
 		return err
 	}
 
-	if err := ep.Join(sb, joinOptions...); err != nil {
+	if err = ep.Join(sb, joinOptions...); err != nil {
 		return err
 	}
+	defer func() {
+		if err != nil {
+			if e := daemon.removeEndpointPolicy(ep.ID()); e != nil {
+				logrus.WithError(e).Warnf("Could not remove the egress policy of the container on network %s", idOrName)
+			}
+			if e := ep.Leave(sb); e != nil {
+				logrus.WithError(e).Warnf("Could not rollback container join to network %s", idOrName)
+			}
+		}
+	}()
 
 	if !container.Managed {
 		// add container name/alias to DNS
@@ -802,6 +815,12 @@ func (daemon *Daemon) connectToNetwork(container *container.Container, idOrName
 		return fmt.Errorf("Updating join info failed: %v", err)
 	}
 
+	if hasEndpointPolicy(endpointConfig) {
+		if err = daemon.applyEndpointPolicy(ep, endpointConfig); err != nil {
+			return err
+		}
+	}
+
 	container.NetworkSettings.Ports = getPortMapInfo(sb)
 
 	daemon.LogNetworkEventWithAttributes(n, "connect", map[string]string{"container": container.ID})
@@ -858,6 +877,12 @@ func (daemon *Daemon) disconnectFromNetwork(container *container.Container, n li
 		return fmt.Errorf("container %s is not connected to network %s", container.ID, n.Name())
 	}
 
+	if settings, ok := container.NetworkSettings.Networks[n.Name()]; ok && settings.EndpointSettings != nil && settings.EgressPolicy != nil {
+		if err := daemon.removeEndpointPolicy(ep.ID()); err != nil {
+			logrus.WithError(err).Warnf("failed to remove the egress policy of container %s on network %s", container.ID, n.Name())
+		}
+	}
+
 	if err := ep.Leave(sbox); err != nil {
 		return fmt.Errorf("container %s failed to leave network %s: %v", container.ID, n.Name(), err)
 	}
@@ -971,6 +996,11 @@ func (daemon *Daemon) releaseNetwork(container *container.Container) {
 			continue
 		}
 
+		if epSettings.EgressPolicy != nil && epSettings.EndpointID != "" {
+			if err := daemon.removeEndpointPolicy(epSettings.EndpointID); err != nil {
+				logrus.WithError(err).Warnf("failed to remove the egress policy of container %s on network %s", container.ID, n)
+			}
+		}
 		cleanOperationalData(epSettings)
 	}
 
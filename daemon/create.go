@@ -58,7 +58,7 @@ func (daemon *Daemon) containerCreate(params types.ContainerCreateConfig, manage
 		return containertypes.ContainerCreateCreatedBody{Warnings: warnings}, errdefs.InvalidParameter(err)
 	}
 
-	err = verifyNetworkingConfig(params.NetworkingConfig)
+	err = verifyNetworkingConfig(params.NetworkingConfig, daemon.managesIPTables())
 	if err != nil {
 		return containertypes.ContainerCreateCreatedBody{Warnings: warnings}, errdefs.InvalidParameter(err)
 	}
@@ -95,6 +95,9 @@ func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool) (
 		if err != nil {
 			return nil, err
 		}
+		if err := daemon.imageService.VerifyImageSignature(params.Config.Image, img); err != nil {
+			return nil, err
+		}
 		if img.OS != "" {
 			os = img.OS
 		} else {
@@ -192,6 +195,7 @@ func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool) (
 		return nil, err
 	}
 	stateCtr.set(container.ID, "stopped")
+	daemon.imageService.TouchImage(container.ImageID)
 	daemon.LogContainerEvent(container, "create")
 	return container, nil
 }
@@ -291,7 +295,7 @@ func (daemon *Daemon) mergeAndVerifyConfig(config *containertypes.Config, img *i
 
 // Checks if the client set configurations for more than one network while creating a container
 // Also checks if the IPAMConfig is valid
-func verifyNetworkingConfig(nwConfig *networktypes.NetworkingConfig) error {
+func verifyNetworkingConfig(nwConfig *networktypes.NetworkingConfig, iptables bool) error {
 	if nwConfig == nil || len(nwConfig.EndpointsConfig) == 0 {
 		return nil
 	}
@@ -300,6 +304,9 @@ func verifyNetworkingConfig(nwConfig *networktypes.NetworkingConfig) error {
 			if v == nil {
 				return errdefs.InvalidParameter(errors.Errorf("no EndpointSettings for %s", k))
 			}
+			if err := validateEndpointPolicy(nil, v, iptables); err != nil {
+				return err
+			}
 			if v.IPAMConfig != nil {
 				if v.IPAMConfig.IPv4Address != "" && net.ParseIP(v.IPAMConfig.IPv4Address).To4() == nil {
 					return errors.Errorf("invalid IPv4 address: %s", v.IPAMConfig.IPv4Address)
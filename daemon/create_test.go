@@ -16,6 +16,6 @@ func TestVerifyNetworkingConfig(t *testing.T) {
 	nwConfig := &network.NetworkingConfig{
 		EndpointsConfig: endpoints,
 	}
-	err := verifyNetworkingConfig(nwConfig)
+	err := verifyNetworkingConfig(nwConfig, true)
 	assert.Check(t, errdefs.IsInvalidParameter(err))
 }
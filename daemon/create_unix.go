@@ -10,8 +10,12 @@ import (
 	containertypes "github.com/docker/docker/api/types/container"
 	mounttypes "github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/volume"
 	"github.com/opencontainers/selinux/go-selinux/label"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -46,7 +50,7 @@ func (daemon *Daemon) createContainerOSSpecificSettings(container *container.Con
 			return fmt.Errorf("cannot mount volume over existing file, file exists %s", path)
 		}
 
-		v, err := daemon.volumes.CreateWithRef(name, hostConfig.VolumeDriver, container.ID, nil, nil)
+		v, err := daemon.volumes.CreateWithRef(name, hostConfig.VolumeDriver, container.ID, nil, map[string]string{volume.AnonymousLabel: ""})
 		if err != nil {
 			return err
 		}
@@ -60,7 +64,8 @@ func (daemon *Daemon) createContainerOSSpecificSettings(container *container.Con
 	return daemon.populateVolumes(container)
 }
 
-// populateVolumes copies data from the container's rootfs into the volume for non-binds.
+// populateVolumes copies data from the container's rootfs into the volume for non-binds,
+// and sets the ownership requested for the volume, if it is used for the first time.
 // this is only called when the container is created.
 func (daemon *Daemon) populateVolumes(c *container.Container) error {
 	for _, mnt := range c.MountPoints {
@@ -68,14 +73,50 @@ func (daemon *Daemon) populateVolumes(c *container.Container) error {
 			continue
 		}
 
-		if mnt.Type != mounttypes.TypeVolume || !mnt.CopyData {
+		if mnt.Type != mounttypes.TypeVolume {
 			continue
 		}
 
-		logrus.Debugf("copying image data from %s:%s, to %s", c.ID, mnt.Destination, mnt.Name)
-		if err := c.CopyImagePathContent(mnt.Volume, mnt.Destination); err != nil {
+		uid, gid, perm := mnt.Ownership()
+		if !mnt.CopyData && uid == -1 && gid == -1 && perm == 0 {
+			continue
+		}
+		uid, gid, err := daemon.volumeOwnerToHost(uid, gid)
+		if err != nil {
+			return errdefs.InvalidParameter(errors.Wrapf(err, "invalid owner of volume %s", mnt.Name))
+		}
+
+		logrus.Debugf("initializing volume %s from %s:%s", mnt.Name, c.ID, mnt.Destination)
+		if err := c.InitializeVolume(mnt.Volume, mnt.Destination, mnt.CopyData, uid, gid, perm); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// volumeOwnerToHost returns the host uid and gid of the container uid and
+// gid of the owner of a volume, which are -1 if they are not set.
+func (daemon *Daemon) volumeOwnerToHost(uid, gid int) (int, int, error) {
+	if daemon.idMappings.Empty() || (uid == -1 && gid == -1) {
+		return uid, gid, nil
+	}
+
+	pair := idtools.IDPair{UID: uid, GID: gid}
+	if uid == -1 {
+		pair.UID = 0
+	}
+	if gid == -1 {
+		pair.GID = 0
+	}
+	host, err := daemon.idMappings.ToHost(pair)
+	if err != nil {
+		return -1, -1, err
+	}
+	if uid != -1 {
+		uid = host.UID
+	}
+	if gid != -1 {
+		gid = host.GID
+	}
+	return uid, gid, nil
+}
@@ -18,6 +18,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
 	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/swarm"
@@ -36,11 +37,13 @@ import (
 	_ "github.com/docker/docker/daemon/graphdriver/register"
 	"github.com/docker/docker/daemon/stats"
 	dmetadata "github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/distribution/signature"
 	"github.com/docker/docker/dockerversion"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/docker/migrate/v1"
+	"github.com/docker/docker/pkg/directory"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/locker"
 	"github.com/docker/docker/pkg/plugingetter"
@@ -52,9 +55,11 @@ import (
 	refstore "github.com/docker/docker/reference"
 	"github.com/docker/docker/registry"
 	"github.com/docker/docker/runconfig"
+	"github.com/docker/docker/volume"
 	volumedrivers "github.com/docker/docker/volume/drivers"
 	"github.com/docker/docker/volume/local"
 	"github.com/docker/docker/volume/store"
+	units "github.com/docker/go-units"
 	"github.com/docker/libnetwork"
 	"github.com/docker/libnetwork/cluster"
 	nwconfig "github.com/docker/libnetwork/config"
@@ -110,6 +115,8 @@ type Daemon struct {
 
 	diskUsageRunning int32
 	pruneRunning     int32
+	stopImageGC      context.CancelFunc
+	stopVolumeReaper context.CancelFunc
 	hosts            map[string]bool // hosts stores the addresses the daemon is listening on
 	startupDone      chan struct{}
 
@@ -785,7 +792,7 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 	}
 
 	// Configure the volumes driver
-	volStore, err := d.configureVolumes(rootIDs)
+	volStore, err := d.configureVolumes(idMappings)
 	if err != nil {
 		return nil, err
 	}
@@ -831,6 +838,21 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 		logrus.Infof("Graph migration to content-addressability took %.2f seconds", time.Since(migrationStart).Seconds())
 	}
 
+	var signatureVerifier *signature.Verifier
+	if config.SignaturePolicy != "" {
+		policy, err := signature.LoadPolicy(config.SignaturePolicy)
+		if err != nil {
+			return nil, err
+		}
+		storeRoot := policy.Store
+		if storeRoot == "" {
+			storeRoot = filepath.Join(config.Root, "signatures")
+		}
+		if signatureVerifier, err = signature.NewVerifier(policy, signature.NewFileStore(storeRoot)); err != nil {
+			return nil, err
+		}
+	}
+
 	// Discovery is only enabled when the daemon is launched with an address to advertise.  When
 	// initialized, the daemon is registered and we can store the discovery backend as it's read-only
 	if err := d.initDiscovery(config); err != nil {
@@ -853,6 +875,9 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 	d.execCommands = exec.NewStore()
 	d.idIndex = truncindex.NewTruncIndex([]string{})
 	d.statsCollector = d.newStatsCollector(1 * time.Second)
+	if config.MetricsAddress != "" {
+		d.registerContainerMetrics(config.MetricsContainerLabels)
+	}
 
 	d.EventsService = events.New()
 	d.volumes = volStore
@@ -876,11 +901,22 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 		MaxConcurrentUploads:      *config.MaxConcurrentUploads,
 		ReferenceStore:            rs,
 		RegistryService:           registryService,
+		SignatureVerifier:         signatureVerifier,
 		TrustKey:                  trustKey,
 	})
 
 	go d.execCommandGC()
 
+	if config.ImageGCConfig.HighWatermark > 0 {
+		policy, err := imageGCPolicy(config)
+		if err != nil {
+			return nil, err
+		}
+		var ctx context.Context
+		ctx, d.stopImageGC = context.WithCancel(context.Background())
+		go d.imageService.RunGC(ctx, policy)
+	}
+
 	d.containerd, err = containerdRemote.NewClient(ContainersNamespace, d)
 	if err != nil {
 		return nil, err
@@ -889,6 +925,17 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 	if err := d.restore(); err != nil {
 		return nil, err
 	}
+
+	// The reaper is started once the volumes of the restored containers are
+	// referenced, so that they are not considered unreferenced.
+	volumePolicy, err := d.volumeLifecyclePolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	var reaperCtx context.Context
+	reaperCtx, d.stopVolumeReaper = context.WithCancel(context.Background())
+	go d.volumes.RunReaper(reaperCtx, volumePolicy)
+
 	close(d.startupDone)
 
 	// FIXME: this method never returns an error
@@ -973,6 +1020,87 @@ func (daemon *Daemon) ShutdownTimeout() int {
 	return shutdownTimeout
 }
 
+// imageGCPolicy returns the policy of the image garbage collector from the
+// daemon configuration.
+func imageGCPolicy(config *config.Config) (images.GCPolicy, error) {
+	policy := images.GCPolicy{
+		Root:          config.Root,
+		HighWatermark: config.ImageGCConfig.HighWatermark,
+		LowWatermark:  config.ImageGCConfig.LowWatermark,
+		KeepLabels:    config.ImageGCConfig.KeepLabels,
+	}
+	var err error
+	if config.ImageGCConfig.MinAge != "" {
+		if policy.MinAge, err = time.ParseDuration(config.ImageGCConfig.MinAge); err != nil {
+			return policy, err
+		}
+	}
+	if config.ImageGCConfig.Interval != "" {
+		if policy.Interval, err = time.ParseDuration(config.ImageGCConfig.Interval); err != nil {
+			return policy, err
+		}
+	}
+	for _, r := range config.ImageGCConfig.KeepReferences {
+		ref, err := reference.ParseNormalizedNamed(r)
+		if err != nil {
+			return policy, errors.Wrapf(err, "invalid image-gc-keep-references: %s", r)
+		}
+		policy.KeepReferences = append(policy.KeepReferences, ref)
+	}
+	return policy, nil
+}
+
+// volumeLifecyclePolicy returns the lifecycle policy of the volume reaper
+// from the daemon configuration. Removed volumes are logged as destroy
+// events with the reason of their removal.
+func (daemon *Daemon) volumeLifecyclePolicy(config *config.Config) (store.LifecyclePolicy, error) {
+	policy := store.LifecyclePolicy{
+		ProjectLabel: config.VolumeLifecycleConfig.ProjectLabel,
+		Size:         volumeSize,
+		Removed: func(v volume.Volume, reason string) {
+			daemon.LogVolumeEvent(v.Name(), "destroy", map[string]string{"driver": v.DriverName(), "reason": reason})
+		},
+	}
+	var err error
+	if config.VolumeLifecycleConfig.AnonymousTTL != "" {
+		if policy.AnonymousTTL, err = time.ParseDuration(config.VolumeLifecycleConfig.AnonymousTTL); err != nil {
+			return policy, err
+		}
+	}
+	if config.VolumeLifecycleConfig.ProjectMaxSize != "" {
+		if policy.ProjectMaxSize, err = units.RAMInBytes(config.VolumeLifecycleConfig.ProjectMaxSize); err != nil {
+			return policy, err
+		}
+	}
+	if config.VolumeLifecycleConfig.ReaperInterval != "" {
+		if policy.Interval, err = time.ParseDuration(config.VolumeLifecycleConfig.ReaperInterval); err != nil {
+			return policy, err
+		}
+	}
+	return policy, nil
+}
+
+// volumeSize returns the disk usage of the volume v for the volume reaper,
+// or -1 if it is unknown. Only local volumes without mount options are
+// measured, the others may be remote or slow to walk.
+func volumeSize(v volume.Volume) int64 {
+	if v.DriverName() != volume.DefaultDriverName {
+		return -1
+	}
+	if usage, ok := v.Status()["Usage"].(uint64); ok {
+		return int64(usage)
+	}
+	if d, ok := v.(volume.DetailedVolume); ok && len(d.Options()) > 0 {
+		return -1
+	}
+	size, err := directory.Size(context.Background(), v.Path())
+	if err != nil {
+		logrus.WithError(err).Warnf("failed to determine size of volume %v", v.Name())
+		return -1
+	}
+	return size
+}
+
 // Shutdown stops the daemon.
 func (daemon *Daemon) Shutdown() error {
 	daemon.shutdown = true
@@ -1007,12 +1135,20 @@ func (daemon *Daemon) Shutdown() error {
 		})
 	}
 
+	if daemon.stopVolumeReaper != nil {
+		daemon.stopVolumeReaper()
+	}
+
 	if daemon.volumes != nil {
 		if err := daemon.volumes.Shutdown(); err != nil {
 			logrus.Errorf("Error shutting down volume store: %v", err)
 		}
 	}
 
+	if daemon.stopImageGC != nil {
+		daemon.stopImageGC()
+	}
+
 	if daemon.imageService != nil {
 		daemon.imageService.Cleanup()
 	}
@@ -1144,11 +1280,19 @@ func setDefaultMtu(conf *config.Config) {
 	conf.Mtu = config.DefaultNetworkMtu
 }
 
-func (daemon *Daemon) configureVolumes(rootIDs idtools.IDPair) (*store.VolumeStore, error) {
-	volumeDriver, err := local.New(daemon.configStore.Root, rootIDs)
+func (daemon *Daemon) configureVolumes(idMappings *idtools.IDMappings) (*store.VolumeStore, error) {
+	volumeDriver, err := local.New(daemon.configStore.Root, idMappings.RootPair())
 	if err != nil {
 		return nil, err
 	}
+	volumeDriver.SetIDMappings(idMappings)
+	if daemon.configStore.VolumeEncryptionKeyFile != "" {
+		keys, err := local.NewFileKeyProvider(daemon.configStore.VolumeEncryptionKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "error loading volume encryption key")
+		}
+		volumeDriver.SetKeyProvider(keys)
+	}
 	drivers := volumedrivers.NewStore(daemon.PluginStore)
 	if !drivers.Register(volumeDriver, volumeDriver.Name()) {
 		return nil, errors.New("local volume driver could not be registered")
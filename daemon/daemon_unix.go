@@ -409,6 +409,28 @@ func verifyContainerResources(resources *containertypes.Resources, sysInfo *sysi
 		warnings = append(warnings, "You specified a kernel memory limit on a kernel older than 4.0. Kernel memory limits are experimental on older kernels, it won't work as expected and can cause your system to be unstable.")
 		logrus.Warn("You specified a kernel memory limit on a kernel older than 4.0. Kernel memory limits are experimental on older kernels, it won't work as expected and can cause your system to be unstable.")
 	}
+	if resources.MemoryHigh > 0 && !sysInfo.MemoryHigh {
+		warnings = append(warnings, "Your kernel does not support memory high limit or the cgroup v2 unified hierarchy is not used. Limitation discarded.")
+		logrus.Warn("Your kernel does not support memory high limit or the cgroup v2 unified hierarchy is not used. Limitation discarded.")
+		resources.MemoryHigh = 0
+	}
+	if resources.MemoryHigh < 0 || (resources.MemoryHigh > 0 && resources.MemoryHigh < linuxMinMemory) {
+		return warnings, fmt.Errorf("Minimum memory high limit allowed is 4MB")
+	}
+	if resources.Memory > 0 && resources.MemoryHigh > resources.Memory {
+		return warnings, fmt.Errorf("Memory high limit can not be larger than the memory limit")
+	}
+	if resources.MemoryLow > 0 && !sysInfo.MemoryLow {
+		warnings = append(warnings, "Your kernel does not support memory protection or the cgroup v2 unified hierarchy is not used. Memory protection discarded.")
+		logrus.Warn("Your kernel does not support memory protection or the cgroup v2 unified hierarchy is not used. Memory protection discarded.")
+		resources.MemoryLow = 0
+	}
+	if resources.MemoryLow < 0 {
+		return warnings, fmt.Errorf("Invalid value: %d, memory low can not be negative", resources.MemoryLow)
+	}
+	if resources.Memory > 0 && resources.MemoryLow > resources.Memory {
+		return warnings, fmt.Errorf("Memory low can not be larger than the memory limit")
+	}
 	if resources.OomKillDisable != nil && !sysInfo.OomKillDisable {
 		// only produce warnings if the setting wasn't to *disable* the OOM Kill; no point
 		// warning the caller if they already wanted the feature to be off
@@ -507,6 +529,11 @@ func verifyContainerResources(resources *containertypes.Resources, sysInfo *sysi
 	if resources.IOMaximumBandwidth != 0 || resources.IOMaximumIOps != 0 {
 		return warnings, fmt.Errorf("Invalid QoS settings: %s does not support Maximum IO Bandwidth or Maximum IO IOps", runtime.GOOS)
 	}
+	if len(resources.BlkioDeviceLatencyTarget) > 0 && !sysInfo.BlkioLatencyTarget {
+		warnings = append(warnings, "Your kernel does not support Block I/O latency target or the cgroup v2 unified hierarchy is not used. Latency target discarded.")
+		logrus.Warn("Your kernel does not support Block I/O latency target or the cgroup v2 unified hierarchy is not used. Latency target discarded.")
+		resources.BlkioDeviceLatencyTarget = []*pblkiodev.LatencyDevice{}
+	}
 	if len(resources.BlkioWeightDevice) > 0 && !sysInfo.BlkioWeightDevice {
 		warnings = append(warnings, "Your kernel does not support Block I/O weight_device or the cgroup is not mounted. Weight-device discarded.")
 		logrus.Warn("Your kernel does not support Block I/O weight_device or the cgroup is not mounted. Weight-device discarded.")
@@ -809,6 +836,12 @@ func (daemon *Daemon) initNetworkController(config *config.Config, activeSandbox
 		return nil, fmt.Errorf("error obtaining controller instance: %v", err)
 	}
 
+	if config.BridgeConfig.EnableIPTables {
+		if err := setupEgressPolicyChain(controller); err != nil {
+			return nil, fmt.Errorf("error setting up the chain of egress policies: %v", err)
+		}
+	}
+
 	if len(activeSandboxes) > 0 {
 		logrus.Info("There are old running containers, the network config will not take affect")
 		return controller, nil
@@ -1396,6 +1429,17 @@ func (daemon *Daemon) stats(c *container.Container) (*types.StatsJSON, error) {
 		}
 	}
 
+	if len(stats.Hugetlb) > 0 {
+		s.HugetlbStats = make(map[string]types.HugetlbStats, len(stats.Hugetlb))
+		for _, h := range stats.Hugetlb {
+			s.HugetlbStats[h.Pagesize] = types.HugetlbStats{
+				Usage:    h.Usage,
+				MaxUsage: h.Max,
+				Failcnt:  h.Failcnt,
+			}
+		}
+	}
+
 	return s, nil
 }
 
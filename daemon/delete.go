@@ -129,6 +129,12 @@ func (daemon *Daemon) cleanupContainer(container *container.Container, forceRemo
 		container.RWLayer = nil
 	}
 
+	if err := daemon.releaseImageMounts(container); err != nil {
+		err = errors.Wrapf(err, "container %s", container.ID)
+		container.SetRemovalError(err)
+		return err
+	}
+
 	if err := system.EnsureRemoveAll(container.Root); err != nil {
 		e := errors.Wrapf(err, "unable to remove filesystem for %s", container.ID)
 		container.SetRemovalError(e)
@@ -46,7 +46,10 @@ func (daemon *Daemon) SystemDiskUsage(ctx context.Context) (*types.DiskUsage, er
 			return nil, ctx.Err()
 		default:
 		}
-		if d, ok := v.(volume.DetailedVolume); ok {
+		// The disk usage of local volumes with a size quota is accounted by
+		// the filesystem, which is cheaper than walking them.
+		usage, hasQuota := v.Status()["Usage"].(uint64)
+		if d, ok := v.(volume.DetailedVolume); ok && !hasQuota {
 			if len(d.Options()) > 0 {
 				// skip local volumes with mount options since these could have external
 				// mounted filesystems that will be slow to enumerate.
@@ -58,10 +61,13 @@ func (daemon *Daemon) SystemDiskUsage(ctx context.Context) (*types.DiskUsage, er
 		refs := daemon.volumes.Refs(v)
 
 		tv := volumeToAPIType(v)
-		sz, err := directory.Size(ctx, v.Path())
-		if err != nil {
-			logrus.Warnf("failed to determine size of volume %v", name)
-			sz = -1
+		sz := int64(usage)
+		if !hasQuota {
+			sz, err = directory.Size(ctx, v.Path())
+			if err != nil {
+				logrus.Warnf("failed to determine size of volume %v", name)
+				sz = -1
+			}
 		}
 		tv.UsageData = &types.VolumeUsageData{Size: sz, RefCount: int64(len(refs))}
 		allVolumes = append(allVolumes, tv)
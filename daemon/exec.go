@@ -4,7 +4,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -99,6 +103,10 @@ func (d *Daemon) ContainerExecCreate(name string, config *types.ExecConfig) (str
 		return "", err
 	}
 
+	if config.Timeout < 0 {
+		return "", errdefs.InvalidParameter(fmt.Errorf("invalid exec timeout: %d", config.Timeout))
+	}
+
 	cmd := strslice.StrSlice(config.Cmd)
 	entrypoint, args := d.getEntrypointAndArgs(strslice.StrSlice{}, cmd)
 
@@ -123,6 +131,7 @@ func (d *Daemon) ContainerExecCreate(name string, config *types.ExecConfig) (str
 	execConfig.Privileged = config.Privileged
 	execConfig.User = config.User
 	execConfig.WorkingDir = config.WorkingDir
+	execConfig.Timeout = time.Duration(config.Timeout) * time.Second
 
 	linkedEnv, err := d.setupLinkedContainers(cntr)
 	if err != nil {
@@ -255,6 +264,7 @@ func (d *Daemon) ContainerExecStart(ctx context.Context, name string, stdin io.R
 		return translateContainerdStartErr(ec.Entrypoint, ec.SetExitCode, err)
 	}
 	ec.Pid = systemPid
+	ec.StartTimer(func() { d.expireExec(c, ec) })
 	c.ExecCommands.Unlock()
 	ec.Unlock()
 
@@ -284,6 +294,95 @@ func (d *Daemon) ContainerExecStart(ctx context.Context, name string, stdin io.R
 	return nil
 }
 
+// ContainerExecKill sends the signal sig to the process of a running exec
+// instance. If no signal is given (sig 0), SIGKILL is sent.
+func (d *Daemon) ContainerExecKill(name string, sig uint64) error {
+	ec, err := d.getExecConfig(name)
+	if err != nil {
+		return err
+	}
+
+	if sig == 0 {
+		sig = uint64(signal.SignalMap["KILL"])
+	}
+	if !signal.ValidSignalForPlatform(syscall.Signal(sig)) {
+		return errdefs.InvalidParameter(fmt.Errorf("The %s daemon does not support signal %d", runtime.GOOS, sig))
+	}
+
+	ec.Lock()
+	running := ec.Running
+	ec.Unlock()
+	if !running {
+		return errdefs.Conflict(fmt.Errorf("Exec command %s is not running", ec.ID))
+	}
+
+	logrus.Debugf("Sending signal %d to exec %s in container %s", sig, ec.ID, ec.ContainerID)
+	if err := d.containerd.SignalProcess(context.Background(), ec.ContainerID, ec.ID, int(sig)); err != nil {
+		return errdefs.System(errors.Wrapf(err, "Cannot kill exec %s", ec.ID))
+	}
+
+	attributes := map[string]string{
+		"execID": ec.ID,
+		"signal": strconv.Itoa(int(sig)),
+	}
+	d.LogContainerEventWithAttributes(d.containers.Get(ec.ContainerID), "exec_kill", attributes)
+	return nil
+}
+
+// ContainerExecList returns the running exec instances of a container, and
+// the ones that finished recently, in the order they were created.
+func (d *Daemon) ContainerExecList(name string) ([]types.ExecSummary, error) {
+	cntr, err := d.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	execs := []types.ExecSummary{}
+	for _, ec := range d.execCommands.Commands() {
+		if ec.ContainerID != cntr.ID {
+			continue
+		}
+		ec.Lock()
+		execs = append(execs, types.ExecSummary{
+			ID:         ec.ID,
+			Running:    ec.Running,
+			ExitCode:   ec.ExitCode,
+			Pid:        ec.Pid,
+			Entrypoint: ec.Entrypoint,
+			Arguments:  ec.Args,
+			Created:    ec.Created,
+			TimedOut:   ec.TimedOut,
+		})
+		ec.Unlock()
+	}
+	sort.Slice(execs, func(i, j int) bool {
+		return execs[i].Created.Before(execs[j].Created)
+	})
+	return execs, nil
+}
+
+// expireExec kills the process tree of an exec instance whose timeout has
+// elapsed.
+func (d *Daemon) expireExec(c *container.Container, ec *exec.Config) {
+	ec.Lock()
+	if !ec.Running {
+		ec.Unlock()
+		return
+	}
+	ec.TimedOut = true
+	pid := ec.Pid
+	ec.Unlock()
+
+	logrus.Infof("Container %v, exec %v did not exit within its %s timeout - killing it", c.ID, ec.ID, ec.Timeout)
+	attributes := map[string]string{
+		"execID": ec.ID,
+	}
+	d.LogContainerEventWithAttributes(c, "exec_timeout", attributes)
+	if err := d.killExecProcessTree(c, ec, pid); err != nil {
+		logrus.WithError(err).Warnf("failed to kill exec %s in container %s", ec.ID, c.ID)
+	}
+}
+
 // execCommandGC runs a ticker to clean up the daemon references
 // of exec configs that are no longer part of the container.
 func (d *Daemon) execCommandGC() {
@@ -3,6 +3,7 @@ package exec // import "github.com/docker/docker/daemon/exec"
 import (
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/containerd/containerd/cio"
 	"github.com/docker/docker/container/stream"
@@ -33,6 +34,11 @@ type Config struct {
 	WorkingDir   string
 	Env          []string
 	Pid          int
+	Created      time.Time
+	Timeout      time.Duration
+	TimedOut     bool
+
+	timer *time.Timer
 }
 
 // NewConfig initializes the a new exec configuration
@@ -40,6 +46,26 @@ func NewConfig() *Config {
 	return &Config{
 		ID:           stringid.GenerateNonCryptoID(),
 		StreamConfig: stream.NewConfig(),
+		Created:      time.Now().UTC(),
+	}
+}
+
+// StartTimer calls expire once the timeout of the exec has elapsed, unless
+// the timer is stopped first. It does nothing if the exec has no timeout.
+// The caller must hold the lock.
+func (c *Config) StartTimer(expire func()) {
+	if c.Timeout <= 0 {
+		return
+	}
+	c.timer = time.AfterFunc(c.Timeout, expire)
+}
+
+// StopTimer stops the timer started by StartTimer. The caller must hold the
+// lock.
+func (c *Config) StopTimer() {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
 	}
 }
 
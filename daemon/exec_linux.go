@@ -1,11 +1,19 @@
 package daemon // import "github.com/docker/docker/daemon"
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/caps"
 	"github.com/docker/docker/daemon/exec"
 	"github.com/opencontainers/runc/libcontainer/apparmor"
 	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
 )
 
 func (daemon *Daemon) execSetPlatformOpt(c *container.Container, ec *exec.Config, p *specs.Process) error {
@@ -57,3 +65,54 @@ func (daemon *Daemon) execSetPlatformOpt(c *container.Container, ec *exec.Config
 	daemon.setRlimits(&specs.Spec{Process: p}, c)
 	return nil
 }
+
+// killExecProcessTree kills the process of an exec instance, whose host PID
+// is pid, along with all its descendants. The descendants are killed first
+// so that they are not reparented, and left running, when the exec process
+// dies.
+func (daemon *Daemon) killExecProcessTree(c *container.Container, ec *exec.Config, pid int) error {
+	for _, p := range processDescendants(pid) {
+		if err := unix.Kill(p, unix.SIGKILL); err != nil && err != unix.ESRCH {
+			logrus.WithError(err).Debugf("failed to kill process %d of exec %s", p, ec.ID)
+		}
+	}
+	return daemon.containerd.SignalProcess(context.Background(), c.ID, ec.ID, int(unix.SIGKILL))
+}
+
+// processDescendants returns the PIDs of the descendants of the process
+// with the given PID, parents first.
+func processDescendants(pid int) []int {
+	dirs, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return nil
+	}
+	children := make(map[int][]int)
+	for _, dir := range dirs {
+		p, err := strconv.Atoi(dir.Name())
+		if err != nil {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join("/proc", dir.Name(), "stat"))
+		if err != nil {
+			// The process exited in the meantime.
+			if !os.IsNotExist(err) {
+				logrus.WithError(err).Debugf("failed to read the status of process %d", p)
+			}
+			continue
+		}
+		st, err := parseProcStat(data)
+		if err != nil {
+			continue
+		}
+		children[st.ppid] = append(children[st.ppid], p)
+	}
+
+	var descendants []int
+	queue := children[pid]
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = append(queue[1:], children[p]...)
+		descendants = append(descendants, p)
+	}
+	return descendants
+}
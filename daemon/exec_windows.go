@@ -1,6 +1,9 @@
 package daemon // import "github.com/docker/docker/daemon"
 
 import (
+	"context"
+	"syscall"
+
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/exec"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
@@ -14,3 +17,9 @@ func (daemon *Daemon) execSetPlatformOpt(c *container.Container, ec *exec.Config
 	}
 	return nil
 }
+
+// killExecProcessTree kills the process of an exec instance. The compute
+// service terminates the processes it started along with it.
+func (daemon *Daemon) killExecProcessTree(c *container.Container, ec *exec.Config, pid int) error {
+	return daemon.containerd.SignalProcess(context.Background(), c.ID, ec.ID, int(syscall.SIGKILL))
+}
@@ -1,6 +1,7 @@
 package daemon // import "github.com/docker/docker/daemon"
 
 import (
+	"archive/tar"
 	"fmt"
 	"io"
 	"runtime"
@@ -47,6 +48,79 @@ func (daemon *Daemon) ContainerExport(name string, out io.Writer) error {
 	return nil
 }
 
+// ContainerExportChanges writes the changes to the filesystem of the
+// container to the given writer, as a layer tar stream in which deleted
+// files are whiteout files.
+func (daemon *Daemon) ContainerExportChanges(name string, out io.Writer) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	if runtime.GOOS == "windows" && container.IsRunning() {
+		return errdefs.Conflict(fmt.Errorf("Windows does not support exporting the changes of a running container"))
+	}
+
+	if container.IsDead() {
+		err := fmt.Errorf("You cannot export container %s which is Dead", container.ID)
+		return errdefs.Conflict(err)
+	}
+
+	if container.IsRemovalInProgress() {
+		err := fmt.Errorf("You cannot export container %s which is being removed", container.ID)
+		return errdefs.Conflict(err)
+	}
+
+	rwlayer, err := daemon.imageService.GetLayerByID(container.ID, container.OS)
+	if err != nil {
+		return err
+	}
+	defer daemon.imageService.ReleaseLayer(rwlayer, container.OS)
+
+	changes, err := rwlayer.TarStream()
+	if err != nil {
+		return fmt.Errorf("Error exporting changes of container %s: %v", name, err)
+	}
+	defer changes.Close()
+
+	tw := tar.NewWriter(out)
+	if err := copyLayerChanges(tw, changes, ""); err != nil {
+		return fmt.Errorf("Error exporting changes of container %s: %v", name, err)
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("Error exporting changes of container %s: %v", name, err)
+	}
+	daemon.LogContainerEvent(container, "export")
+	return nil
+}
+
+// copyLayerChanges copies the layer tar stream changes of a read-write layer
+// to tw, prefixing the paths of the files with prefix. The owners of the
+// files are already translated to the user namespace of the container by the
+// storage driver.
+func copyLayerChanges(tw *tar.Writer, changes io.Reader, prefix string) error {
+	tr := tar.NewReader(changes)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		hdr.Name = prefix + hdr.Name
+		if hdr.Typeflag == tar.TypeLink {
+			hdr.Linkname = prefix + hdr.Linkname
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+}
+
 func (daemon *Daemon) containerExport(container *container.Container) (arch io.ReadCloser, err error) {
 	if !system.IsOSSupported(container.OS) {
 		return nil, fmt.Errorf("cannot export %s: %s ", container.ID, system.ErrNotSupportedOperatingSystem)
@@ -480,6 +480,10 @@ func (d *Driver) subvolumesDirID(id string) string {
 	return path.Join(d.subvolumesDir(), id)
 }
 
+func (d *Driver) snapshotsDirID(id string) string {
+	return path.Join(d.home, "snapshots", id)
+}
+
 func (d *Driver) quotasDir() string {
 	return path.Join(d.home, "quotas")
 }
@@ -595,6 +599,97 @@ func (d *Driver) setStorageSize(dir string, driver *Driver) error {
 	return subvolLimitQgroup(dir, driver.options.size)
 }
 
+// SetQuota sets the qgroup limit of the subvolume with given id, and saves
+// it to be set again when the subvolume is next used.
+func (d *Driver) SetQuota(id string, size uint64) error {
+	dir := d.subvolumesDirID(id)
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+	driver := &Driver{options: btrfsOptions{size: size}}
+	if err := d.setStorageSize(dir, driver); err != nil {
+		return err
+	}
+	rootUID, rootGID, err := idtools.GetRootUIDGID(d.uidMaps, d.gidMaps)
+	if err != nil {
+		return err
+	}
+	if err := idtools.MkdirAllAndChown(d.quotasDir(), 0700, idtools.IDPair{UID: rootUID, GID: rootGID}); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(d.quotasDirID(id), []byte(fmt.Sprint(size)), 0644)
+}
+
+// Snapshot creates a snapshot subvolume of the subvolume with given id.
+func (d *Driver) Snapshot(id, name string) error {
+	dir := d.subvolumesDirID(id)
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+	rootUID, rootGID, err := idtools.GetRootUIDGID(d.uidMaps, d.gidMaps)
+	if err != nil {
+		return err
+	}
+	if err := idtools.MkdirAllAndChown(d.snapshotsDirID(id), 0700, idtools.IDPair{UID: rootUID, GID: rootGID}); err != nil {
+		return err
+	}
+	return subvolSnapshot(dir, d.snapshotsDirID(id), name)
+}
+
+// Rollback replaces the subvolume with given id by a snapshot of its
+// snapshot subvolume name. The quota of the subvolume is set again when it
+// is next used.
+func (d *Driver) Rollback(id, name string) error {
+	snapshot := path.Join(d.snapshotsDirID(id), name)
+	if _, err := os.Stat(snapshot); err != nil {
+		return err
+	}
+	tmpID := id + "-rollback"
+	if err := subvolSnapshot(snapshot, d.subvolumesDir(), tmpID); err != nil {
+		return err
+	}
+
+	// Call updateQuotaStatus() to invoke status update
+	d.updateQuotaStatus()
+
+	if err := subvolDelete(d.subvolumesDir(), id, d.quotaEnabled); err != nil {
+		subvolDelete(d.subvolumesDir(), tmpID, d.quotaEnabled)
+		return err
+	}
+	return os.Rename(d.subvolumesDirID(tmpID), d.subvolumesDirID(id))
+}
+
+// RemoveSnapshot deletes the snapshot subvolume name of the subvolume with
+// given id.
+func (d *Driver) RemoveSnapshot(id, name string) error {
+	if _, err := os.Stat(path.Join(d.snapshotsDirID(id), name)); err != nil {
+		return err
+	}
+
+	// Call updateQuotaStatus() to invoke status update
+	d.updateQuotaStatus()
+
+	return subvolDelete(d.snapshotsDirID(id), name, d.quotaEnabled)
+}
+
+// removeSnapshots deletes the snapshot subvolumes of the subvolume with
+// given id.
+func (d *Driver) removeSnapshots(id string) error {
+	dir := d.snapshotsDirID(id)
+	fis, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, fi := range fis {
+		if err := subvolDelete(dir, fi.Name(), d.quotaEnabled); err != nil {
+			return err
+		}
+	}
+	return os.Remove(dir)
+}
+
 // Remove the filesystem with given id.
 func (d *Driver) Remove(id string) error {
 	dir := d.subvolumesDirID(id)
@@ -613,6 +708,9 @@ func (d *Driver) Remove(id string) error {
 	// Call updateQuotaStatus() to invoke status update
 	d.updateQuotaStatus()
 
+	if err := d.removeSnapshots(id); err != nil {
+		return err
+	}
 	if err := subvolDelete(d.subvolumesDir(), id, d.quotaEnabled); err != nil {
 		return err
 	}
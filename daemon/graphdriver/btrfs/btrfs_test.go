@@ -28,6 +28,10 @@ func TestBtrfsCreateSnap(t *testing.T) {
 	graphtest.DriverTestCreateSnap(t, "btrfs")
 }
 
+func TestBtrfsSnapshotRollback(t *testing.T) {
+	graphtest.DriverTestSnapshotRollback(t, "btrfs")
+}
+
 func TestBtrfsSubvolDelete(t *testing.T) {
 	d := graphtest.GetDriver(t, "btrfs")
 	if err := d.CreateReadWrite("test", "", nil); err != nil {
@@ -1946,6 +1946,48 @@ func (devices *DeviceSet) AddDevice(hash, baseHash string, storageOpt map[string
 	return nil
 }
 
+// ResizeDevice grows the inactive device with the given hash, and its
+// filesystem, to size bytes. A device cannot be shrunk, as its filesystem
+// cannot.
+func (devices *DeviceSet) ResizeDevice(hash string, size uint64) error {
+	info, err := devices.lookupDeviceWithLock(hash)
+	if err != nil {
+		return err
+	}
+
+	if info.Deleted {
+		return fmt.Errorf("devmapper: Can't resize device %v as it has been marked for deferred deletion", info.Hash)
+	}
+
+	info.lock.Lock()
+	defer info.lock.Unlock()
+
+	devices.Lock()
+	defer devices.Unlock()
+
+	if size < info.Size {
+		return fmt.Errorf("devmapper: Container size cannot be smaller than %s", units.HumanSize(float64(info.Size)))
+	}
+	if size == info.Size {
+		return nil
+	}
+
+	// The device is activated with the size saved in its metadata, make
+	// sure it is not still active with the previous size.
+	if err := devices.deactivateDeviceMode(info, false); err != nil {
+		return err
+	}
+
+	oldSize := info.Size
+	info.Size = size
+	if err := devices.saveMetadata(info); err != nil {
+		info.Size = oldSize
+		return err
+	}
+
+	return devices.growFS(info)
+}
+
 func (devices *DeviceSet) parseStorageOpt(storageOpt map[string]string) (uint64, error) {
 
 	// Read size to change the block device size per container.
@@ -252,6 +252,18 @@ func (d *Driver) Put(id string) error {
 	return err
 }
 
+// SetQuota grows the device with given id, and its filesystem, to size
+// bytes. The device must not be mounted.
+func (d *Driver) SetQuota(id string, size uint64) error {
+	d.locker.Lock(id)
+	defer d.locker.Unlock(id)
+	mp := path.Join(d.home, "mnt", id)
+	if mounted, _ := mount.Mounted(mp); mounted {
+		return fmt.Errorf("devmapper: Can't change the size of device %s while it is mounted", id)
+	}
+	return d.DeviceSet.ResizeDevice(id, size)
+}
+
 // Exists checks to see if the device exists.
 func (d *Driver) Exists(id string) bool {
 	return d.DeviceSet.HasDevice(id)
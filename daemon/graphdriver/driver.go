@@ -139,6 +139,36 @@ type FileGetCloser interface {
 	Close() error
 }
 
+// QuotaDriver is the interface for layered file system drivers that can
+// change the size quota of a read-write layer after it was created, as set
+// with the "size" storage option. It may be implemented by the ProtoDriver
+// of a NaiveDiffDriver.
+type QuotaDriver interface {
+	ProtoDriver
+	// SetQuota sets the size quota of the layer with the given id to size
+	// bytes, whether the layer is mounted or not. It returns an error
+	// implementing errdefs.ErrNotImplemented if the backing filesystem
+	// does not support quotas.
+	SetQuota(id string, size uint64) error
+}
+
+// SnapshotDriver is the interface for layered file system drivers that can
+// save the content of a read-write layer to named snapshots, and roll the
+// layer back to them. The snapshots of a layer are removed with the layer.
+// It may be implemented by the ProtoDriver of a NaiveDiffDriver.
+type SnapshotDriver interface {
+	ProtoDriver
+	// Snapshot saves the content of the unmounted layer with the given id
+	// to a new snapshot with the given name.
+	Snapshot(id, name string) error
+	// Rollback restores the content of the unmounted layer with the given
+	// id from its snapshot with the given name. The snapshot is kept.
+	Rollback(id, name string) error
+	// RemoveSnapshot removes the snapshot with the given name of the layer
+	// with the given id.
+	RemoveSnapshot(id, name string) error
+}
+
 // Checker makes checks on specified filesystems.
 type Checker interface {
 	// IsMounted returns true if the provided path is mounted for the specific checker
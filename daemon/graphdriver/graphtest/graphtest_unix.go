@@ -139,6 +139,42 @@ func DriverTestCreateSnap(t testing.TB, drivername string, driverOptions ...stri
 	verifyBase(t, driver, "Snap")
 }
 
+// DriverTestSnapshotRollback Create a driver and test rolling a layer back
+// to a snapshot of its content.
+func DriverTestSnapshotRollback(t testing.TB, drivername string, driverOptions ...string) {
+	driver := GetDriver(t, drivername, driverOptions...)
+	defer PutDriver(t)
+
+	var pd graphdriver.ProtoDriver = driver.(*Driver).Driver
+	if nd, ok := pd.(*graphdriver.NaiveDiffDriver); ok {
+		pd = nd.ProtoDriver
+	}
+	sd, ok := pd.(graphdriver.SnapshotDriver)
+	assert.Assert(t, ok, "%s does not support snapshots", drivername)
+
+	createBase(t, driver, "SnapshotBase")
+	defer func() {
+		assert.NilError(t, driver.Remove("SnapshotBase"))
+	}()
+
+	err := driver.CreateReadWrite("SnapshotLayer", "SnapshotBase", nil)
+	assert.NilError(t, err)
+	defer func() {
+		assert.NilError(t, driver.Remove("SnapshotLayer"))
+	}()
+
+	assert.NilError(t, sd.Snapshot("SnapshotLayer", "snapshot"))
+
+	assert.NilError(t, addFile(driver, "SnapshotLayer", "added file", []byte("Some data")))
+	assert.NilError(t, removeAll(driver, "SnapshotLayer", "a file"))
+
+	assert.NilError(t, sd.Rollback("SnapshotLayer", "snapshot"))
+	verifyBase(t, driver, "SnapshotLayer")
+
+	assert.NilError(t, sd.RemoveSnapshot("SnapshotLayer", "snapshot"))
+	assert.Check(t, sd.Rollback("SnapshotLayer", "snapshot") != nil)
+}
+
 // DriverTestDeepLayerRead reads a file from a lower layer under a given number of layers
 func DriverTestDeepLayerRead(t testing.TB, layerCount int, drivername string, driverOptions ...string) {
 	driver := GetDriver(t, drivername, driverOptions...)
@@ -18,6 +18,7 @@ import (
 	"sync"
 
 	"github.com/docker/docker/daemon/graphdriver"
+	"github.com/docker/docker/daemon/graphdriver/copy"
 	"github.com/docker/docker/daemon/graphdriver/overlayutils"
 	"github.com/docker/docker/daemon/graphdriver/quota"
 	"github.com/docker/docker/pkg/archive"
@@ -71,10 +72,11 @@ var (
 // that mounts do not fail due to length.
 
 const (
-	driverName = "overlay2"
-	linkDir    = "l"
-	lowerFile  = "lower"
-	maxDepth   = 128
+	driverName   = "overlay2"
+	linkDir      = "l"
+	snapshotsDir = "snapshots"
+	lowerFile    = "lower"
+	maxDepth     = 128
 
 	// idLength represents the number of random characters
 	// which can be used to create the unique link identifier
@@ -444,6 +446,88 @@ func (d *Driver) create(id, parent string, opts *graphdriver.CreateOpts) (retErr
 	return nil
 }
 
+// SetQuota sets the project quota of the layer with the given id. The
+// quota can be set below the space already used by the layer, in which
+// case further writes to the layer fail.
+func (d *Driver) SetQuota(id string, size uint64) error {
+	if !projectQuotaSupported {
+		return quota.ErrQuotaNotSupported
+	}
+	dir := d.dir(id)
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+	return d.quotaCtl.SetQuota(dir, quota.Quota{Size: size})
+}
+
+func (d *Driver) layerSnapshotsDir(id string) string {
+	return path.Join(d.home, snapshotsDir, id)
+}
+
+// Snapshot copies the upper directory of the layer with the given id to
+// its snapshot name.
+func (d *Driver) Snapshot(id, name string) error {
+	d.locker.Lock(id)
+	defer d.locker.Unlock(id)
+	diffDir := path.Join(d.dir(id), "diff")
+	if _, err := os.Stat(diffDir); err != nil {
+		return err
+	}
+
+	rootUID, rootGID, err := idtools.GetRootUIDGID(d.uidMaps, d.gidMaps)
+	if err != nil {
+		return err
+	}
+	snapshot := path.Join(d.layerSnapshotsDir(id), name)
+	if err := idtools.MkdirAllAndChown(path.Dir(snapshot), 0700, idtools.IDPair{UID: rootUID, GID: rootGID}); err != nil {
+		return err
+	}
+	if err := copy.DirCopy(diffDir, snapshot, copy.Content, true); err != nil {
+		system.EnsureRemoveAll(snapshot)
+		return err
+	}
+	return nil
+}
+
+// Rollback replaces the upper directory of the layer with the given id by a
+// copy of its snapshot name. The copy is made in the layer directory so that
+// it is accounted in the quota of the layer.
+func (d *Driver) Rollback(id, name string) error {
+	d.locker.Lock(id)
+	defer d.locker.Unlock(id)
+	snapshot := path.Join(d.layerSnapshotsDir(id), name)
+	if _, err := os.Stat(snapshot); err != nil {
+		return err
+	}
+
+	dir := d.dir(id)
+	tmpDir := path.Join(dir, "rollback")
+	oldDir := path.Join(dir, "rollback-old")
+	if err := system.EnsureRemoveAll(tmpDir); err != nil {
+		return err
+	}
+	if err := copy.DirCopy(snapshot, tmpDir, copy.Content, true); err != nil {
+		system.EnsureRemoveAll(tmpDir)
+		return err
+	}
+	if err := os.Rename(path.Join(dir, "diff"), oldDir); err != nil {
+		system.EnsureRemoveAll(tmpDir)
+		return err
+	}
+	if err := os.Rename(tmpDir, path.Join(dir, "diff")); err != nil {
+		os.Rename(oldDir, path.Join(dir, "diff"))
+		return err
+	}
+	return system.EnsureRemoveAll(oldDir)
+}
+
+// RemoveSnapshot deletes the snapshot name of the layer with the given id.
+func (d *Driver) RemoveSnapshot(id, name string) error {
+	d.locker.Lock(id)
+	defer d.locker.Unlock(id)
+	return system.EnsureRemoveAll(path.Join(d.layerSnapshotsDir(id), name))
+}
+
 // Parse overlay storage options
 func (d *Driver) parseStorageOpt(storageOpt map[string]string, driver *Driver) error {
 	// Read size to set the disk project quota per container
@@ -523,6 +607,9 @@ func (d *Driver) Remove(id string) error {
 		}
 	}
 
+	if err := system.EnsureRemoveAll(d.layerSnapshotsDir(id)); err != nil {
+		return err
+	}
 	if err := system.EnsureRemoveAll(dir); err != nil && !os.IsNotExist(err) {
 		return err
 	}
@@ -78,6 +78,10 @@ func TestOverlayChanges(t *testing.T) {
 	graphtest.DriverTestChanges(t, driverName)
 }
 
+func TestOverlaySnapshotRollback(t *testing.T) {
+	graphtest.DriverTestSnapshotRollback(t, driverName)
+}
+
 func TestOverlayTeardown(t *testing.T) {
 	graphtest.PutDriver(t)
 }
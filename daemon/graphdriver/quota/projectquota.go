@@ -56,6 +56,7 @@ import (
 	"io/ioutil"
 	"path"
 	"path/filepath"
+	"sync"
 	"unsafe"
 
 	rsystem "github.com/opencontainers/runc/libcontainer/system"
@@ -71,11 +72,25 @@ type Quota struct {
 // Control - Context to be used by storage driver (e.g. overlay)
 // who wants to apply project quotas to container dirs
 type Control struct {
+	mu                sync.Mutex
 	backingFsBlockDev string
-	nextProjectID     uint32
+	projectIDs        *projectIDs
 	quotas            map[string]uint32
 }
 
+// projectIDs - the next project id to be used on a filesystem. It is shared
+// by the controls of all the directories of the filesystem, such as the homes
+// of the storage driver and of the local volumes, so that they never assign
+// the same project id.
+type projectIDs struct {
+	next uint32
+}
+
+var (
+	projectIDsMu       sync.Mutex
+	projectIDsByDevice = make(map[uint64]*projectIDs)
+)
+
 // NewControl - initialize project quota support.
 // Test to make sure that quota can be set on a test dir and find
 // the first project id to be used for the next container create.
@@ -147,29 +162,64 @@ func NewControl(basePath string) (*Control, error) {
 
 	q := Control{
 		backingFsBlockDev: backingFsBlockDev,
-		nextProjectID:     minProjectID + 1,
 		quotas:            make(map[string]uint32),
 	}
 
 	//
 	// get first project id to be used for next container
 	//
-	err = q.findNextProjectID(basePath)
+	nextProjectID, err := q.findNextProjectID(basePath, minProjectID+1)
+	if err != nil {
+		return nil, err
+	}
+	q.projectIDs, err = getProjectIDs(basePath, nextProjectID)
 	if err != nil {
 		return nil, err
 	}
 
-	logrus.Debugf("NewControl(%s): nextProjectID = %d", basePath, q.nextProjectID)
+	logrus.Debugf("NewControl(%s): nextProjectID = %d", basePath, nextProjectID)
 	return &q, nil
 }
 
+// getProjectIDs - get the next project id shared by the controls of the
+// filesystem of basePath, making sure that it is at least nextProjectID
+func getProjectIDs(basePath string, nextProjectID uint32) (*projectIDs, error) {
+	var stat unix.Stat_t
+	if err := unix.Stat(basePath, &stat); err != nil {
+		return nil, err
+	}
+
+	projectIDsMu.Lock()
+	defer projectIDsMu.Unlock()
+	ids, ok := projectIDsByDevice[uint64(stat.Dev)]
+	if !ok {
+		ids = &projectIDs{}
+		projectIDsByDevice[uint64(stat.Dev)] = ids
+	}
+	if ids.next < nextProjectID {
+		ids.next = nextProjectID
+	}
+	return ids, nil
+}
+
+// allocate - reserve the next project id of the filesystem
+func (ids *projectIDs) allocate() uint32 {
+	projectIDsMu.Lock()
+	defer projectIDsMu.Unlock()
+	projectID := ids.next
+	ids.next++
+	return projectID
+}
+
 // SetQuota - assign a unique project id to directory and set the quota limits
 // for that project id
 func (q *Control) SetQuota(targetPath string, quota Quota) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
 
 	projectID, ok := q.quotas[targetPath]
 	if !ok {
-		projectID = q.nextProjectID
+		projectID = q.projectIDs.allocate()
 
 		//
 		// assign project id to new container directory
@@ -180,7 +230,6 @@ func (q *Control) SetQuota(targetPath string, quota Quota) error {
 		}
 
 		q.quotas[targetPath] = projectID
-		q.nextProjectID++
 	}
 
 	//
@@ -217,8 +266,40 @@ func setProjectQuota(backingFsBlockDev string, projectID uint32, quota Quota) er
 
 // GetQuota - get the quota limits of a directory that was configured with SetQuota
 func (q *Control) GetQuota(targetPath string, quota *Quota) error {
+	var d C.fs_disk_quota_t
+	if err := q.getDiskQuota(targetPath, &d); err != nil {
+		return err
+	}
+	quota.Size = uint64(d.d_blk_hardlimit) * 512
+
+	return nil
+}
 
+// GetUsage - get the disk space used by a directory that was configured
+// with SetQuota, as accounted for its project id
+func (q *Control) GetUsage(targetPath string) (uint64, error) {
+	var d C.fs_disk_quota_t
+	if err := q.getDiskQuota(targetPath, &d); err != nil {
+		return 0, err
+	}
+	return uint64(d.d_bcount) * 512, nil
+}
+
+// RemoveQuota - forget the project id of a directory that was configured
+// with SetQuota and is being removed, so that a new directory at the same
+// path is assigned a new project id
+func (q *Control) RemoveQuota(targetPath string) {
+	q.mu.Lock()
+	delete(q.quotas, targetPath)
+	q.mu.Unlock()
+}
+
+// getDiskQuota - get the quota of the project id of a directory that was
+// configured with SetQuota
+func (q *Control) getDiskQuota(targetPath string, d *C.fs_disk_quota_t) error {
+	q.mu.Lock()
 	projectID, ok := q.quotas[targetPath]
+	q.mu.Unlock()
 	if !ok {
 		return fmt.Errorf("quota not found for path : %s", targetPath)
 	}
@@ -226,19 +307,16 @@ func (q *Control) GetQuota(targetPath string, quota *Quota) error {
 	//
 	// get the quota limit for the container's project id
 	//
-	var d C.fs_disk_quota_t
-
 	var cs = C.CString(q.backingFsBlockDev)
 	defer C.free(unsafe.Pointer(cs))
 
 	_, _, errno := unix.Syscall6(unix.SYS_QUOTACTL, C.Q_XGETPQUOTA,
 		uintptr(unsafe.Pointer(cs)), uintptr(C.__u32(projectID)),
-		uintptr(unsafe.Pointer(&d)), 0, 0)
+		uintptr(unsafe.Pointer(d)), 0, 0)
 	if errno != 0 {
 		return fmt.Errorf("Failed to get quota limit for projid %d on %s: %v",
 			projectID, q.backingFsBlockDev, errno.Error())
 	}
-	quota.Size = uint64(d.d_blk_hardlimit) * 512
 
 	return nil
 }
@@ -286,12 +364,13 @@ func setProjectID(targetPath string, projectID uint32) error {
 	return nil
 }
 
-// findNextProjectID - find the next project id to be used for containers
-// by scanning driver home directory to find used project ids
-func (q *Control) findNextProjectID(home string) error {
+// findNextProjectID - find the next project id to be used for containers,
+// starting from nextProjectID, by scanning driver home directory to find
+// used project ids
+func (q *Control) findNextProjectID(home string, nextProjectID uint32) (uint32, error) {
 	files, err := ioutil.ReadDir(home)
 	if err != nil {
-		return fmt.Errorf("read directory failed : %s", home)
+		return 0, fmt.Errorf("read directory failed : %s", home)
 	}
 	for _, file := range files {
 		if !file.IsDir() {
@@ -300,17 +379,17 @@ func (q *Control) findNextProjectID(home string) error {
 		path := filepath.Join(home, file.Name())
 		projid, err := getProjectID(path)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		if projid > 0 {
 			q.quotas[path] = projid
 		}
-		if q.nextProjectID <= projid {
-			q.nextProjectID = projid + 1
+		if nextProjectID <= projid {
+			nextProjectID = projid + 1
 		}
 	}
 
-	return nil
+	return nextProjectID, nil
 }
 
 func free(p *C.char) {
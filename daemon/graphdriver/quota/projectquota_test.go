@@ -58,6 +58,8 @@ func TestBlockDev(t *testing.T) {
 	t.Run("testSmallerThanQuota", wrapMountTest(imageFileName, true, wrapQuotaTest(testSmallerThanQuota)))
 	t.Run("testBiggerThanQuota", wrapMountTest(imageFileName, true, wrapQuotaTest(testBiggerThanQuota)))
 	t.Run("testRetrieveQuota", wrapMountTest(imageFileName, true, wrapQuotaTest(testRetrieveQuota)))
+	t.Run("testRetrieveUsage", wrapMountTest(imageFileName, true, wrapQuotaTest(testRetrieveUsage)))
+	t.Run("testSharedProjectIDs", wrapMountTest(imageFileName, true, wrapQuotaTest(testSharedProjectIDs)))
 }
 
 func wrapMountTest(imageFileName string, enableQuota bool, testFunc func(t *testing.T, mountPoint, backingFsDev string)) func(*testing.T) {
@@ -150,3 +152,38 @@ func testRetrieveQuota(t *testing.T, ctrl *Control, homeDir, testDir, testSubDir
 	assert.NilError(t, ctrl.GetQuota(testSubDir, &q))
 	assert.Check(t, is.Equal(uint64(testQuotaSize), q.Size))
 }
+
+func testRetrieveUsage(t *testing.T, ctrl *Control, homeDir, testDir, testSubDir string) {
+	// Validate that the usage of the project id is accounted
+	assert.NilError(t, ctrl.SetQuota(testSubDir, Quota{testQuotaSize}))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(testSubDir, "usage"), make([]byte, testQuotaSize/2), 0644))
+
+	usage, err := ctrl.GetUsage(testSubDir)
+	assert.NilError(t, err)
+	assert.Check(t, usage >= testQuotaSize/2, "usage %d is smaller than the written file", usage)
+
+	ctrl.RemoveQuota(testSubDir)
+	_, err = ctrl.GetUsage(testSubDir)
+	assert.Check(t, is.ErrorContains(err, "quota not found"))
+}
+
+func testSharedProjectIDs(t *testing.T, ctrl *Control, homeDir, testDir, testSubDir string) {
+	// Validate that the controls of the same filesystem assign different
+	// project ids
+	otherDir, err := ioutil.TempDir(homeDir, "other-test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(otherDir)
+	otherCtrl, err := NewControl(otherDir)
+	assert.NilError(t, err)
+	otherSubDir, err := ioutil.TempDir(otherDir, "quota-test")
+	assert.NilError(t, err)
+
+	assert.NilError(t, ctrl.SetQuota(testSubDir, Quota{testQuotaSize}))
+	assert.NilError(t, otherCtrl.SetQuota(otherSubDir, Quota{testQuotaSize}))
+
+	projectID, err := getProjectID(testSubDir)
+	assert.NilError(t, err)
+	otherProjectID, err := getProjectID(otherSubDir)
+	assert.NilError(t, err)
+	assert.Check(t, projectID != otherProjectID, "project id %d assigned twice", projectID)
+}
@@ -0,0 +1,34 @@
+// +build !linux
+
+package quota // import "github.com/docker/docker/daemon/graphdriver/quota"
+
+// Quota limit params - currently we only control blocks hard limit
+type Quota struct {
+	Size uint64
+}
+
+// Control - project quotas are only supported on Linux
+type Control struct{}
+
+// NewControl - project quotas are not supported on this platform
+func NewControl(basePath string) (*Control, error) {
+	return nil, ErrQuotaNotSupported
+}
+
+// SetQuota - project quotas are not supported on this platform
+func (q *Control) SetQuota(targetPath string, quota Quota) error {
+	return ErrQuotaNotSupported
+}
+
+// GetQuota - project quotas are not supported on this platform
+func (q *Control) GetQuota(targetPath string, quota *Quota) error {
+	return ErrQuotaNotSupported
+}
+
+// GetUsage - project quotas are not supported on this platform
+func (q *Control) GetUsage(targetPath string) (uint64, error) {
+	return 0, ErrQuotaNotSupported
+}
+
+// RemoveQuota - project quotas are not supported on this platform
+func (q *Control) RemoveQuota(targetPath string) {}
@@ -2,6 +2,7 @@ package vfs // import "github.com/docker/docker/daemon/graphdriver/vfs"
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -133,12 +134,78 @@ func (d *Driver) create(id, parent string, size uint64) error {
 	return CopyDir(parentDir.Path(), dir)
 }
 
+// SetQuota sets the project quota of the directory for a given id.
+func (d *Driver) SetQuota(id string, size uint64) error {
+	if !d.quotaSupported() {
+		return quota.ErrQuotaNotSupported
+	}
+	dir := d.dir(id)
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+	return d.setupQuota(dir, size)
+}
+
 func (d *Driver) dir(id string) string {
 	return filepath.Join(d.home, "dir", filepath.Base(id))
 }
 
-// Remove deletes the content from the directory for a given id.
+func (d *Driver) snapshotsDir(id string) string {
+	return filepath.Join(d.home, "snapshots", filepath.Base(id))
+}
+
+// Snapshot copies the directory for a given id to its snapshot name.
+func (d *Driver) Snapshot(id, name string) error {
+	dir := d.dir(id)
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+	snapshot := filepath.Join(d.snapshotsDir(id), filepath.Base(name))
+	if err := idtools.MkdirAllAndChown(filepath.Dir(snapshot), 0700, d.idMappings.RootPair()); err != nil {
+		return err
+	}
+	if err := idtools.MkdirAndChown(snapshot, 0755, d.idMappings.RootPair()); err != nil {
+		return err
+	}
+	if err := CopyDir(dir, snapshot); err != nil {
+		system.EnsureRemoveAll(snapshot)
+		return err
+	}
+	return nil
+}
+
+// Rollback replaces the content of the directory for a given id by the
+// content of its snapshot name. The directory itself is kept, along with its
+// quota.
+func (d *Driver) Rollback(id, name string) error {
+	snapshot := filepath.Join(d.snapshotsDir(id), filepath.Base(name))
+	if _, err := os.Stat(snapshot); err != nil {
+		return err
+	}
+	dir := d.dir(id)
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, fi := range fis {
+		if err := system.EnsureRemoveAll(filepath.Join(dir, fi.Name())); err != nil {
+			return err
+		}
+	}
+	return CopyDir(snapshot, dir)
+}
+
+// RemoveSnapshot deletes the snapshot name of the directory for a given id.
+func (d *Driver) RemoveSnapshot(id, name string) error {
+	return system.EnsureRemoveAll(filepath.Join(d.snapshotsDir(id), filepath.Base(name)))
+}
+
+// Remove deletes the content from the directory for a given id, and its
+// snapshots.
 func (d *Driver) Remove(id string) error {
+	if err := system.EnsureRemoveAll(d.snapshotsDir(id)); err != nil {
+		return err
+	}
 	return system.EnsureRemoveAll(d.dir(id))
 }
 
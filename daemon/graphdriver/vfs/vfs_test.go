@@ -36,6 +36,10 @@ func TestVfsSetQuota(t *testing.T) {
 	graphtest.DriverTestSetQuota(t, "vfs", false)
 }
 
+func TestVfsSnapshotRollback(t *testing.T) {
+	graphtest.DriverTestSnapshotRollback(t, "vfs")
+}
+
 func TestVfsTeardown(t *testing.T) {
 	graphtest.PutDriver(t)
 }
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/daemon/graphdriver"
+	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/containerfs"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/mount"
@@ -332,6 +333,12 @@ func parseStorageOpt(storageOpt map[string]string) (string, error) {
 	return "0", nil
 }
 
+// SetQuota sets the quota of the dataset for the given id. ZFS refuses a
+// quota lower than the space the dataset already uses.
+func (d *Driver) SetQuota(id string, size uint64) error {
+	return setQuota(d.zfsPath(id), strconv.FormatUint(size, 10))
+}
+
 func setQuota(name string, quota string) error {
 	if quota == "0" {
 		return nil
@@ -343,6 +350,42 @@ func setQuota(name string, quota string) error {
 	return fs.SetProperty("quota", quota)
 }
 
+// Snapshot creates the ZFS snapshot name of the dataset for the given id.
+func (d *Driver) Snapshot(id, name string) error {
+	dataset := zfs.Dataset{Name: d.zfsPath(id)}
+	_, err := dataset.Snapshot(name /*recursive */, false)
+	return err
+}
+
+// Rollback rolls the dataset for the given id back to its ZFS snapshot name.
+// ZFS only rolls a dataset back to its most recent snapshot, the more recent
+// snapshots have to be removed first.
+func (d *Driver) Rollback(id, name string) error {
+	snapshots, err := zfs.Snapshots(d.zfsPath(id))
+	if err != nil {
+		return err
+	}
+	// The snapshots of a dataset are listed in the order they were taken.
+	snapshotName := d.zfsPath(id) + "@" + name
+	for i, snapshot := range snapshots {
+		if snapshot.Name != snapshotName {
+			continue
+		}
+		if i != len(snapshots)-1 {
+			return errdefs.Conflict(fmt.Errorf("cannot roll back to snapshot %s: the zfs storage driver can only roll back to the most recent snapshot, remove the snapshots taken after it first", name))
+		}
+		return snapshot.Rollback(false)
+	}
+	return fmt.Errorf("snapshot %s does not exist", snapshotName)
+}
+
+// RemoveSnapshot destroys the ZFS snapshot name of the dataset for the given
+// id.
+func (d *Driver) RemoveSnapshot(id, name string) error {
+	snapshot := zfs.Dataset{Name: d.zfsPath(id) + "@" + name}
+	return snapshot.Destroy(zfs.DestroyDefault)
+}
+
 // Remove deletes the dataset, filesystem and the cache for the given id.
 func (d *Driver) Remove(id string) error {
 	name := d.zfsPath(id)
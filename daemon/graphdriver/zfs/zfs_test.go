@@ -30,6 +30,10 @@ func TestZfsSetQuota(t *testing.T) {
 	graphtest.DriverTestSetQuota(t, "zfs", true)
 }
 
+func TestZfsSnapshotRollback(t *testing.T) {
+	graphtest.DriverTestSnapshotRollback(t, "zfs")
+}
+
 func TestZfsTeardown(t *testing.T) {
 	graphtest.PutDriver(t)
 }
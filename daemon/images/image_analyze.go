@@ -0,0 +1,240 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"archive/tar"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/system"
+	"github.com/pkg/errors"
+)
+
+// layerFile is a file, directory or whiteout found in a layer tar stream.
+type layerFile struct {
+	path     string
+	size     int64
+	dir      bool
+	whiteout bool // path is deleted from the lower layers
+	opaque   bool // the lower layers' content of directory path is hidden
+}
+
+// ImageAnalyze returns a breakdown of the size of the image by layer and
+// file, including the bytes wasted on files that are hidden by later
+// layers, and the other local images sharing each layer.
+func (i *ImageService) ImageAnalyze(name string) (*image.AnalyzeResponse, error) {
+	start := time.Now()
+	img, err := i.GetImage(name)
+	if err != nil {
+		return nil, err
+	}
+	if !system.IsOSSupported(img.OperatingSystem()) {
+		return nil, system.ErrNotSupportedOperatingSystem
+	}
+	ls := i.layerStores[img.OperatingSystem()]
+
+	resp := &image.AnalyzeResponse{ID: img.ID().String()}
+	var files [][]layerFile
+
+	rootFS := *img.RootFS
+	rootFS.DiffIDs = nil
+	for _, diffID := range img.RootFS.DiffIDs {
+		rootFS.Append(diffID)
+		l, err := ls.Get(rootFS.ChainID())
+		if err != nil {
+			return nil, err
+		}
+		size, err := l.DiffSize()
+		if err != nil {
+			layer.ReleaseAndLog(ls, l)
+			return nil, err
+		}
+		lf, err := readLayerFiles(l)
+		layer.ReleaseAndLog(ls, l)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read layer %s", diffID)
+		}
+
+		files = append(files, lf)
+		resp.Size += size
+		resp.Layers = append(resp.Layers, image.LayerAnalysis{
+			ChainID: rootFS.ChainID().String(),
+			DiffID:  diffID.String(),
+			Size:    size,
+		})
+	}
+
+	for n, tree := range analyzeLayerFiles(files) {
+		resp.Layers[n].Files = tree
+		resp.Layers[n].WastedSize = tree.WastedSize
+		resp.WastedSize += tree.WastedSize
+	}
+
+	shared := i.layerSharing(img.ID().String())
+	for n := range resp.Layers {
+		resp.Layers[n].SharedBy = shared[layer.ChainID(resp.Layers[n].ChainID)]
+		if resp.Layers[n].SharedBy == nil {
+			resp.Layers[n].SharedBy = []string{}
+		}
+	}
+
+	imageActions.WithValues("analyze").UpdateSince(start)
+	return resp, nil
+}
+
+// layerSharing returns, for each layer, the IDs of the images other than
+// the image with ID exclude that use it.
+func (i *ImageService) layerSharing(exclude string) map[layer.ChainID][]string {
+	shared := make(map[layer.ChainID][]string)
+	for id, img := range i.imageStore.Map() {
+		if id.String() == exclude || img.RootFS == nil {
+			continue
+		}
+		rootFS := *img.RootFS
+		rootFS.DiffIDs = nil
+		for _, diffID := range img.RootFS.DiffIDs {
+			rootFS.Append(diffID)
+			shared[rootFS.ChainID()] = append(shared[rootFS.ChainID()], id.String())
+		}
+	}
+	for _, ids := range shared {
+		sort.Strings(ids)
+	}
+	return shared
+}
+
+// readLayerFiles lists the entries of the tar stream of l.
+func readLayerFiles(l layer.Layer) ([]layerFile, error) {
+	rc, err := l.TarStream()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var files []layerFile
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		p := path.Clean("/" + hdr.Name)
+		if p == "/" {
+			continue
+		}
+		dir, base := path.Split(p)
+		switch {
+		case base == archive.WhiteoutOpaqueDir:
+			files = append(files, layerFile{path: path.Clean(dir), dir: true, opaque: true})
+		case strings.HasPrefix(base, archive.WhiteoutMetaPrefix):
+			// Other metadata entries, such as hardlink directories
+			// from aufs, are not part of the image filesystem.
+		case strings.HasPrefix(base, archive.WhiteoutPrefix):
+			files = append(files, layerFile{path: path.Join(dir, strings.TrimPrefix(base, archive.WhiteoutPrefix)), whiteout: true})
+		default:
+			f := layerFile{path: p, dir: hdr.Typeflag == tar.TypeDir}
+			if hdr.Typeflag == tar.TypeReg || hdr.Typeflag == tar.TypeRegA {
+				f.size = hdr.Size
+			}
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// analyzeLayerFiles builds the file tree of each layer, given the entries
+// of the layers from the bottom-most to the top-most, and computes which
+// bytes are hidden by later layers.
+func analyzeLayerFiles(layers [][]layerFile) []*image.FileNode {
+	type layerIndex struct {
+		entries   map[string]bool // path -> is directory
+		whiteouts map[string]struct{}
+		opaques   map[string]struct{}
+	}
+	indexes := make([]layerIndex, len(layers))
+	for n, files := range layers {
+		idx := layerIndex{
+			entries:   make(map[string]bool),
+			whiteouts: make(map[string]struct{}),
+			opaques:   make(map[string]struct{}),
+		}
+		for _, f := range files {
+			switch {
+			case f.whiteout:
+				idx.whiteouts[f.path] = struct{}{}
+			case f.opaque:
+				idx.opaques[f.path] = struct{}{}
+			default:
+				idx.entries[f.path] = f.dir
+			}
+		}
+		indexes[n] = idx
+	}
+
+	// hidden returns whether the file at p in layer n is overwritten or
+	// deleted by a later layer.
+	hidden := func(n int, p string) bool {
+		for _, idx := range indexes[n+1:] {
+			if _, ok := idx.entries[p]; ok {
+				return true
+			}
+			for q := p; q != "/"; q = path.Dir(q) {
+				if _, ok := idx.whiteouts[q]; ok {
+					return true
+				}
+				if q == p {
+					continue
+				}
+				if _, ok := idx.opaques[q]; ok {
+					return true
+				}
+				if dir, ok := idx.entries[q]; ok && !dir {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	trees := make([]*image.FileNode, len(layers))
+	for n, files := range layers {
+		root := &image.FileNode{Name: "/"}
+		nodes := map[string]*image.FileNode{"/": root}
+		for _, f := range files {
+			if f.opaque {
+				continue
+			}
+			var wasted int64
+			if f.size > 0 && hidden(n, f.path) {
+				wasted = f.size
+			}
+			node, p := root, "/"
+			node.Size += f.size
+			node.WastedSize += wasted
+			for _, name := range strings.Split(strings.TrimPrefix(f.path, "/"), "/") {
+				p = path.Join(p, name)
+				child, ok := nodes[p]
+				if !ok {
+					child = &image.FileNode{Name: name}
+					node.Children = append(node.Children, child)
+					nodes[p] = child
+				}
+				node = child
+				node.Size += f.size
+				node.WastedSize += wasted
+			}
+			node.Whiteout = f.whiteout
+		}
+		trees[n] = root
+	}
+	return trees
+}
@@ -0,0 +1,67 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+func findNode(root *image.FileNode, names ...string) *image.FileNode {
+	node := root
+	for _, name := range names {
+		var next *image.FileNode
+		for _, c := range node.Children {
+			if c.Name == name {
+				next = c
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		node = next
+	}
+	return node
+}
+
+func TestAnalyzeLayerFiles(t *testing.T) {
+	layers := [][]layerFile{
+		{
+			{path: "/bin", dir: true},
+			{path: "/bin/sh", size: 100},
+			{path: "/etc", dir: true},
+			{path: "/etc/passwd", size: 10},
+			{path: "/tmp", dir: true},
+			{path: "/tmp/cache", size: 1000},
+			{path: "/var", dir: true},
+			{path: "/var/lib", dir: true},
+			{path: "/var/lib/data", size: 500},
+		},
+		{
+			{path: "/etc", dir: true},
+			{path: "/etc/passwd", size: 20},
+			{path: "/tmp", dir: true},
+			{path: "/tmp/cache", whiteout: true},
+			{path: "/var/lib", dir: true, opaque: true},
+		},
+	}
+
+	trees := analyzeLayerFiles(layers)
+	assert.Assert(t, is.Len(trees, 2))
+
+	base := trees[0]
+	assert.Check(t, is.Equal(base.Size, int64(1610)))
+	assert.Check(t, is.Equal(base.WastedSize, int64(1510)))
+	assert.Check(t, is.Equal(findNode(base, "bin", "sh").WastedSize, int64(0)))
+	assert.Check(t, is.Equal(findNode(base, "etc", "passwd").WastedSize, int64(10)))
+	assert.Check(t, is.Equal(findNode(base, "tmp").WastedSize, int64(1000)))
+	assert.Check(t, is.Equal(findNode(base, "var", "lib", "data").WastedSize, int64(500)))
+
+	top := trees[1]
+	assert.Check(t, is.Equal(top.Size, int64(20)))
+	assert.Check(t, is.Equal(top.WastedSize, int64(0)))
+	assert.Check(t, findNode(top, "tmp", "cache").Whiteout)
+	assert.Check(t, !findNode(top, "etc", "passwd").Whiteout)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/image"
@@ -76,7 +77,7 @@ func (i *ImageService) ImageDelete(imageRef string, force, prune bool) ([]types.
 	repoRefs := i.referenceStore.References(imgID.Digest())
 
 	using := func(c *container.Container) bool {
-		return c.ImageID == imgID
+		return usesImage(c, imgID)
 	}
 
 	var removedRepositoryRef bool
@@ -350,6 +351,20 @@ func (i *ImageService) imageDeleteHelper(imgID image.ID, records *[]types.ImageD
 	return i.imageDeleteHelper(parent, records, false, true, true)
 }
 
+// usesImage returns whether the container c is created from the image with
+// the given ID, or mounts it with an image mount.
+func usesImage(c *container.Container, imgID image.ID) bool {
+	if c.ImageID == imgID {
+		return true
+	}
+	for _, m := range c.MountPoints {
+		if m.Type == mount.TypeImage && m.Name == imgID.String() {
+			return true
+		}
+	}
+	return false
+}
+
 // checkImageDeleteConflict determines whether there are any conflicts
 // preventing deletion of the given image from this daemon. A hard conflict is
 // any image which has the given image as a parent or any running container
@@ -369,7 +384,7 @@ func (i *ImageService) checkImageDeleteConflict(imgID image.ID, mask conflictTyp
 	if mask&conflictRunningContainer != 0 {
 		// Check if any running container is using the image.
 		running := func(c *container.Container) bool {
-			return c.IsRunning() && c.ImageID == imgID
+			return c.IsRunning() && usesImage(c, imgID)
 		}
 		if container := i.containers.First(running); container != nil {
 			return &imageDeleteConflict{
@@ -392,7 +407,7 @@ func (i *ImageService) checkImageDeleteConflict(imgID image.ID, mask conflictTyp
 	if mask&conflictStoppedContainer != 0 {
 		// Check if any stopped containers reference this image.
 		stopped := func(c *container.Container) bool {
-			return !c.IsRunning() && c.ImageID == imgID
+			return !c.IsRunning() && usesImage(c, imgID)
 		}
 		if container := i.containers.First(stopped); container != nil {
 			return &imageDeleteConflict{
@@ -0,0 +1,212 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/image"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultGCInterval is the interval between two disk usage checks of the
+// image garbage collector if none is configured.
+const defaultGCInterval = 5 * time.Minute
+
+// GCPolicy is the policy applied by the image garbage collector.
+type GCPolicy struct {
+	// Root is a path on the filesystem whose usage is watched.
+	Root string
+	// HighWatermark is the disk usage, in percent, above which unused
+	// images are evicted.
+	HighWatermark int
+	// LowWatermark is the disk usage, in percent, at which eviction stops.
+	LowWatermark int
+	// MinAge is the duration an image must have been unused before it
+	// can be evicted.
+	MinAge time.Duration
+	// Interval is the duration between two disk usage checks.
+	Interval time.Duration
+	// KeepLabels protects images carrying one of the labels, given as
+	// "key" or "key=value".
+	KeepLabels []string
+	// KeepReferences protects images referenced by one of the references.
+	// A reference without tag or digest protects the whole repository.
+	KeepReferences []reference.Named
+}
+
+// gcImage is the information the garbage collector needs about an image
+// to decide whether it can be evicted.
+type gcImage struct {
+	id       image.ID
+	lastUsed time.Time
+	labels   map[string]string
+	refs     []reference.Named
+	inUse    bool // used by a container or by a child image
+}
+
+// RunGC checks the disk usage every policy.Interval and evicts unused
+// images when it is above the high watermark, until ctx is done.
+func (i *ImageService) RunGC(ctx context.Context, policy GCPolicy) {
+	interval := policy.Interval
+	if interval <= 0 {
+		interval = defaultGCInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := i.collectImages(ctx, policy); err != nil {
+				logrus.WithError(err).Warn("image garbage collection failed")
+			}
+		}
+	}
+}
+
+// collectImages evicts unused images, least recently used first, as long as
+// the disk usage is above the low watermark. Nothing is evicted unless the
+// disk usage is above the high watermark.
+func (i *ImageService) collectImages(ctx context.Context, policy GCPolicy) error {
+	usage, err := diskUsage(policy.Root)
+	if err != nil {
+		return err
+	}
+	if usage < policy.HighWatermark {
+		return nil
+	}
+	logrus.Infof("disk usage %d%% is above the image garbage collection high watermark (%d%%)", usage, policy.HighWatermark)
+
+	candidates := selectGCCandidates(i.gcImages(), policy, time.Now())
+	evicted := 0
+	for _, id := range candidates {
+		if usage <= policy.LowWatermark {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if !i.evictImage(id, usage, policy) {
+			continue
+		}
+		evicted++
+		if usage, err = diskUsage(policy.Root); err != nil {
+			return err
+		}
+	}
+	logrus.Infof("image garbage collection evicted %d images, disk usage is %d%%", evicted, usage)
+	return nil
+}
+
+// gcImages returns the images of the image store.
+func (i *ImageService) gcImages() []gcImage {
+	var images []gcImage
+	for id, img := range i.imageStore.Map() {
+		gi := gcImage{
+			id:       id,
+			lastUsed: i.lastActivity(id, img),
+			refs:     i.referenceStore.References(id.Digest()),
+			inUse:    i.checkImageDeleteConflict(id, conflictDependentChild|conflictRunningContainer|conflictStoppedContainer) != nil,
+		}
+		if img.Config != nil {
+			gi.labels = img.Config.Labels
+		}
+		images = append(images, gi)
+	}
+	return images
+}
+
+// evictImage removes the image with the given id and all its references.
+// It returns whether the image was removed.
+func (i *ImageService) evictImage(id image.ID, usage int, policy GCPolicy) bool {
+	attributes := map[string]string{
+		"reason":         "disk-usage",
+		"disk-usage":     strconv.Itoa(usage),
+		"high-watermark": strconv.Itoa(policy.HighWatermark),
+	}
+	// The labels of the image are no longer available once it is removed,
+	// so they are added to the attributes of the event beforehand.
+	if img, err := i.imageStore.Get(id); err == nil && img.Config != nil {
+		copyAttributes(attributes, img.Config.Labels)
+	}
+
+	refs := i.referenceStore.References(id.Digest())
+	if len(refs) == 0 {
+		if _, err := i.ImageDelete(id.Digest().Hex(), false, true); imageDeleteFailed(id.String(), err) {
+			return false
+		}
+	}
+	for _, ref := range refs {
+		if _, err := i.ImageDelete(ref.String(), false, true); imageDeleteFailed(ref.String(), err) {
+			return false
+		}
+		// Removing a tag also removes the digest references of the same
+		// repository, which may remove the image before all of refs are.
+		if _, err := i.imageStore.Get(id); err != nil {
+			break
+		}
+	}
+	i.LogImageEventWithAttributes(id.String(), "", "evict", attributes)
+	return true
+}
+
+// selectGCCandidates returns the IDs of the images that may be evicted
+// under policy, least recently used first.
+func selectGCCandidates(images []gcImage, policy GCPolicy, now time.Time) []image.ID {
+	var candidates []gcImage
+	for _, img := range images {
+		if img.inUse || now.Sub(img.lastUsed) < policy.MinAge {
+			continue
+		}
+		if hasKeepLabel(img.labels, policy.KeepLabels) || hasKeepReference(img.refs, policy.KeepReferences) {
+			continue
+		}
+		candidates = append(candidates, img)
+	}
+	sort.SliceStable(candidates, func(a, b int) bool {
+		if candidates[a].lastUsed.Equal(candidates[b].lastUsed) {
+			return candidates[a].id < candidates[b].id
+		}
+		return candidates[a].lastUsed.Before(candidates[b].lastUsed)
+	})
+
+	ids := make([]image.ID, len(candidates))
+	for n, c := range candidates {
+		ids[n] = c.id
+	}
+	return ids
+}
+
+func hasKeepLabel(labels map[string]string, keep []string) bool {
+	for _, k := range keep {
+		kv := strings.SplitN(k, "=", 2)
+		v, ok := labels[kv[0]]
+		if ok && (len(kv) == 1 || v == kv[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasKeepReference(refs, keep []reference.Named) bool {
+	for _, k := range keep {
+		for _, ref := range refs {
+			if reference.IsNameOnly(k) {
+				if ref.Name() == k.Name() {
+					return true
+				}
+			} else if ref.String() == k.String() {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/container"
+	daemonevents "github.com/docker/docker/daemon/events"
+	"github.com/docker/docker/image"
+	dockerreference "github.com/docker/docker/reference"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+func mustParseNamed(t *testing.T, s string) reference.Named {
+	ref, err := reference.ParseNormalizedNamed(s)
+	assert.NilError(t, err)
+	return ref
+}
+
+func TestSelectGCCandidates(t *testing.T) {
+	now := time.Now()
+	images := []gcImage{
+		{id: "recent", lastUsed: now.Add(-time.Minute)},
+		{id: "old", lastUsed: now.Add(-72 * time.Hour)},
+		{id: "older", lastUsed: now.Add(-96 * time.Hour)},
+		{id: "used", lastUsed: now.Add(-96 * time.Hour), inUse: true},
+		{id: "labeled", lastUsed: now.Add(-96 * time.Hour), labels: map[string]string{"keep": "true"}},
+		{id: "other-label", lastUsed: now.Add(-48 * time.Hour), labels: map[string]string{"keep": "false"}},
+		{id: "pinned", lastUsed: now.Add(-96 * time.Hour), refs: []reference.Named{mustParseNamed(t, "busybox:latest")}},
+		{id: "repo", lastUsed: now.Add(-96 * time.Hour), refs: []reference.Named{mustParseNamed(t, "alpine:3.7")}},
+		{id: "tagged", lastUsed: now.Add(-24 * time.Hour), refs: []reference.Named{mustParseNamed(t, "busybox:1.28")}},
+	}
+	policy := GCPolicy{
+		MinAge:         time.Hour,
+		KeepLabels:     []string{"keep=true"},
+		KeepReferences: []reference.Named{mustParseNamed(t, "busybox:latest"), mustParseNamed(t, "alpine")},
+	}
+
+	ids := selectGCCandidates(images, policy, now)
+	assert.Check(t, is.DeepEqual(ids, []image.ID{"older", "old", "other-label", "tagged"}))
+
+	policy.KeepLabels = []string{"keep"}
+	ids = selectGCCandidates(images, policy, now)
+	assert.Check(t, is.DeepEqual(ids, []image.ID{"older", "old", "tagged"}))
+}
+
+func TestEvictImageWithTagAndDigest(t *testing.T) {
+	root, err := ioutil.TempDir("", "image-gc-test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(root)
+
+	fs, err := image.NewFSStoreBackend(filepath.Join(root, "images"))
+	assert.NilError(t, err)
+	imageStore, err := image.NewImageStore(fs, map[string]image.LayerGetReleaser{})
+	assert.NilError(t, err)
+	referenceStore, err := dockerreference.NewReferenceStore(filepath.Join(root, "repositories.json"))
+	assert.NilError(t, err)
+	i := &ImageService{
+		containers:     container.NewMemoryStore(),
+		eventsService:  daemonevents.New(),
+		imageStore:     imageStore,
+		referenceStore: referenceStore,
+	}
+
+	id, err := imageStore.Create([]byte(`{"architecture":"amd64","os":"linux","rootfs":{"type":"layers"}}`))
+	assert.NilError(t, err)
+	assert.NilError(t, referenceStore.AddTag(mustParseNamed(t, "busybox:latest"), id.Digest(), false))
+	canonical, err := reference.WithDigest(mustParseNamed(t, "busybox"), id.Digest())
+	assert.NilError(t, err)
+	assert.NilError(t, referenceStore.AddDigest(canonical, id.Digest(), false))
+
+	assert.Check(t, i.evictImage(id, 95, GCPolicy{HighWatermark: 90}))
+	_, err = imageStore.Get(id)
+	assert.Check(t, err != nil)
+	assert.Check(t, is.Len(referenceStore.References(id.Digest()), 0))
+
+	messages, _, cancel := i.eventsService.Subscribe()
+	defer cancel()
+	var evicted bool
+	for _, m := range messages {
+		if m.Action == "evict" && m.Actor.ID == id.String() {
+			evicted = true
+		}
+	}
+	assert.Check(t, evicted, "no evict event was logged")
+}
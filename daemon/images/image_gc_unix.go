@@ -0,0 +1,20 @@
+// +build linux freebsd
+
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// diskUsage returns the usage, in percent, of the filesystem holding path,
+// counting the blocks reserved to the super-user as used.
+func diskUsage(path string) (int, error) {
+	var buf unix.Statfs_t
+	if err := unix.Statfs(path, &buf); err != nil {
+		return 0, err
+	}
+	if buf.Blocks == 0 {
+		return 0, nil
+	}
+	return int(100 - uint64(buf.Bavail)*100/uint64(buf.Blocks)), nil
+}
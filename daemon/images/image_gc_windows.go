@@ -0,0 +1,11 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+)
+
+// diskUsage is not implemented on Windows.
+func diskUsage(path string) (int, error) {
+	return 0, errdefs.NotImplemented(errors.New("image garbage collection is not supported on Windows"))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/pkg/system"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 // LookupImage looks up an image by name and returns it as an ImageInspect
@@ -62,6 +63,11 @@ func (i *ImageService) LookupImage(name string) (*types.ImageInspect, error) {
 	if err != nil {
 		return nil, err
 	}
+	lastUsed, useCount, err := i.imageStore.GetLastUsed(img.ID())
+	if err != nil {
+		logrus.WithError(err).WithField("image", img.ID().String()).Warn("failed to get the last use of the image")
+		lastUsed, useCount = time.Time{}, 0
+	}
 
 	imageInspect := &types.ImageInspect{
 		ID:              img.ID().String(),
@@ -82,7 +88,9 @@ func (i *ImageService) LookupImage(name string) (*types.ImageInspect, error) {
 		VirtualSize:     size, // TODO: field unused, deprecate
 		RootFS:          rootFSToAPIType(img.RootFS),
 		Metadata: types.ImageMetadata{
-			LastTagTime: lastUpdated,
+			LastTagTime:  lastUpdated,
+			LastUsedTime: lastUsed,
+			UseCount:     useCount,
 		},
 	}
 
@@ -18,10 +18,11 @@ import (
 )
 
 var imagesAcceptedFilters = map[string]bool{
-	"dangling": true,
-	"label":    true,
-	"label!":   true,
-	"until":    true,
+	"dangling":   true,
+	"label":      true,
+	"label!":     true,
+	"until":      true,
+	"unused-for": true,
 }
 
 // errPruneRunning is returned when a prune request is received while
@@ -57,6 +58,11 @@ func (i *ImageService) ImagesPrune(ctx context.Context, pruneFilters filters.Arg
 		return nil, err
 	}
 
+	unusedFor, err := getUnusedForFromPruneFilters(pruneFilters)
+	if err != nil {
+		return nil, err
+	}
+
 	var allImages map[image.ID]*image.Image
 	if danglingOnly {
 		allImages = i.imageStore.Heads()
@@ -84,6 +90,9 @@ func (i *ImageService) ImagesPrune(ctx context.Context, pruneFilters filters.Arg
 			if !until.IsZero() && img.Created.After(until) {
 				continue
 			}
+			if unusedFor > 0 && time.Since(i.lastActivity(id, img)) < unusedFor {
+				continue
+			}
 			if img.Config != nil && !matchLabels(pruneFilters, img.Config.Labels) {
 				continue
 			}
@@ -208,3 +217,18 @@ func getUntilFromPruneFilters(pruneFilters filters.Args) (time.Time, error) {
 	until = time.Unix(seconds, nanoseconds)
 	return until, nil
 }
+
+func getUnusedForFromPruneFilters(pruneFilters filters.Args) (time.Duration, error) {
+	if !pruneFilters.Contains("unused-for") {
+		return 0, nil
+	}
+	unusedForFilters := pruneFilters.Get("unused-for")
+	if len(unusedForFilters) > 1 {
+		return 0, fmt.Errorf("more than one unused-for filter specified")
+	}
+	unusedFor, err := time.ParseDuration(unusedForFilters[0])
+	if err != nil || unusedFor < 0 {
+		return 0, invalidFilter{"unused-for", unusedForFilters[0]}
+	}
+	return unusedFor, nil
+}
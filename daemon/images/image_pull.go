@@ -81,6 +81,9 @@ func (i *ImageService) pullImageWithReference(ctx context.Context, ref reference
 		Schema2Types:    distribution.ImageTypes,
 		OS:              os,
 	}
+	if i.signatureVerifier != nil {
+		imagePullConfig.SignatureVerifier = i.signatureVerifier
+	}
 
 	err := distribution.Pull(ctx, ref, imagePullConfig)
 	close(progressChan)
@@ -0,0 +1,62 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/image"
+	"github.com/opencontainers/go-digest"
+)
+
+// VerifyImageSignature checks that img, which was looked up with refOrID,
+// is accepted by the signature policy. If refOrID names the image, only the
+// repository it names is considered; otherwise all repositories the image
+// is known in must accept it.
+// called from create.go
+func (i *ImageService) VerifyImageSignature(refOrID string, img *image.Image) error {
+	if i.signatureVerifier == nil {
+		return nil
+	}
+
+	var repos []reference.Named
+	if ref, err := reference.ParseNormalizedNamed(refOrID); err == nil {
+		if id, err := i.referenceStore.Get(ref); err == nil && image.IDFromDigest(id) == img.ID() {
+			repos = append(repos, reference.TrimNamed(ref))
+		}
+	}
+	if len(repos) == 0 {
+		seen := make(map[string]struct{})
+		for _, ref := range i.referenceStore.References(img.ID().Digest()) {
+			if _, ok := seen[ref.Name()]; !ok {
+				seen[ref.Name()] = struct{}{}
+				repos = append(repos, reference.TrimNamed(ref))
+			}
+		}
+	}
+
+	for _, repo := range repos {
+		if !i.signatureVerifier.Requires(repo) {
+			continue
+		}
+		var digests []digest.Digest
+		for _, ref := range i.referenceStore.References(img.ID().Digest()) {
+			if c, ok := ref.(reference.Canonical); ok && c.Name() == repo.Name() {
+				digests = append(digests, c.Digest())
+			}
+		}
+		if len(digests) == 0 {
+			// The image was not pulled from this repository, so there is
+			// no manifest digest that could have been signed.
+			digests = append(digests, "")
+		}
+
+		var err error
+		for _, dgst := range digests {
+			if err = i.signatureVerifier.Verify(repo, dgst); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"time"
+
+	"github.com/docker/docker/image"
+	"github.com/sirupsen/logrus"
+)
+
+// RecordImageUse records that the image with the given id was used to
+// start a container.
+// called from start.go
+func (i *ImageService) RecordImageUse(id image.ID) {
+	if id == "" {
+		return
+	}
+	if err := i.imageStore.SetLastUsed(id); err != nil {
+		logrus.WithError(err).WithField("image", id.String()).Warn("failed to record image use")
+	}
+}
+
+// TouchImage records that the image with the given id was used to create a
+// container. Only its last used time is updated, the use is counted when the
+// container is started, so that running a container counts a single use.
+// called from create.go
+func (i *ImageService) TouchImage(id image.ID) {
+	if id == "" {
+		return
+	}
+	if err := i.imageStore.TouchLastUsed(id); err != nil {
+		logrus.WithError(err).WithField("image", id.String()).Warn("failed to record image use")
+	}
+}
+
+// lastActivity returns the last time img was used, or, if it was never used,
+// the last time it was tagged or pulled.
+func (i *ImageService) lastActivity(id image.ID, img *image.Image) time.Time {
+	last := img.Created
+	if t, err := i.imageStore.GetLastUpdated(id); err == nil && t.After(last) {
+		last = t
+	}
+	if t, _, err := i.imageStore.GetLastUsed(id); err == nil && t.After(last) {
+		last = t
+	}
+	return last
+}
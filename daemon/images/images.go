@@ -142,6 +142,12 @@ func (i *ImageService) Images(imageFilters filters.Args, all bool, withExtraAttr
 		}
 
 		newImage := newImage(img, size)
+		if lastUsed, useCount, err := i.imageStore.GetLastUsed(id); err == nil {
+			if !lastUsed.IsZero() {
+				newImage.LastUsed = lastUsed.Unix()
+			}
+			newImage.UseCount = useCount
+		}
 
 		for _, ref := range i.referenceStore.References(id.Digest()) {
 			if imageFilters.Contains("reference") {
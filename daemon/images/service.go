@@ -7,9 +7,11 @@ import (
 	"github.com/docker/docker/container"
 	daemonevents "github.com/docker/docker/daemon/events"
 	"github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/distribution/signature"
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/system"
 	dockerreference "github.com/docker/docker/reference"
 	"github.com/docker/docker/registry"
 	"github.com/docker/libtrust"
@@ -38,6 +40,7 @@ type ImageServiceConfig struct {
 	MaxConcurrentUploads      int
 	ReferenceStore            dockerreference.Store
 	RegistryService           registry.Service
+	SignatureVerifier         *signature.Verifier
 	TrustKey                  libtrust.PrivateKey
 }
 
@@ -54,6 +57,7 @@ func NewImageService(config ImageServiceConfig) *ImageService {
 		layerStores:               config.LayerStores,
 		referenceStore:            config.ReferenceStore,
 		registryService:           config.RegistryService,
+		signatureVerifier:         config.SignatureVerifier,
 		trustKey:                  config.TrustKey,
 		uploadManager:             xfer.NewLayerUploadManager(config.MaxConcurrentUploads),
 	}
@@ -70,6 +74,7 @@ type ImageService struct {
 	pruneRunning              int32
 	referenceStore            dockerreference.Store
 	registryService           registry.Service
+	signatureVerifier         *signature.Verifier
 	trustKey                  libtrust.PrivateKey
 	uploadManager             *xfer.LayerUploadManager
 }
@@ -111,12 +116,67 @@ func (i *ImageService) CreateLayer(container *container.Container, initFunc laye
 	return i.layerStores[container.OS].CreateRWLayer(container.ID, layerID, rwLayerOpts)
 }
 
+// CreateImageMountLayer creates a read-write layer with the given ID on top of
+// the root filesystem of the image, for an image mount of a container
+// called from volumes.go Daemon.registerMountPoints()
+func (i *ImageService) CreateImageMountLayer(id string, img *image.Image, mountLabel string) (layer.RWLayer, error) {
+	ls, ok := i.layerStores[img.OperatingSystem()]
+	if !ok {
+		return nil, errors.Errorf("cannot mount image %s: %s", img.ID(), system.ErrNotSupportedOperatingSystem)
+	}
+	rwLayerOpts := &layer.CreateRWLayerOpts{
+		MountLabel: mountLabel,
+	}
+	return ls.CreateRWLayer(id, img.RootFS.ChainID(), rwLayerOpts)
+}
+
 // GetLayerByID returns a layer by ID and operating system
-// called from daemon.go Daemon.restore(), and Daemon.containerExport()
+// called from daemon.go Daemon.restore(), Daemon.containerExport(), and
+// volumes.go Daemon.lazyInitializeImage()
 func (i *ImageService) GetLayerByID(cid string, os string) (layer.RWLayer, error) {
 	return i.layerStores[os].GetRWLayer(cid)
 }
 
+// SetLayerQuota sets the size quota of the read-write layer of a container
+// called from update.go Daemon.update()
+func (i *ImageService) SetLayerQuota(cid string, os string, size uint64) error {
+	qs, ok := i.layerStores[os].(layer.QuotaStore)
+	if !ok {
+		return layer.ErrQuotaNotSupported
+	}
+	return qs.SetRWLayerQuota(cid, size)
+}
+
+// SnapshotLayer saves the read-write layer of a container to a snapshot
+// called from snapshot.go Daemon.ContainerSnapshotCreate()
+func (i *ImageService) SnapshotLayer(cid string, os string, snapshot string) error {
+	ss, ok := i.layerStores[os].(layer.SnapshotStore)
+	if !ok {
+		return layer.ErrSnapshotNotSupported
+	}
+	return ss.SnapshotRWLayer(cid, snapshot)
+}
+
+// RollbackLayer restores the read-write layer of a container from a snapshot
+// called from snapshot.go Daemon.ContainerSnapshotRollback()
+func (i *ImageService) RollbackLayer(cid string, os string, snapshot string) error {
+	ss, ok := i.layerStores[os].(layer.SnapshotStore)
+	if !ok {
+		return layer.ErrSnapshotNotSupported
+	}
+	return ss.RollbackRWLayer(cid, snapshot)
+}
+
+// RemoveLayerSnapshot removes a snapshot of the read-write layer of a container
+// called from snapshot.go Daemon.ContainerSnapshotDelete()
+func (i *ImageService) RemoveLayerSnapshot(cid string, os string, snapshot string) error {
+	ss, ok := i.layerStores[os].(layer.SnapshotStore)
+	if !ok {
+		return layer.ErrSnapshotNotSupported
+	}
+	return ss.RemoveRWLayerSnapshot(cid, snapshot)
+}
+
 // LayerStoreStatus returns the status for each layer store
 // called from info.go
 func (i *ImageService) LayerStoreStatus() map[string][][2]string {
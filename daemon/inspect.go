@@ -233,6 +233,7 @@ func (daemon *Daemon) ContainerExecInspect(id string) (*backend.ExecInspect, err
 		ContainerID:   e.ContainerID,
 		DetachKeys:    e.DetachKeys,
 		Pid:           e.Pid,
+		TimedOut:      e.TimedOut,
 	}, nil
 }
 
@@ -249,6 +250,11 @@ func (daemon *Daemon) VolumeInspect(name string) (*types.Volume, error) {
 	apiV := volumeToAPIType(v)
 	apiV.Mountpoint = v.Path()
 	apiV.Status = v.Status()
+	usage, err := daemon.volumes.Usage(v)
+	if err != nil {
+		return nil, errdefs.System(err)
+	}
+	apiV.UsageHistory = volumeUsageToAPIType(usage)
 	return apiV, nil
 }
 
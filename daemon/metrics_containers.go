@@ -0,0 +1,267 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	metrics "github.com/docker/go-metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+var invalidMetricLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// containerMetrics exports the resource usage, restart count and health of
+// each container. The resource usage is the latest sample published by the
+// stats collector: the collector is subscribed to the stats of a running
+// container the first time it is scraped, so its resource usage is only
+// exported from the next scrape on.
+type containerMetrics struct {
+	daemon *Daemon
+	// labels are the container labels exported with the metrics, and names
+	// the names of the metric labels: the name, ID and image of the
+	// container followed by the names of the exported container labels.
+	labels []string
+	names  []string
+
+	cpuUsage         *prometheus.Desc
+	cpuUser          *prometheus.Desc
+	cpuKernel        *prometheus.Desc
+	cpuThrottled     *prometheus.Desc
+	cpuThrottledTime *prometheus.Desc
+	memoryUsage      *prometheus.Desc
+	memoryLimit      *prometheus.Desc
+	memoryOOMKills   *prometheus.Desc
+	blkioRead        *prometheus.Desc
+	blkioWrite       *prometheus.Desc
+	networkRxBytes   *prometheus.Desc
+	networkTxBytes   *prometheus.Desc
+	networkRxPackets *prometheus.Desc
+	networkTxPackets *prometheus.Desc
+	pids             *prometheus.Desc
+	restarts         *prometheus.Desc
+	healthy          *prometheus.Desc
+	failingStreak    *prometheus.Desc
+
+	mu   sync.Mutex
+	subs map[string]*containerStatsSubscription // by container ID
+}
+
+type containerStatsSubscription struct {
+	container *container.Container
+	ch        chan interface{}
+
+	mu    sync.Mutex
+	stats *types.StatsJSON
+}
+
+// registerContainerMetrics registers the per-container metrics with the
+// metrics registry of the daemon. The given container labels are exported
+// as metric labels, prefixed with "container_label_".
+func (daemon *Daemon) registerContainerMetrics(labels []string) {
+	ns := metrics.NewNamespace("engine", "container", nil)
+	ns.Add(newContainerMetrics(daemon, ns, labels))
+	metrics.Register(ns)
+}
+
+func newContainerMetrics(daemon *Daemon, ns *metrics.Namespace, labels []string) *containerMetrics {
+	m := &containerMetrics{
+		daemon: daemon,
+		names:  []string{"name", "id", "image"},
+		subs:   make(map[string]*containerStatsSubscription),
+	}
+	seen := make(map[string]bool)
+	for _, l := range labels {
+		name := metricLabelName(l)
+		if seen[name] {
+			logrus.Warnf("container label %q is exported as metric label %s more than once, ignoring", l, name)
+			continue
+		}
+		seen[name] = true
+		m.labels = append(m.labels, l)
+		m.names = append(m.names, name)
+	}
+	network := append([]string{"interface"}, m.names...)
+
+	m.cpuUsage = ns.NewDesc("cpu_usage_seconds", "The total CPU time consumed by a container", metrics.Total, m.names...)
+	m.cpuUser = ns.NewDesc("cpu_user_seconds", "The CPU time consumed by a container in user mode", metrics.Total, m.names...)
+	m.cpuKernel = ns.NewDesc("cpu_kernel_seconds", "The CPU time consumed by a container in kernel mode", metrics.Total, m.names...)
+	m.cpuThrottled = ns.NewDesc("cpu_throttled_periods", "The number of periods in which a container was throttled", metrics.Total, m.names...)
+	m.cpuThrottledTime = ns.NewDesc("cpu_throttled_seconds", "The time a container was throttled for", metrics.Total, m.names...)
+	m.memoryUsage = ns.NewDesc("memory_usage", "The memory used by a container", metrics.Bytes, m.names...)
+	m.memoryLimit = ns.NewDesc("memory_limit", "The memory limit of a container", metrics.Bytes, m.names...)
+	m.memoryOOMKills = ns.NewDesc("memory_oom_kills", "The number of processes of a container killed by the OOM killer", metrics.Total, m.names...)
+	m.blkioRead = ns.NewDesc("blkio_read_bytes", "The number of bytes a container read from block devices", metrics.Total, m.names...)
+	m.blkioWrite = ns.NewDesc("blkio_write_bytes", "The number of bytes a container wrote to block devices", metrics.Total, m.names...)
+	m.networkRxBytes = ns.NewDesc("network_receive_bytes", "The number of bytes received by a container on a network interface", metrics.Total, network...)
+	m.networkTxBytes = ns.NewDesc("network_transmit_bytes", "The number of bytes sent by a container on a network interface", metrics.Total, network...)
+	m.networkRxPackets = ns.NewDesc("network_receive_packets", "The number of packets received by a container on a network interface", metrics.Total, network...)
+	m.networkTxPackets = ns.NewDesc("network_transmit_packets", "The number of packets sent by a container on a network interface", metrics.Total, network...)
+	m.pids = ns.NewDesc("pids", "The number of processes running in a container", metrics.Unit("processes"), m.names...)
+	m.restarts = ns.NewDesc("restarts", "The number of times a container was restarted by its restart policy", metrics.Total, m.names...)
+	m.healthy = ns.NewDesc("healthy", "Whether a container with a health check is healthy", "", m.names...)
+	m.failingStreak = ns.NewDesc("health_failing_streak", "The number of consecutive failed health checks of a container", metrics.Unit("checks"), m.names...)
+	return m
+}
+
+// metricLabelName returns the name of the metric label of a container label.
+func metricLabelName(label string) string {
+	return "container_label_" + invalidMetricLabelChars.ReplaceAllString(label, "_")
+}
+
+func (m *containerMetrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		m.cpuUsage, m.cpuUser, m.cpuKernel, m.cpuThrottled, m.cpuThrottledTime,
+		m.memoryUsage, m.memoryLimit, m.memoryOOMKills,
+		m.blkioRead, m.blkioWrite,
+		m.networkRxBytes, m.networkTxBytes, m.networkRxPackets, m.networkTxPackets,
+		m.pids, m.restarts, m.healthy, m.failingStreak,
+	} {
+		ch <- d
+	}
+}
+
+func (m *containerMetrics) Collect(ch chan<- prometheus.Metric) {
+	running := make(map[string]bool)
+	for _, c := range m.daemon.List() {
+		c.Lock()
+		values := []string{strings.TrimPrefix(c.Name, "/"), c.ID, c.Config.Image}
+		for _, l := range m.labels {
+			values = append(values, c.Config.Labels[l])
+		}
+		isRunning := c.IsRunning()
+		restarts := c.RestartCount
+		hasHealth := c.State.Health != nil
+		var healthy, failingStreak float64
+		if hasHealth {
+			if c.State.Health.Status() == types.Healthy {
+				healthy = 1
+			}
+			failingStreak = float64(c.State.Health.FailingStreak)
+		}
+		c.Unlock()
+
+		ch <- prometheus.MustNewConstMetric(m.restarts, prometheus.CounterValue, float64(restarts), values...)
+		if hasHealth {
+			ch <- prometheus.MustNewConstMetric(m.healthy, prometheus.GaugeValue, healthy, values...)
+			ch <- prometheus.MustNewConstMetric(m.failingStreak, prometheus.GaugeValue, failingStreak, values...)
+		}
+
+		if !isRunning {
+			continue
+		}
+		running[c.ID] = true
+		if stats := m.latestStats(c); stats != nil {
+			m.collectStats(ch, stats, values)
+		}
+	}
+	m.unsubscribeStopped(running)
+}
+
+// latestStats returns the latest stats published for the container c, or
+// nil if none was published yet.
+func (m *containerMetrics) latestStats(c *container.Container) *types.StatsJSON {
+	m.mu.Lock()
+	sub, ok := m.subs[c.ID]
+	if !ok {
+		sub = &containerStatsSubscription{
+			container: c,
+			ch:        m.daemon.subscribeToContainerStats(c),
+		}
+		m.subs[c.ID] = sub
+		go sub.receive()
+	}
+	m.mu.Unlock()
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	return sub.stats
+}
+
+// unsubscribeStopped stops receiving the stats of the containers which
+// are no longer running.
+func (m *containerMetrics) unsubscribeStopped(running map[string]bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, sub := range m.subs {
+		if !running[id] {
+			m.daemon.unsubscribeToContainerStats(sub.container, sub.ch)
+			delete(m.subs, id)
+		}
+	}
+}
+
+func (s *containerStatsSubscription) receive() {
+	for v := range s.ch {
+		stats, ok := v.(types.StatsJSON)
+		// The collector publishes empty stats for stopped containers.
+		if !ok || stats.Read.IsZero() {
+			continue
+		}
+		s.mu.Lock()
+		s.stats = &stats
+		s.mu.Unlock()
+	}
+}
+
+func (m *containerMetrics) collectStats(ch chan<- prometheus.Metric, stats *types.StatsJSON, values []string) {
+	counter := func(desc *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, v, values...)
+	}
+	gauge := func(desc *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, v, values...)
+	}
+
+	// CPU usage is reported in nanoseconds on Linux and in 100's of
+	// nanoseconds on Windows.
+	cpuUnit := 1e9
+	if runtime.GOOS == "windows" {
+		cpuUnit = 1e7
+	}
+	usage := stats.CPUStats.CPUUsage
+	counter(m.cpuUsage, float64(usage.TotalUsage)/cpuUnit)
+	counter(m.cpuUser, float64(usage.UsageInUsermode)/cpuUnit)
+	counter(m.cpuKernel, float64(usage.UsageInKernelmode)/cpuUnit)
+	counter(m.cpuThrottled, float64(stats.CPUStats.ThrottlingData.ThrottledPeriods))
+	counter(m.cpuThrottledTime, float64(stats.CPUStats.ThrottlingData.ThrottledTime)/1e9)
+
+	if runtime.GOOS == "windows" {
+		gauge(m.memoryUsage, float64(stats.MemoryStats.PrivateWorkingSet))
+	} else {
+		gauge(m.memoryUsage, float64(stats.MemoryStats.Usage))
+		gauge(m.memoryLimit, float64(stats.MemoryStats.Limit))
+	}
+	if stats.MemoryStats.Events != nil {
+		counter(m.memoryOOMKills, float64(stats.MemoryStats.Events.OOMKill))
+	}
+
+	read, write := stats.StorageStats.ReadSizeBytes, stats.StorageStats.WriteSizeBytes
+	for _, e := range stats.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			read += e.Value
+		case "write":
+			write += e.Value
+		}
+	}
+	counter(m.blkioRead, float64(read))
+	counter(m.blkioWrite, float64(write))
+
+	for iface, n := range stats.Networks {
+		v := append([]string{iface}, values...)
+		ch <- prometheus.MustNewConstMetric(m.networkRxBytes, prometheus.CounterValue, float64(n.RxBytes), v...)
+		ch <- prometheus.MustNewConstMetric(m.networkTxBytes, prometheus.CounterValue, float64(n.TxBytes), v...)
+		ch <- prometheus.MustNewConstMetric(m.networkRxPackets, prometheus.CounterValue, float64(n.RxPackets), v...)
+		ch <- prometheus.MustNewConstMetric(m.networkTxPackets, prometheus.CounterValue, float64(n.TxPackets), v...)
+	}
+
+	if runtime.GOOS == "windows" {
+		gauge(m.pids, float64(stats.NumProcs))
+	} else {
+		gauge(m.pids, float64(stats.PidsStats.Current))
+	}
+}
@@ -0,0 +1,21 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+
+	metrics "github.com/docker/go-metrics"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+func TestMetricLabelName(t *testing.T) {
+	assert.Check(t, is.Equal(metricLabelName("com.example.team"), "container_label_com_example_team"))
+	assert.Check(t, is.Equal(metricLabelName("tier"), "container_label_tier"))
+}
+
+func TestContainerMetricsDuplicateLabels(t *testing.T) {
+	ns := metrics.NewNamespace("test", "container", nil)
+	m := newContainerMetrics(nil, ns, []string{"com.example.team", "tier", "com_example_team"})
+	assert.Check(t, is.DeepEqual(m.labels, []string{"com.example.team", "tier"}))
+	assert.Check(t, is.DeepEqual(m.names, []string{"name", "id", "image", "container_label_com_example_team", "container_label_tier"}))
+}
@@ -118,6 +118,7 @@ func (daemon *Daemon) ProcessEvent(id string, e libcontainerd.EventType, ei libc
 			ec := int(ei.ExitCode)
 			execConfig.Lock()
 			defer execConfig.Unlock()
+			execConfig.StopTimer()
 			execConfig.ExitCode = &ec
 			execConfig.Running = false
 			execConfig.StreamConfig.Wait()
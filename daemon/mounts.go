@@ -6,7 +6,12 @@ import (
 
 	mounttypes "github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/layer"
+	volumemounts "github.com/docker/docker/volume/mounts"
 	volumestore "github.com/docker/docker/volume/store"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
 )
 
 func (daemon *Daemon) prepareMountPoints(container *container.Container) error {
@@ -14,6 +19,9 @@ func (daemon *Daemon) prepareMountPoints(container *container.Container) error {
 		if err := daemon.lazyInitializeVolume(container.ID, config); err != nil {
 			return err
 		}
+		if err := daemon.lazyInitializeImage(container, config); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -51,3 +59,93 @@ func (daemon *Daemon) removeMountPoints(container *container.Container, rm bool)
 	}
 	return nil
 }
+
+// imageMountLayer is the read-write layer of an image mount. It is only
+// mounted read-only in the container, so that it exposes the unmodified root
+// filesystem of the image.
+type imageMountLayer struct {
+	layer.RWLayer
+}
+
+func (l imageMountLayer) Mount(mountLabel string) (string, error) {
+	fs, err := l.RWLayer.Mount(mountLabel)
+	if err != nil {
+		return "", err
+	}
+	return fs.Path(), nil
+}
+
+// imageMountLayerID returns the ID of the layer of the image mount of the
+// container with the given destination.
+func imageMountLayerID(containerID, destination string) string {
+	return containerID + "-image-" + digest.FromString(destination).Hex()[:12]
+}
+
+// createImageMount creates the layer of the image mount point m of the
+// container, on top of the root filesystem of its image. The image is pinned
+// by ID in the mount point, as its reference may later point to another
+// image.
+func (daemon *Daemon) createImageMount(container *container.Container, m *volumemounts.MountPoint) error {
+	img, err := daemon.imageService.GetImage(m.Name)
+	if err != nil {
+		return err
+	}
+	if img.OperatingSystem() != container.OS {
+		return errdefs.InvalidParameter(errors.Errorf("cannot mount image %s of operating system %q in a %q container", m.Name, img.OperatingSystem(), container.OS))
+	}
+	l, err := daemon.imageService.CreateImageMountLayer(imageMountLayerID(container.ID, m.Destination), img, container.MountLabel)
+	if err != nil {
+		return errdefs.System(errors.Wrapf(err, "error while creating layer of image mount %s", m.Destination))
+	}
+	m.Name = img.ID().String()
+	m.Image = imageMountLayer{l}
+	return nil
+}
+
+// lazyInitializeImage initializes the layer of an image mount point if needed.
+// This happens after a daemon restart.
+func (daemon *Daemon) lazyInitializeImage(container *container.Container, m *volumemounts.MountPoint) error {
+	if m.Type != mounttypes.TypeImage || m.Image != nil {
+		return nil
+	}
+	l, err := daemon.imageService.GetLayerByID(imageMountLayerID(container.ID, m.Destination), container.OS)
+	if err != nil {
+		return errors.Wrapf(err, "error while loading layer of image mount %s", m.Destination)
+	}
+	m.Image = imageMountLayer{l}
+	return nil
+}
+
+// releaseImageMount releases the layer of the image mount point m of the
+// container.
+func (daemon *Daemon) releaseImageMount(container *container.Container, m *volumemounts.MountPoint) error {
+	if err := daemon.lazyInitializeImage(container, m); err != nil {
+		if errors.Cause(err) == layer.ErrMountDoesNotExist {
+			return nil
+		}
+		return err
+	}
+	l, ok := m.Image.(imageMountLayer)
+	if !ok {
+		return nil
+	}
+	if err := daemon.imageService.ReleaseLayer(l.RWLayer, container.OS); err != nil {
+		return err
+	}
+	m.Image = nil
+	return nil
+}
+
+// releaseImageMounts releases the layers of the image mounts of the
+// container.
+func (daemon *Daemon) releaseImageMounts(container *container.Container) error {
+	for _, m := range container.MountPoints {
+		if m.Type != mounttypes.TypeImage {
+			continue
+		}
+		if err := daemon.releaseImageMount(container, m); err != nil {
+			return errors.Wrapf(err, "error while releasing image mount %s", m.Destination)
+		}
+	}
+	return nil
+}
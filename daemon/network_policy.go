@@ -0,0 +1,121 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/libnetwork"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// policyNetworkDriver is the only network driver supporting the bandwidth
+// limits and egress policies of endpoints.
+const policyNetworkDriver = "bridge"
+
+// hasEndpointPolicy returns whether the endpoint configuration sets bandwidth
+// limits or an egress policy.
+func hasEndpointPolicy(epConfig *network.EndpointSettings) bool {
+	if epConfig == nil {
+		return false
+	}
+	if epConfig.Bandwidth != nil && (epConfig.Bandwidth.IngressRate != 0 || epConfig.Bandwidth.EgressRate != 0) {
+		return true
+	}
+	return epConfig.EgressPolicy != nil && (len(epConfig.EgressPolicy.Allow) > 0 || len(epConfig.EgressPolicy.Deny) > 0)
+}
+
+// validateEndpointPolicy validates the bandwidth limits and the egress policy
+// of the endpoint configuration. They are only supported on networks of the
+// bridge driver, which is checked if the network n is not nil, and egress
+// policies are only supported if the daemon manages iptables.
+func validateEndpointPolicy(n libnetwork.Network, epConfig *network.EndpointSettings, iptables bool) error {
+	if !hasEndpointPolicy(epConfig) {
+		return nil
+	}
+	if bw := epConfig.Bandwidth; bw != nil {
+		if bw.IngressRate < 0 {
+			return errdefs.InvalidParameter(fmt.Errorf("invalid ingress rate: %d", bw.IngressRate))
+		}
+		if bw.EgressRate < 0 {
+			return errdefs.InvalidParameter(fmt.Errorf("invalid egress rate: %d", bw.EgressRate))
+		}
+	}
+	if policy := epConfig.EgressPolicy; policy != nil && (len(policy.Allow) > 0 || len(policy.Deny) > 0) {
+		if !iptables {
+			return errdefs.InvalidParameter(errors.New("egress policies require the daemon to manage iptables"))
+		}
+		for _, cidr := range append(append([]string(nil), policy.Allow...), policy.Deny...) {
+			ip, _, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return errdefs.InvalidParameter(errors.Wrap(err, "invalid egress policy"))
+			}
+			if ip.To4() == nil {
+				return errdefs.InvalidParameter(fmt.Errorf("invalid egress policy: %s is not an IPv4 CIDR", cidr))
+			}
+		}
+	}
+	if n != nil && n.Type() != policyNetworkDriver {
+		return errdefs.InvalidParameter(fmt.Errorf("network %s does not support bandwidth limits and egress policies, only networks of the %s driver do", n.Name(), policyNetworkDriver))
+	}
+	return nil
+}
+
+// UpdateContainerEndpoint updates the bandwidth limits and the egress policy
+// of the endpoint of the container on the network, with the ones of the
+// endpoint configuration. They are applied immediately if the container is
+// running.
+func (daemon *Daemon) UpdateContainerEndpoint(containerName, networkName string, endpointConfig *network.EndpointSettings) error {
+	if endpointConfig == nil {
+		endpointConfig = &network.EndpointSettings{}
+	}
+	container, err := daemon.GetContainer(containerName)
+	if err != nil {
+		return err
+	}
+	n, err := daemon.FindNetwork(networkName)
+	if err != nil {
+		return err
+	}
+	if err := validateEndpointPolicy(n, endpointConfig, daemon.managesIPTables()); err != nil {
+		return err
+	}
+
+	container.Lock()
+	defer container.Unlock()
+
+	settings, ok := container.NetworkSettings.Networks[n.Name()]
+	if !ok || settings.EndpointSettings == nil {
+		return errdefs.NotFound(fmt.Errorf("container %s is not connected to the network %s", container.ID, n.Name()))
+	}
+	var ep libnetwork.Endpoint
+	if container.Running && settings.EndpointID != "" {
+		ep, err = n.EndpointByID(settings.EndpointID)
+		if err != nil {
+			return err
+		}
+		if err := daemon.applyEndpointPolicy(ep, endpointConfig); err != nil {
+			return err
+		}
+	}
+	backup := *settings.EndpointSettings
+	settings.Bandwidth = endpointConfig.Bandwidth
+	settings.EgressPolicy = endpointConfig.EgressPolicy
+
+	if err := container.CheckpointTo(daemon.containersReplica); err != nil {
+		settings.Bandwidth = backup.Bandwidth
+		settings.EgressPolicy = backup.EgressPolicy
+		if ep != nil {
+			if rErr := daemon.applyEndpointPolicy(ep, &backup); rErr != nil {
+				logrus.WithError(rErr).WithField("container", container.ID).Errorf("failed to restore the bandwidth limits and the egress policy of the endpoint on network %s", n.Name())
+			}
+		}
+		return err
+	}
+	daemon.LogNetworkEventWithAttributes(n, "update", map[string]string{
+		"container": container.ID,
+	})
+	return nil
+}
@@ -0,0 +1,298 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"bytes"
+	"math"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/libnetwork"
+	"github.com/docker/libnetwork/iptables"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+const (
+	// egressPolicyChain is the iptables chain of the egress policies of
+	// the endpoints, jumped to from the FORWARD chain.
+	egressPolicyChain = "DOCKER-EGRESS"
+
+	// tbfBurstTime is the time of traffic at the limited rate the token
+	// bucket of a bandwidth limit holds, with at least tbfMinBurst bytes.
+	tbfBurstTime = 20 * time.Millisecond
+	tbfMinBurst  = 64 * 1024
+	// tbfLatency is the maximum time a packet waits in the queue of a
+	// bandwidth limit before being dropped.
+	tbfLatency = 50 * time.Millisecond
+)
+
+// applyEndpointPolicy sets the bandwidth limits and the egress policy of the
+// endpoint ep of a running container to the ones of the endpoint
+// configuration, removing those which are not set.
+func (daemon *Daemon) applyEndpointPolicy(ep libnetwork.Endpoint, epConfig *network.EndpointSettings) error {
+	info := ep.Info()
+	if info == nil || info.Sandbox() == nil || info.Iface() == nil {
+		if hasEndpointPolicy(epConfig) {
+			return errors.Errorf("endpoint %s is not attached to a container", ep.Name())
+		}
+		return nil
+	}
+
+	var bandwidth network.EndpointBandwidth
+	if epConfig.Bandwidth != nil {
+		bandwidth = *epConfig.Bandwidth
+	}
+	if err := setEndpointBandwidth(info.Sandbox().Key(), info.Iface().MacAddress(), bandwidth); err != nil {
+		return errors.Wrap(err, "error while setting the bandwidth limits of the endpoint")
+	}
+
+	// Egress policies are refused by validateEndpointPolicy if the daemon
+	// does not manage iptables.
+	if !daemon.managesIPTables() {
+		return nil
+	}
+	if err := setEgressPolicy(ep.ID(), info.Iface().Address(), epConfig.EgressPolicy); err != nil {
+		return errors.Wrap(err, "error while setting the egress policy of the endpoint")
+	}
+	return nil
+}
+
+// removeEndpointPolicy removes the egress policy of the endpoint with the
+// given ID. The bandwidth limits are removed with the interfaces of the
+// endpoint.
+func (daemon *Daemon) removeEndpointPolicy(id string) error {
+	if !daemon.managesIPTables() {
+		return nil
+	}
+	return removeEgressPolicy(id)
+}
+
+// managesIPTables returns whether the daemon manages iptables, which the
+// egress policies require.
+func (daemon *Daemon) managesIPTables() bool {
+	return daemon.configStore.BridgeConfig.EnableIPTables
+}
+
+// setupEgressPolicyChain creates the chain of the egress policies, and removes
+// the rules of the endpoints which no longer exist in the network controller,
+// such as those of the containers which were not restored.
+func setupEgressPolicyChain(controller libnetwork.NetworkController) error {
+	if _, err := iptables.NewChain(egressPolicyChain, iptables.Filter, false); err != nil {
+		return err
+	}
+	endpoints := make(map[string]bool)
+	for _, n := range controller.Networks() {
+		for _, ep := range n.Endpoints() {
+			endpoints[ep.ID()] = true
+		}
+	}
+	if err := removeEgressPolicies(func(id string) bool { return !endpoints[id] }); err != nil {
+		return err
+	}
+	return iptables.EnsureJumpRule("FORWARD", egressPolicyChain)
+}
+
+// setEgressPolicy replaces the rules of the egress policy of the endpoint
+// with the given ID and address.
+func setEgressPolicy(id string, addr *net.IPNet, policy *network.EndpointEgressPolicy) error {
+	if err := removeEgressPolicy(id); err != nil {
+		return err
+	}
+	if addr == nil {
+		return nil
+	}
+	rules := egressPolicyRules(id, addr.IP.String(), policy)
+	if len(rules) == 0 {
+		return nil
+	}
+	// The bridge driver inserts its rules on top of the FORWARD chain when
+	// a network is created, so the jump is moved back on top.
+	if err := iptables.EnsureJumpRule("FORWARD", egressPolicyChain); err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if err := iptables.RawCombinedOutput(append([]string{"-A", egressPolicyChain}, rule...)...); err != nil {
+			if rErr := removeEgressPolicy(id); rErr != nil {
+				return errors.Wrap(err, rErr.Error())
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// removeEgressPolicy removes the rules of the egress policy of the endpoint
+// with the given ID.
+func removeEgressPolicy(id string) error {
+	return removeEgressPolicies(func(ruleID string) bool { return ruleID == id })
+}
+
+// removeEgressPolicies removes the rules of the egress policies of the
+// endpoints whose ID, found in the comment of the rules, matches remove.
+func removeEgressPolicies(remove func(id string) bool) error {
+	if !iptables.ExistChain(egressPolicyChain, iptables.Filter) {
+		return nil
+	}
+	out, err := iptables.Raw("-S", egressPolicyChain)
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		rule := strings.Fields(line)
+		if len(rule) == 0 || rule[0] != "-A" {
+			continue
+		}
+		if id, ok := ruleComment(rule); !ok || !remove(id) {
+			continue
+		}
+		rule[0] = "-D"
+		for i := range rule {
+			rule[i] = strings.Trim(rule[i], `"`)
+		}
+		if err := iptables.RawCombinedOutput(rule...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ruleComment returns the comment of the iptables rule, and whether it has
+// one.
+func ruleComment(rule []string) (string, bool) {
+	for i := 0; i < len(rule)-1; i++ {
+		if rule[i] == "--comment" {
+			return strings.Trim(rule[i+1], `"`), true
+		}
+	}
+	return "", false
+}
+
+// egressPolicyRules returns the iptables rules of the egress policy of the
+// endpoint with the given ID and IP address. The traffic of the connections
+// made to the container is always allowed, denied destinations are dropped
+// first, then all but the allowed destinations if any.
+func egressPolicyRules(id, ip string, policy *network.EndpointEgressPolicy) [][]string {
+	if policy == nil || (len(policy.Allow) == 0 && len(policy.Deny) == 0) {
+		return nil
+	}
+	rule := func(target string, matches ...string) []string {
+		r := append([]string{"-s", ip}, matches...)
+		return append(r, "-m", "comment", "--comment", id, "-j", target)
+	}
+	rules := [][]string{rule("RETURN", "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED")}
+	for _, cidr := range policy.Deny {
+		rules = append(rules, rule("DROP", "-d", cidr))
+	}
+	for _, cidr := range policy.Allow {
+		rules = append(rules, rule("RETURN", "-d", cidr))
+	}
+	if len(policy.Allow) > 0 {
+		rules = append(rules, rule("DROP"))
+	}
+	return rules
+}
+
+// setEndpointBandwidth sets the bandwidth limits of the endpoint with the
+// given MAC address in the network namespace of the sandbox. The traffic sent
+// by the container is limited on its interface, and the traffic it receives on
+// the host side of its veth pair.
+func setEndpointBandwidth(sandboxKey string, mac net.HardwareAddr, bandwidth network.EndpointBandwidth) error {
+	sbNs, err := netns.GetFromPath(sandboxKey)
+	if err != nil {
+		return err
+	}
+	defer sbNs.Close()
+	sbHandle, err := netlink.NewHandleAt(sbNs, syscall.NETLINK_ROUTE)
+	if err != nil {
+		return err
+	}
+	defer sbHandle.Delete()
+
+	link, err := linkByHardwareAddr(sbHandle, mac)
+	if err != nil {
+		return err
+	}
+
+	handle, err := netlink.NewHandle(syscall.NETLINK_ROUTE)
+	if err != nil {
+		return err
+	}
+	defer handle.Delete()
+	hostLink, err := handle.LinkByIndex(link.Attrs().ParentIndex)
+	if err != nil || hostLink.Type() != "veth" {
+		return errors.Errorf("no host interface for the interface %s of the container", link.Attrs().Name)
+	}
+
+	if err := setRateLimit(sbHandle, link, bandwidth.EgressRate); err != nil {
+		return err
+	}
+	return setRateLimit(handle, hostLink, bandwidth.IngressRate)
+}
+
+// linkByHardwareAddr returns the link with the given MAC address of the
+// network namespace of the handle h.
+func linkByHardwareAddr(h *netlink.Handle, mac net.HardwareAddr) (netlink.Link, error) {
+	links, err := h.LinkList()
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range links {
+		if bytes.Equal(l.Attrs().HardwareAddr, mac) {
+			return l, nil
+		}
+	}
+	return nil, errors.Errorf("no interface with address %s in the container", mac)
+}
+
+// setRateLimit limits the traffic sent on the link to the given rate, in
+// bytes per second, with a tbf root qdisc. The limit is removed if the rate
+// is zero.
+func setRateLimit(h *netlink.Handle, link netlink.Link, rate int64) error {
+	qdiscs, err := h.QdiscList(link)
+	if err != nil {
+		return err
+	}
+	var current netlink.Qdisc
+	for _, q := range qdiscs {
+		if q.Attrs().Parent == netlink.HANDLE_ROOT && q.Type() == "tbf" {
+			current = q
+		}
+	}
+	if rate == 0 {
+		if current == nil {
+			return nil
+		}
+		return h.QdiscDel(current)
+	}
+	return h.QdiscReplace(newTbf(link.Attrs().Index, uint64(rate)))
+}
+
+// newTbf returns a tbf root qdisc of the link with the given index, limiting
+// its traffic to the given rate in bytes per second.
+func newTbf(linkIndex int, rate uint64) *netlink.Tbf {
+	burst := uint64(float64(rate) * tbfBurstTime.Seconds())
+	if burst < tbfMinBurst {
+		burst = tbfMinBurst
+	}
+	limit := uint64(float64(rate)*tbfLatency.Seconds()) + burst
+	if burst > math.MaxUint32 {
+		burst = math.MaxUint32
+	}
+	if limit > math.MaxUint32 {
+		limit = math.MaxUint32
+	}
+	return &netlink.Tbf{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: linkIndex,
+			Handle:    netlink.MakeHandle(1, 0),
+			Parent:    netlink.HANDLE_ROOT,
+		},
+		Rate:   rate,
+		Limit:  uint32(limit),
+		Buffer: uint32(netlink.Xmittime(rate, uint32(burst))),
+	}
+}
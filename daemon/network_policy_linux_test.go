@@ -0,0 +1,71 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+func TestValidateEndpointPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		config   *network.EndpointSettings
+		iptables bool
+		expected string
+	}{
+		{config: &network.EndpointSettings{}},
+		{config: &network.EndpointSettings{Bandwidth: &network.EndpointBandwidth{IngressRate: 1024, EgressRate: 2048}}},
+		{config: &network.EndpointSettings{EgressPolicy: &network.EndpointEgressPolicy{Allow: []string{"10.0.0.0/8"}, Deny: []string{"10.1.0.0/16"}}}, iptables: true},
+		{config: &network.EndpointSettings{EgressPolicy: &network.EndpointEgressPolicy{Allow: []string{"10.0.0.0/8"}}}, expected: "egress policies require the daemon to manage iptables"},
+		{config: &network.EndpointSettings{Bandwidth: &network.EndpointBandwidth{EgressRate: -1}}, expected: "invalid egress rate: -1"},
+		{config: &network.EndpointSettings{EgressPolicy: &network.EndpointEgressPolicy{Deny: []string{"10.0.0.1"}}}, iptables: true, expected: "invalid CIDR address: 10.0.0.1"},
+		{config: &network.EndpointSettings{EgressPolicy: &network.EndpointEgressPolicy{Allow: []string{"fd00::/64"}}}, iptables: true, expected: "fd00::/64 is not an IPv4 CIDR"},
+	} {
+		err := validateEndpointPolicy(nil, tc.config, tc.iptables)
+		if tc.expected == "" {
+			assert.Check(t, err)
+		} else {
+			assert.Check(t, is.ErrorContains(err, tc.expected))
+		}
+	}
+}
+
+func TestEgressPolicyRules(t *testing.T) {
+	assert.Check(t, is.Len(egressPolicyRules("ep", "172.18.0.2", &network.EndpointEgressPolicy{}), 0))
+
+	rules := egressPolicyRules("ep", "172.18.0.2", &network.EndpointEgressPolicy{
+		Allow: []string{"10.0.0.0/8"},
+		Deny:  []string{"10.1.0.0/16"},
+	})
+	comment := []string{"-m", "comment", "--comment", "ep"}
+	expected := [][]string{
+		append(append([]string{"-s", "172.18.0.2", "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED"}, comment...), "-j", "RETURN"),
+		append(append([]string{"-s", "172.18.0.2", "-d", "10.1.0.0/16"}, comment...), "-j", "DROP"),
+		append(append([]string{"-s", "172.18.0.2", "-d", "10.0.0.0/8"}, comment...), "-j", "RETURN"),
+		append(append([]string{"-s", "172.18.0.2"}, comment...), "-j", "DROP"),
+	}
+	assert.Check(t, is.DeepEqual(rules, expected))
+
+	// without allowed destinations, only the denied ones are dropped
+	rules = egressPolicyRules("ep", "172.18.0.2", &network.EndpointEgressPolicy{Deny: []string{"10.1.0.0/16"}})
+	assert.Check(t, is.DeepEqual(rules, expected[:2]))
+
+	id, ok := ruleComment([]string{"-A", "DOCKER-EGRESS", "--comment", `"ep"`, "-j", "DROP"})
+	assert.Check(t, ok)
+	assert.Check(t, is.Equal(id, "ep"))
+	_, ok = ruleComment([]string{"-A", "DOCKER-EGRESS", "-j", "DROP"})
+	assert.Check(t, !ok)
+}
+
+func TestNewTbf(t *testing.T) {
+	tbf := newTbf(3, 1024*1024)
+	assert.Check(t, is.Equal(tbf.LinkIndex, 3))
+	assert.Check(t, is.Equal(tbf.Rate, uint64(1024*1024)))
+	// the burst is at least tbfMinBurst, and the queue holds 50ms more
+	assert.Check(t, is.Equal(tbf.Limit, uint32(tbfMinBurst+52428)))
+	assert.Check(t, tbf.Buffer > 0)
+
+	tbf = newTbf(3, 1<<40)
+	assert.Check(t, is.Equal(tbf.Limit, uint32(1<<32-1)))
+}
@@ -0,0 +1,29 @@
+// +build !linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"errors"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/libnetwork"
+)
+
+func (daemon *Daemon) applyEndpointPolicy(ep libnetwork.Endpoint, epConfig *network.EndpointSettings) error {
+	if hasEndpointPolicy(epConfig) {
+		return errors.New("bandwidth limits and egress policies are not supported on this platform")
+	}
+	return nil
+}
+
+func (daemon *Daemon) removeEndpointPolicy(id string) error {
+	return nil
+}
+
+func (daemon *Daemon) managesIPTables() bool {
+	return false
+}
+
+func setupEgressPolicyChain(controller libnetwork.NetworkController) error {
+	return nil
+}
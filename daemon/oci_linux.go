@@ -18,6 +18,7 @@ import (
 	"github.com/docker/docker/oci"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/docker/pkg/sysinfo"
 	volumemounts "github.com/docker/docker/volume/mounts"
 	"github.com/opencontainers/runc/libcontainer/apparmor"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
@@ -84,6 +85,9 @@ func setResources(s *specs.Spec, r containertypes.Resources) error {
 	}
 
 	s.Linux.Resources = specResources
+	if sysinfo.IsCgroup2UnifiedMode() {
+		return setCgroup2Resources(s, r)
+	}
 	return nil
 }
 
@@ -871,12 +875,13 @@ func (daemon *Daemon) createSpec(c *container.Container) (retSpec *specs.Spec, e
 	for _, ns := range s.Linux.Namespaces {
 		if ns.Type == "network" && ns.Path == "" && !c.Config.NetworkDisabled {
 			target := filepath.Join("/proc", strconv.Itoa(os.Getpid()), "exe")
-			s.Hooks = &specs.Hooks{
-				Prestart: []specs.Hook{{
-					Path: target,
-					Args: []string{"libnetwork-setkey", c.ID, daemon.netController.ID()},
-				}},
+			if s.Hooks == nil {
+				s.Hooks = &specs.Hooks{}
 			}
+			s.Hooks.Prestart = append(s.Hooks.Prestart, specs.Hook{
+				Path: target,
+				Args: []string{"libnetwork-setkey", c.ID, daemon.netController.ID()},
+			})
 		}
 	}
 
@@ -0,0 +1,319 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/opencontainers/runc/libcontainer/system"
+	"github.com/pkg/errors"
+)
+
+// procStat holds the fields of /proc/<pid>/stat used by the daemon.
+type procStat struct {
+	pid       int
+	comm      string
+	state     byte
+	ppid      int
+	utime     uint64 // clock ticks
+	stime     uint64 // clock ticks
+	startTime uint64 // clock ticks after boot
+	vsize     uint64 // bytes
+	rss       uint64 // pages
+}
+
+// parseProcStat parses the content of /proc/<pid>/stat. The command name, in
+// parentheses, may contain spaces and parentheses, so the other fields are
+// read after the last closing parenthesis.
+func parseProcStat(data []byte) (procStat, error) {
+	var st procStat
+	open := bytes.IndexByte(data, '(')
+	end := bytes.LastIndexByte(data, ')')
+	if open < 0 || end < open {
+		return st, fmt.Errorf("invalid stat format: %q", data)
+	}
+	pid, err := strconv.Atoi(string(bytes.TrimSpace(data[:open])))
+	if err != nil {
+		return st, fmt.Errorf("invalid stat format: %q", data)
+	}
+	st.pid = pid
+	st.comm = string(data[open+1 : end])
+
+	// fields[0] is field 3 of proc(5)
+	fields := strings.Fields(string(data[end+1:]))
+	if len(fields) < 22 {
+		return st, fmt.Errorf("invalid stat format: %q", data)
+	}
+	st.state = fields[0][0]
+	for _, f := range []struct {
+		index int
+		int   *int
+		uint  *uint64
+	}{
+		{index: 4, int: &st.ppid},
+		{index: 14, uint: &st.utime},
+		{index: 15, uint: &st.stime},
+		{index: 22, uint: &st.startTime},
+		{index: 23, uint: &st.vsize},
+		{index: 24, uint: &st.rss},
+	} {
+		value := fields[f.index-3]
+		if f.int != nil {
+			*f.int, err = strconv.Atoi(value)
+		} else {
+			*f.uint, err = strconv.ParseUint(value, 10, 64)
+		}
+		if err != nil {
+			return st, fmt.Errorf("invalid stat field %d: %q", f.index, value)
+		}
+	}
+	return st, nil
+}
+
+var procStates = map[byte]string{
+	'R': "running",
+	'S': "sleeping",
+	'D': "disk sleep",
+	'T': "stopped",
+	't': "tracing stop",
+	'X': "dead",
+	'Z': "zombie",
+	'P': "parked",
+	'I': "idle",
+}
+
+// procStatus holds the fields of /proc/<pid>/status used by the daemon.
+type procStatus struct {
+	nspid int // PID in the innermost PID namespace of the process
+	uid   int
+	gid   int
+}
+
+func parseProcStatus(data []byte) (procStatus, error) {
+	var st procStatus
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		kv := strings.SplitN(s.Text(), ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		fields := strings.Fields(kv[1])
+		if len(fields) == 0 {
+			continue
+		}
+		var err error
+		switch kv[0] {
+		case "NSpid":
+			st.nspid, err = strconv.Atoi(fields[len(fields)-1])
+		case "Uid":
+			st.uid, err = strconv.Atoi(fields[0])
+		case "Gid":
+			st.gid, err = strconv.Atoi(fields[0])
+		}
+		if err != nil {
+			return st, fmt.Errorf("invalid status field %s: %q", kv[0], kv[1])
+		}
+	}
+	return st, s.Err()
+}
+
+// ticksToNanoseconds converts a number of clock ticks, at the given number of
+// ticks per second, to nanoseconds. The whole seconds and the remainder are
+// converted separately, so that large tick counts do not overflow.
+func ticksToNanoseconds(t, ticks uint64) uint64 {
+	return t/ticks*uint64(time.Second) + t%ticks*uint64(time.Second)/ticks
+}
+
+// bootTime returns the time the host booted, from /proc/stat.
+func bootTime() (time.Time, error) {
+	data, err := ioutil.ReadFile("/proc/stat")
+	if err != nil {
+		return time.Time{}, err
+	}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 2 && fields[0] == "btime" {
+			sec, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return time.Time{}, err
+			}
+			return time.Unix(sec, 0), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("btime not found in /proc/stat")
+}
+
+// readProcessInfo reads the information about the process pid from /proc.
+// The optional fields are only read when they are set in fields.
+func readProcessInfo(pid int, fields map[string]bool, boot time.Time, now time.Time) (container.ProcessInfo, error) {
+	dir := filepath.Join("/proc", strconv.Itoa(pid))
+	data, err := ioutil.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return container.ProcessInfo{}, err
+	}
+	st, err := parseProcStat(data)
+	if err != nil {
+		return container.ProcessInfo{}, err
+	}
+	p := container.ProcessInfo{
+		PID:     st.pid,
+		PPID:    st.ppid,
+		Command: st.comm,
+		State:   procStates[st.state],
+	}
+	if p.State == "" {
+		p.State = fmt.Sprintf("unknown (%c)", st.state)
+	}
+
+	if fields[container.ProcessFieldNSPID] || fields[container.ProcessFieldUser] {
+		data, err := ioutil.ReadFile(filepath.Join(dir, "status"))
+		if err != nil {
+			return p, err
+		}
+		status, err := parseProcStatus(data)
+		if err != nil {
+			return p, err
+		}
+		if fields[container.ProcessFieldNSPID] {
+			p.NSPID = status.nspid
+		}
+		if fields[container.ProcessFieldUser] {
+			p.UID, p.GID = &status.uid, &status.gid
+		}
+	}
+	if fields[container.ProcessFieldArgs] {
+		data, err := ioutil.ReadFile(filepath.Join(dir, "cmdline"))
+		if err != nil {
+			return p, err
+		}
+		if data = bytes.TrimRight(data, "\x00"); len(data) > 0 {
+			p.Args = strings.Split(string(data), "\x00")
+		}
+	}
+	if fields[container.ProcessFieldCPU] {
+		ticks := uint64(system.GetClockTicks())
+		cpuTime := ticksToNanoseconds(st.utime+st.stime, ticks)
+		var percent float64
+		started := boot.Add(time.Duration(ticksToNanoseconds(st.startTime, ticks)))
+		if elapsed := now.Sub(started); elapsed > 0 {
+			percent = float64(cpuTime) / float64(elapsed) * 100
+		}
+		p.CPUTime, p.CPUPercent = &cpuTime, &percent
+	}
+	if fields[container.ProcessFieldMemory] {
+		rss := st.rss * uint64(os.Getpagesize())
+		p.MemoryRSS, p.MemoryVirtual = &rss, &st.vsize
+	}
+	if fields[container.ProcessFieldFDs] {
+		fds, err := ioutil.ReadDir(filepath.Join(dir, "fd"))
+		if err != nil {
+			return p, err
+		}
+		n := len(fds)
+		p.FDs = &n
+	}
+	return p, nil
+}
+
+// buildProcessTree nests each process under its parent, and returns the
+// processes whose parent is not in procs, ordered by PID.
+func buildProcessTree(procs []container.ProcessInfo) []container.ProcessInfo {
+	byPID := make(map[int]bool, len(procs))
+	children := make(map[int][]container.ProcessInfo)
+	for _, p := range procs {
+		byPID[p.PID] = true
+	}
+	var roots []container.ProcessInfo
+	for _, p := range procs {
+		if byPID[p.PPID] && p.PPID != p.PID {
+			children[p.PPID] = append(children[p.PPID], p)
+		} else {
+			roots = append(roots, p)
+		}
+	}
+
+	var attach func(procs []container.ProcessInfo) []container.ProcessInfo
+	attach = func(procs []container.ProcessInfo) []container.ProcessInfo {
+		sort.Slice(procs, func(i, j int) bool { return procs[i].PID < procs[j].PID })
+		for i := range procs {
+			if c, ok := children[procs[i].PID]; ok {
+				// Each process is attached once, which also guards
+				// against cycles.
+				delete(children, procs[i].PID)
+				procs[i].Children = attach(c)
+			}
+		}
+		return procs
+	}
+	return attach(roots)
+}
+
+// ContainerProcesses returns the tree of the processes running in the
+// given container, read from /proc. The optional fields of the processes
+// listed in fields are filled in as well.
+func (daemon *Daemon) ContainerProcesses(name string, fields []string) (*container.ContainerProcessList, error) {
+	want := make(map[string]bool)
+	for _, f := range fields {
+		valid := false
+		for _, known := range container.ProcessFields {
+			if f == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, errdefs.InvalidParameter(fmt.Errorf("invalid process field %q: valid fields are %s", f, strings.Join(container.ProcessFields, ", ")))
+		}
+		want[f] = true
+	}
+
+	ctr, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+	if !ctr.IsRunning() {
+		return nil, errNotRunning(ctr.ID)
+	}
+	if ctr.IsRestarting() {
+		return nil, errContainerIsRestarting(ctr.ID)
+	}
+
+	pids, err := daemon.containerd.ListPids(context.Background(), ctr.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var boot time.Time
+	if want[container.ProcessFieldCPU] {
+		if boot, err = bootTime(); err != nil {
+			return nil, errdefs.System(err)
+		}
+	}
+	now := time.Now()
+	var procs []container.ProcessInfo
+	for _, pid := range pids {
+		p, err := readProcessInfo(int(pid), want, boot, now)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// The process exited since the PIDs were listed.
+				continue
+			}
+			return nil, errdefs.System(errors.Wrapf(err, "failed to read process %d", pid))
+		}
+		procs = append(procs, p)
+	}
+
+	daemon.LogContainerEvent(ctr, "top")
+	return &container.ContainerProcessList{Processes: buildProcessTree(procs)}, nil
+}
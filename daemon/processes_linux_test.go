@@ -0,0 +1,59 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+func TestParseProcStat(t *testing.T) {
+	st, err := parseProcStat([]byte("89653 (gunicorn: (maste)) S 89630 89653 89653 0 -1 4194560 29689 28896 0 3 146 32 76 19 20 0 1 0 2971844 52965376 3920 18446744073709551615 1 1 0 0 0 0 0 16781312 137447943 0 0 0 17 1 0 0 0 0 0 0 0 0 0 0 0 0 0\n"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(st.pid, 89653))
+	assert.Check(t, is.Equal(st.comm, "gunicorn: (maste)"))
+	assert.Check(t, is.Equal(st.state, byte('S')))
+	assert.Check(t, is.Equal(st.ppid, 89630))
+	assert.Check(t, is.Equal(st.utime, uint64(146)))
+	assert.Check(t, is.Equal(st.stime, uint64(32)))
+	assert.Check(t, is.Equal(st.startTime, uint64(2971844)))
+	assert.Check(t, is.Equal(st.vsize, uint64(52965376)))
+	assert.Check(t, is.Equal(st.rss, uint64(3920)))
+
+	_, err = parseProcStat([]byte("1234 (sh) S 1"))
+	assert.Check(t, is.ErrorContains(err, "invalid stat format"))
+}
+
+func TestParseProcStatus(t *testing.T) {
+	st, err := parseProcStatus([]byte("Name:\tsh\nState:\tS (sleeping)\nUid:\t1000\t1000\t1000\t1000\nGid:\t100\t100\t100\t100\nNSpid:\t4242\t7\n"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(st.nspid, 7))
+	assert.Check(t, is.Equal(st.uid, 1000))
+	assert.Check(t, is.Equal(st.gid, 100))
+}
+
+func TestTicksToNanoseconds(t *testing.T) {
+	assert.Check(t, is.Equal(ticksToNanoseconds(150, 100), uint64(1500000000)))
+	assert.Check(t, is.Equal(ticksToNanoseconds(1, 3), uint64(333333333)))
+	// multiplying first would overflow
+	assert.Check(t, is.Equal(ticksToNanoseconds(1<<40, 1000), uint64(1099511627776000000)))
+}
+
+func TestBuildProcessTree(t *testing.T) {
+	tree := buildProcessTree([]container.ProcessInfo{
+		{PID: 30, PPID: 10},
+		{PID: 20, PPID: 10},
+		{PID: 10, PPID: 1},
+		{PID: 40, PPID: 20},
+		{PID: 50, PPID: 1},
+	})
+	assert.Assert(t, is.Len(tree, 2))
+	assert.Check(t, is.Equal(tree[0].PID, 10))
+	assert.Check(t, is.Equal(tree[1].PID, 50))
+	assert.Assert(t, is.Len(tree[0].Children, 2))
+	assert.Check(t, is.Equal(tree[0].Children[0].PID, 20))
+	assert.Check(t, is.Equal(tree[0].Children[1].PID, 30))
+	assert.Assert(t, is.Len(tree[0].Children[0].Children, 1))
+	assert.Check(t, is.Equal(tree[0].Children[0].Children[0].PID, 40))
+}
@@ -0,0 +1,16 @@
+// +build !linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+)
+
+// ContainerProcesses is not implemented on this platform.
+func (daemon *Daemon) ContainerProcesses(name string, fields []string) (*container.ContainerProcessList, error) {
+	return nil, errdefs.NotImplemented(fmt.Errorf("listing the processes of a container is not supported on %s", runtime.GOOS))
+}
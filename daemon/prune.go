@@ -10,6 +10,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	timetypes "github.com/docker/docker/api/types/time"
+	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/directory"
 	"github.com/docker/docker/runconfig"
 	"github.com/docker/docker/volume"
@@ -28,8 +29,9 @@ var (
 		"until":  true,
 	}
 	volumesAcceptedFilters = map[string]bool{
-		"label":  true,
-		"label!": true,
+		"label":      true,
+		"label!":     true,
+		"unused-for": true,
 	}
 
 	networksAcceptedFilters = map[string]bool{
@@ -105,6 +107,11 @@ func (daemon *Daemon) VolumesPrune(ctx context.Context, pruneFilters filters.Arg
 		return nil, err
 	}
 
+	unusedFor, err := getUnusedForFromPruneFilters(pruneFilters)
+	if err != nil {
+		return nil, errdefs.InvalidParameter(err)
+	}
+
 	rep := &types.VolumesPruneReport{}
 
 	volumes, err := daemon.volumes.FilterByDriver(volume.DefaultDriverName)
@@ -134,6 +141,9 @@ func (daemon *Daemon) VolumesPrune(ctx context.Context, pruneFilters filters.Arg
 					continue
 				}
 			}
+			if unusedFor > 0 && !daemon.volumeUnusedFor(v, unusedFor) {
+				continue
+			}
 
 			vSize, err := directory.Size(ctx, v.Path())
 			if err != nil {
@@ -300,6 +310,44 @@ func getUntilFromPruneFilters(pruneFilters filters.Args) (time.Time, error) {
 	return until, nil
 }
 
+// getUnusedForFromPruneFilters returns the duration of the unused-for filter
+// of the volume prune filters, or 0 if there is none.
+func getUnusedForFromPruneFilters(pruneFilters filters.Args) (time.Duration, error) {
+	if !pruneFilters.Contains("unused-for") {
+		return 0, nil
+	}
+	unusedForFilters := pruneFilters.Get("unused-for")
+	if len(unusedForFilters) > 1 {
+		return 0, fmt.Errorf("more than one unused-for filter specified")
+	}
+	d, err := time.ParseDuration(unusedForFilters[0])
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid unused-for filter: %s", unusedForFilters[0])
+	}
+	return d, nil
+}
+
+// volumeUnusedFor returns whether the volume v was not mounted for at least
+// the duration d, according to its usage history. A volume which was never
+// mounted is considered used when it was created.
+func (daemon *Daemon) volumeUnusedFor(v volume.Volume, d time.Duration) bool {
+	usage, err := daemon.volumes.Usage(v)
+	if err != nil {
+		logrus.Warnf("could not determine usage of volume %s: %v", v.Name(), err)
+		return false
+	}
+	lastUsed := usage.LastMounted
+	if usage.LastUnmounted.After(lastUsed) {
+		lastUsed = usage.LastUnmounted
+	}
+	if lastUsed.IsZero() {
+		if lastUsed, err = v.CreatedAt(); err != nil || lastUsed.IsZero() {
+			return false
+		}
+	}
+	return time.Since(lastUsed) >= d
+}
+
 func matchLabels(pruneFilters filters.Args, labels map[string]string) bool {
 	if !pruneFilters.MatchKVList("label", labels) {
 		return false
@@ -0,0 +1,163 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/names"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/layer"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ContainerSnapshotCreate saves the writable layer of a stopped container to
+// a new snapshot, to which the container can later be rolled back.
+func (daemon *Daemon) ContainerSnapshotCreate(name string, config types.ContainerSnapshotCreateOptions) error {
+	if !names.RestrictedNamePattern.MatchString(config.Name) {
+		return errdefs.InvalidParameter(fmt.Errorf("Invalid snapshot name (%s), only %s are allowed", config.Name, names.RestrictedNameChars))
+	}
+
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	container.Lock()
+	defer container.Unlock()
+
+	if err := checkSnapshotState(container); err != nil {
+		return err
+	}
+	if snapshotIndex(container, config.Name) >= 0 {
+		return errdefs.Conflict(fmt.Errorf("snapshot %s already exists for container %s", config.Name, name))
+	}
+
+	if err := daemon.imageService.SnapshotLayer(container.ID, container.OS, config.Name); err != nil {
+		return daemon.snapshotError(container, err)
+	}
+
+	container.Snapshots = append(container.Snapshots, types.ContainerSnapshot{
+		Name:    config.Name,
+		Created: time.Now().UTC(),
+	})
+	if err := container.CheckpointTo(daemon.containersReplica); err != nil {
+		container.Snapshots = container.Snapshots[:len(container.Snapshots)-1]
+		if rmErr := daemon.imageService.RemoveLayerSnapshot(container.ID, container.OS, config.Name); rmErr != nil {
+			logrus.WithError(rmErr).WithField("container", container.ID).Errorf("failed to remove snapshot %s", config.Name)
+		}
+		return err
+	}
+
+	daemon.LogContainerEventWithAttributes(container, "snapshot", map[string]string{"snapshot": config.Name})
+	return nil
+}
+
+// ContainerSnapshotList returns the snapshots of the writable layer of a
+// container, oldest first.
+func (daemon *Daemon) ContainerSnapshotList(name string) ([]types.ContainerSnapshot, error) {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	container.Lock()
+	defer container.Unlock()
+
+	snapshots := make([]types.ContainerSnapshot, len(container.Snapshots))
+	copy(snapshots, container.Snapshots)
+	return snapshots, nil
+}
+
+// ContainerSnapshotRollback restores the writable layer of a stopped
+// container from one of its snapshots. The snapshot is kept.
+func (daemon *Daemon) ContainerSnapshotRollback(name, snapshot string) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	container.Lock()
+	defer container.Unlock()
+
+	if err := checkSnapshotState(container); err != nil {
+		return err
+	}
+	if snapshotIndex(container, snapshot) < 0 {
+		return errdefs.NotFound(fmt.Errorf("snapshot %s does not exist for container %s", snapshot, name))
+	}
+
+	if err := daemon.imageService.RollbackLayer(container.ID, container.OS, snapshot); err != nil {
+		return daemon.snapshotError(container, err)
+	}
+
+	daemon.LogContainerEventWithAttributes(container, "snapshot-rollback", map[string]string{"snapshot": snapshot})
+	return nil
+}
+
+// ContainerSnapshotDelete removes a snapshot of the writable layer of a
+// container.
+func (daemon *Daemon) ContainerSnapshotDelete(name, snapshot string) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	container.Lock()
+	defer container.Unlock()
+
+	i := snapshotIndex(container, snapshot)
+	if i < 0 {
+		return errdefs.NotFound(fmt.Errorf("snapshot %s does not exist for container %s", snapshot, name))
+	}
+
+	if err := daemon.imageService.RemoveLayerSnapshot(container.ID, container.OS, snapshot); err != nil {
+		return daemon.snapshotError(container, err)
+	}
+
+	container.Snapshots = append(container.Snapshots[:i:i], container.Snapshots[i+1:]...)
+	if err := container.CheckpointTo(daemon.containersReplica); err != nil {
+		return err
+	}
+
+	daemon.LogContainerEventWithAttributes(container, "snapshot-delete", map[string]string{"snapshot": snapshot})
+	return nil
+}
+
+// checkSnapshotState returns an error if the writable layer of the locked
+// container c may be in use, so that it cannot be saved or rolled back.
+func checkSnapshotState(c *container.Container) error {
+	if c.Running {
+		return errdefs.Conflict(fmt.Errorf("container %s is running, stop the container before taking or restoring a snapshot", c.ID))
+	}
+	if c.RemovalInProgress || c.Dead {
+		return errdefs.Conflict(fmt.Errorf("container %s is marked for removal", c.ID))
+	}
+	return nil
+}
+
+// snapshotIndex returns the index in the snapshots of the container c of
+// the snapshot with the given name, or -1.
+func snapshotIndex(c *container.Container, name string) int {
+	for i, s := range c.Snapshots {
+		if s.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// snapshotError returns the error err of the storage driver of the container
+// c for a snapshot operation, as an error of the API.
+func (daemon *Daemon) snapshotError(c *container.Container, err error) error {
+	switch {
+	case err == layer.ErrSnapshotNotSupported:
+		return errdefs.NotImplemented(errors.Errorf("the %s storage driver does not support snapshots of containers", daemon.imageService.GraphDriverForOS(c.OS)))
+	case errdefs.IsNotImplemented(err), errdefs.IsConflict(err):
+		return err
+	default:
+		return errdefs.System(err)
+	}
+}
@@ -205,6 +205,7 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 			Errorf("failed to store container")
 	}
 
+	daemon.imageService.RecordImageUse(container.ImageID)
 	daemon.LogContainerEvent(container, "start")
 	containerActions.WithValues("start").UpdateSince(start)
 
@@ -241,7 +242,7 @@ func (daemon *Daemon) Cleanup(container *container.Container) {
 	}
 
 	if container.BaseFS != nil && container.BaseFS.Path() != "" {
-		if err := container.UnmountVolumes(daemon.LogVolumeEvent); err != nil {
+		if err := container.UnmountVolumes(daemon.logVolumeMountEvent); err != nil {
 			logrus.Warnf("%s cleanup: Failed to umount volumes: %v", container.ID, err)
 		}
 	}
@@ -0,0 +1,360 @@
+package stats // import "github.com/docker/docker/daemon/stats"
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/sysinfo"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// collectCgroupStats adds to stats the metrics which are not reported by
+// containerd, read from the cgroup filesystem: pressure stall information,
+// memory events and burst throttling data, and, with cgroup v2, IO latency
+// and hugetlb usage. Errors are logged, as these metrics are best-effort.
+func (s *Collector) collectCgroupStats(c *container.Container, stats *types.StatsJSON) {
+	pid := c.GetPID()
+	if pid == 0 {
+		return
+	}
+	paths, err := cgroups.ParseCgroupFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		logrus.WithError(err).WithField("container_id", c.ID).Debug("reading container cgroup")
+		return
+	}
+	if sysinfo.IsCgroup2UnifiedMode() {
+		err = addCgroup2Stats(filepath.Join(sysinfo.Cgroup2Root, paths[""]), stats)
+	} else {
+		err = addCgroup1Stats(paths, stats)
+	}
+	if err != nil {
+		logrus.WithError(err).WithField("container_id", c.ID).Debug("collecting cgroup stats")
+	}
+}
+
+// addCgroup2Stats adds the metrics of the cgroup v2 directory dir to stats.
+func addCgroup2Stats(dir string, stats *types.StatsJSON) error {
+	pressure, err := readPressureStats(dir)
+	if err != nil {
+		return err
+	}
+	stats.PressureStats = pressure
+
+	if values, err := readFlatKeyed(filepath.Join(dir, "memory.events")); err != nil {
+		return err
+	} else if values != nil {
+		stats.MemoryStats.Events = &types.MemoryEvents{
+			Low:     values["low"],
+			High:    values["high"],
+			Max:     values["max"],
+			OOM:     values["oom"],
+			OOMKill: values["oom_kill"],
+		}
+	}
+
+	values, err := readFlatKeyed(filepath.Join(dir, "cpu.stat"))
+	if err != nil {
+		return err
+	}
+	setThrottlingData(&stats.CPUStats.ThrottlingData, values)
+
+	data, err := readCgroupFile(filepath.Join(dir, "io.stat"))
+	if err != nil {
+		return err
+	}
+	latency, err := parseIOLatency(data)
+	if err != nil {
+		return err
+	}
+	stats.BlkioStats.IoLatencyRecursive = latency
+
+	hugetlb, err := readHugetlbStats(dir)
+	if err != nil {
+		return err
+	}
+	stats.HugetlbStats = hugetlb
+	return nil
+}
+
+// addCgroup1Stats adds the metrics of the cgroup v1 hierarchies paths, as
+// read from /proc/<pid>/cgroup, to stats. The hugetlb usage and the other
+// IO statistics are reported by containerd.
+func addCgroup1Stats(paths map[string]string, stats *types.StatsJSON) error {
+	// With cgroup v1, the pressure files are found in the cpuacct
+	// hierarchy when the kernel is booted with psi_v1.
+	if dir, err := cgroup1Dir("cpuacct", paths); err == nil {
+		pressure, err := readPressureStats(dir)
+		if err != nil {
+			return err
+		}
+		stats.PressureStats = pressure
+	}
+
+	if dir, err := cgroup1Dir("memory", paths); err == nil {
+		values, err := readFlatKeyed(filepath.Join(dir, "memory.oom_control"))
+		if err != nil {
+			return err
+		}
+		if values != nil {
+			stats.MemoryStats.Events = &types.MemoryEvents{
+				Max:     stats.MemoryStats.Failcnt,
+				OOMKill: values["oom_kill"],
+			}
+		}
+	}
+
+	if dir, err := cgroup1Dir("cpu", paths); err == nil {
+		values, err := readFlatKeyed(filepath.Join(dir, "cpu.stat"))
+		if err != nil {
+			return err
+		}
+		setThrottlingData(&stats.CPUStats.ThrottlingData, values)
+	}
+	return nil
+}
+
+// cgroup1Dir returns the directory of the cgroup v1 subsystem from the
+// cgroup paths of a process.
+func cgroup1Dir(subsystem string, paths map[string]string) (string, error) {
+	path, ok := paths[subsystem]
+	if !ok {
+		return "", cgroups.NewNotFoundError(subsystem)
+	}
+	mnt, root, err := cgroups.FindCgroupMountpointAndRoot(subsystem)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(mnt, rel), nil
+}
+
+// readCgroupFile returns the content of a cgroup file, or nil if the file
+// does not exist because the controller or the kernel feature is not
+// available.
+func readCgroupFile(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+func readFlatKeyed(path string) (map[string]uint64, error) {
+	data, err := readCgroupFile(path)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	return parseFlatKeyed(data)
+}
+
+// parseFlatKeyed parses the content of a cgroup file made of "key value"
+// lines, such as cpu.stat or memory.events.
+func parseFlatKeyed(data []byte) (map[string]uint64, error) {
+	values := make(map[string]uint64)
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %q", fields[0], fields[1])
+		}
+		values[fields[0]] = v
+	}
+	return values, s.Err()
+}
+
+// setThrottlingData sets the throttling data from the content of cpu.stat.
+// The cgroup v1 file reports times in nanoseconds, the cgroup v2 file in
+// microseconds.
+func setThrottlingData(t *types.ThrottlingData, values map[string]uint64) {
+	if values == nil {
+		return
+	}
+	t.Periods = values["nr_periods"]
+	t.ThrottledPeriods = values["nr_throttled"]
+	if v, ok := values["throttled_usec"]; ok {
+		t.ThrottledTime = v * 1000
+	} else {
+		t.ThrottledTime = values["throttled_time"]
+	}
+	t.Bursts = values["nr_bursts"]
+	if v, ok := values["burst_usec"]; ok {
+		t.BurstTime = v * 1000
+	} else {
+		t.BurstTime = values["burst_time"]
+	}
+}
+
+// readPressureStats reads the pressure stall information from the cpu,
+// memory and io pressure files of dir. It returns nil if PSI is not
+// available.
+func readPressureStats(dir string) (*types.PressureStats, error) {
+	var pressure types.PressureStats
+	found := false
+	for _, r := range []struct {
+		file string
+		psi  **types.PSIStats
+	}{
+		{"cpu.pressure", &pressure.CPU},
+		{"memory.pressure", &pressure.Memory},
+		{"io.pressure", &pressure.IO},
+	} {
+		data, err := readCgroupFile(filepath.Join(dir, r.file))
+		if err != nil {
+			// Reading a pressure file fails with EOPNOTSUPP when PSI is
+			// disabled on the kernel command line.
+			if pe, ok := err.(*os.PathError); ok && pe.Err == unix.EOPNOTSUPP {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if data == nil {
+			continue
+		}
+		if *r.psi, err = parsePSI(data); err != nil {
+			return nil, err
+		}
+		found = true
+	}
+	if !found {
+		return nil, nil
+	}
+	return &pressure, nil
+}
+
+// parsePSI parses the content of a pressure file, for example:
+//
+//     some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//     full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+func parsePSI(data []byte) (*types.PSIStats, error) {
+	var psi types.PSIStats
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		var d types.PSIData
+		for _, f := range fields[1:] {
+			kv := strings.SplitN(f, "=", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("invalid pressure field: %q", f)
+			}
+			var err error
+			switch kv[0] {
+			case "avg10":
+				d.Avg10, err = strconv.ParseFloat(kv[1], 64)
+			case "avg60":
+				d.Avg60, err = strconv.ParseFloat(kv[1], 64)
+			case "avg300":
+				d.Avg300, err = strconv.ParseFloat(kv[1], 64)
+			case "total":
+				d.Total, err = strconv.ParseUint(kv[1], 10, 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid pressure field: %q", f)
+			}
+		}
+		switch fields[0] {
+		case "some":
+			psi.Some = d
+		case "full":
+			psi.Full = &d
+		}
+	}
+	return &psi, s.Err()
+}
+
+// parseIOLatency parses the latency statistics of the io.stat file of
+// cgroup v2, which are only present on devices with an io.latency target:
+//
+//     8:0 rbytes=4096 wbytes=0 rios=1 wios=0 dbytes=0 dios=0 use_delay=0 delay_nsec=0 avg_lat=112 win=100
+//
+// avg_lat is in microseconds and win in milliseconds; both are returned in
+// nanoseconds.
+func parseIOLatency(data []byte) ([]types.BlkioStatEntry, error) {
+	var entries []types.BlkioStatEntry
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		var major, minor uint64
+		if _, err := fmt.Sscanf(fields[0], "%d:%d", &major, &minor); err != nil {
+			return nil, fmt.Errorf("invalid io.stat device: %q", fields[0])
+		}
+		for _, f := range fields[1:] {
+			kv := strings.SplitN(f, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			var unit uint64
+			switch kv[0] {
+			case "avg_lat":
+				unit = 1000
+			case "win":
+				unit = 1000000
+			default:
+				continue
+			}
+			v, err := strconv.ParseUint(kv[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid io.stat field: %q", f)
+			}
+			entries = append(entries, types.BlkioStatEntry{
+				Major: major,
+				Minor: minor,
+				Op:    kv[0],
+				Value: v * unit,
+			})
+		}
+	}
+	return entries, s.Err()
+}
+
+// readHugetlbStats reads the hugetlb usage of the cgroup v2 directory dir,
+// per page size.
+func readHugetlbStats(dir string) (map[string]types.HugetlbStats, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "hugetlb.*.current"))
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+	stats := make(map[string]types.HugetlbStats, len(files))
+	for _, file := range files {
+		pagesize := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), "hugetlb."), ".current")
+		data, err := readCgroupFile(file)
+		if err != nil {
+			return nil, err
+		}
+		usage, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hugetlb usage: %q", data)
+		}
+		events, err := readFlatKeyed(filepath.Join(dir, "hugetlb."+pagesize+".events"))
+		if err != nil {
+			return nil, err
+		}
+		stats[pagesize] = types.HugetlbStats{
+			Usage:   usage,
+			Failcnt: events["max"],
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,54 @@
+package stats // import "github.com/docker/docker/daemon/stats"
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+func TestParsePSI(t *testing.T) {
+	psi, err := parsePSI([]byte("some avg10=1.50 avg60=0.75 avg300=0.10 total=123456\nfull avg10=0.50 avg60=0.25 avg300=0.00 total=4567\n"))
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(psi, &types.PSIStats{
+		Some: types.PSIData{Avg10: 1.5, Avg60: 0.75, Avg300: 0.1, Total: 123456},
+		Full: &types.PSIData{Avg10: 0.5, Avg60: 0.25, Total: 4567},
+	}))
+
+	// cpu.pressure has no "full" line on older kernels
+	psi, err = parsePSI([]byte("some avg10=0.00 avg60=0.00 avg300=0.00 total=0\n"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Nil(psi.Full))
+
+	_, err = parsePSI([]byte("some avg10=abc avg60=0.00 avg300=0.00 total=0\n"))
+	assert.Check(t, is.ErrorContains(err, "invalid pressure field"))
+}
+
+func TestSetThrottlingData(t *testing.T) {
+	v1, err := parseFlatKeyed([]byte("nr_periods 10\nnr_throttled 2\nthrottled_time 3000\nnr_bursts 1\nburst_time 500\n"))
+	assert.NilError(t, err)
+	var data types.ThrottlingData
+	setThrottlingData(&data, v1)
+	assert.Check(t, is.DeepEqual(data, types.ThrottlingData{Periods: 10, ThrottledPeriods: 2, ThrottledTime: 3000, Bursts: 1, BurstTime: 500}))
+
+	v2, err := parseFlatKeyed([]byte("usage_usec 100\nnr_periods 10\nnr_throttled 2\nthrottled_usec 3\nnr_bursts 1\nburst_usec 5\n"))
+	assert.NilError(t, err)
+	data = types.ThrottlingData{}
+	setThrottlingData(&data, v2)
+	assert.Check(t, is.DeepEqual(data, types.ThrottlingData{Periods: 10, ThrottledPeriods: 2, ThrottledTime: 3000, Bursts: 1, BurstTime: 5000}))
+}
+
+func TestParseIOLatency(t *testing.T) {
+	entries, err := parseIOLatency([]byte(
+		"8:0 rbytes=4096 wbytes=0 rios=1 wios=0 dbytes=0 dios=0 use_delay=0 delay_nsec=0 avg_lat=112 win=100\n" +
+			"8:16 rbytes=0 wbytes=0 rios=0 wios=0 dbytes=0 dios=0\n"))
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(entries, []types.BlkioStatEntry{
+		{Major: 8, Minor: 0, Op: "avg_lat", Value: 112000},
+		{Major: 8, Minor: 0, Op: "win", Value: 100000000},
+	}))
+
+	_, err = parseIOLatency([]byte("sda rbytes=0\n"))
+	assert.Check(t, is.ErrorContains(err, "invalid io.stat device"))
+}
@@ -0,0 +1,12 @@
+// +build !linux
+
+package stats // import "github.com/docker/docker/daemon/stats"
+
+import (
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+)
+
+// collectCgroupStats is a no-op on platforms without cgroups.
+func (s *Collector) collectCgroupStats(c *container.Container, stats *types.StatsJSON) {
+}
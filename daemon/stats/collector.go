@@ -131,6 +131,7 @@ func (s *Collector) Run() {
 				// FIXME: move to containerd on Linux (not Windows)
 				stats.CPUStats.SystemUsage = systemUsage
 				stats.CPUStats.OnlineCPUs = onlineCPUs
+				s.collectCgroupStats(pair.container, stats)
 
 				pair.publisher.Publish(*stats)
 
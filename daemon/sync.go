@@ -0,0 +1,212 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/containerfs"
+	digest "github.com/opencontainers/go-digest"
+)
+
+// ContainerSync compares the directory at the specified path in the
+// container identified by the given name with the manifest of a directory to
+// synchronize to it. It returns the files of the manifest which are missing
+// or differ in the container, to be copied with ContainerExtractToDir, and the
+// files of the container which are not in the manifest. If remove is true,
+// these extraneous files are removed from the container.
+func (daemon *Daemon) ContainerSync(name, path string, manifest []containertypes.ContainerSyncEntry, remove bool) (*containertypes.ContainerSyncDiff, error) {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure an online file-system operation is permitted.
+	if err := daemon.isOnlineFSOperationPermitted(container); err != nil {
+		return nil, errdefs.System(err)
+	}
+
+	diff, err := daemon.containerSync(container, path, manifest, remove)
+	if err == nil {
+		return diff, nil
+	}
+
+	if os.IsNotExist(err) {
+		return nil, containerFileNotFound{path, name}
+	}
+	if errdefs.IsInvalidParameter(err) {
+		return nil, err
+	}
+	return nil, errdefs.System(err)
+}
+
+func (daemon *Daemon) containerSync(container *container.Container, path string, manifest []containertypes.ContainerSyncEntry, remove bool) (*containertypes.ContainerSyncDiff, error) {
+	container.Lock()
+	defer container.Unlock()
+
+	if err := daemon.Mount(container); err != nil {
+		return nil, err
+	}
+	defer daemon.Unmount(container)
+
+	err := daemon.mountVolumes(container)
+	defer container.DetachAndUnmount(daemon.logVolumeMountEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	// Normalize path before sending to rootfs
+	path = container.BaseFS.FromSlash(path)
+	driver := container.BaseFS
+
+	// As for an extraction, the last path element is followed if it is a
+	// symbolic link to a directory.
+	absPath := archive.PreserveTrailingDotOrSeparator(
+		driver.Join(string(driver.Separator()), path),
+		path,
+		driver.Separator())
+	resolvedPath, err := container.GetResourcePath(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := driver.Lstat(resolvedPath)
+	if err != nil {
+		return nil, err
+	}
+	if !stat.IsDir() {
+		return nil, errdefs.InvalidParameter(fmt.Errorf("cannot synchronize %s: not a directory", absPath))
+	}
+
+	diff, err := syncDiff(driver, resolvedPath, manifest)
+	if err != nil || !remove || len(diff.Extraneous) == 0 {
+		return diff, err
+	}
+
+	baseRel, err := driver.Rel(driver.Path(), resolvedPath)
+	if err != nil {
+		return nil, err
+	}
+	toVolume, err := checkIfPathIsInAVolume(container, driver.Join(string(driver.Separator()), baseRel))
+	if err != nil {
+		return nil, err
+	}
+	if !toVolume && container.HostConfig.ReadonlyRootfs {
+		return nil, ErrRootFSReadOnly
+	}
+
+	for _, p := range diff.Extraneous {
+		if err := driver.RemoveAll(driver.Join(resolvedPath, driver.FromSlash(p))); err != nil {
+			return nil, err
+		}
+	}
+
+	daemon.LogContainerEvent(container, "sync")
+
+	return diff, nil
+}
+
+// syncDiff compares the directory dir of the filesystem root with the
+// manifest of a directory to synchronize to it. The files of dir are walked
+// without following symbolic links, and the files of an extraneous
+// directory are not listed.
+func syncDiff(root containerfs.ContainerFS, dir string, manifest []containertypes.ContainerSyncEntry) (*containertypes.ContainerSyncDiff, error) {
+	paths := make([]string, 0, len(manifest))
+	entries := make(map[string]*containertypes.ContainerSyncEntry, len(manifest))
+	for i, e := range manifest {
+		p := path.Clean("/" + e.Path)[1:]
+		if p == "" {
+			return nil, errdefs.InvalidParameter(fmt.Errorf("invalid path %q in sync manifest", e.Path))
+		}
+		if _, ok := entries[p]; ok {
+			return nil, errdefs.InvalidParameter(fmt.Errorf("duplicate path %s in sync manifest", p))
+		}
+		if e.Digest != "" {
+			if _, err := digest.Parse(e.Digest); err != nil {
+				return nil, errdefs.InvalidParameter(fmt.Errorf("invalid digest of %s in sync manifest: %v", p, err))
+			}
+		}
+		paths = append(paths, p)
+		entries[p] = &manifest[i]
+	}
+	for _, p := range paths {
+		if d := path.Dir(p); d != "." && (entries[d] == nil || !entries[d].Mode.IsDir()) {
+			return nil, errdefs.InvalidParameter(fmt.Errorf("missing directory %s of %s in sync manifest", d, p))
+		}
+	}
+
+	diff := &containertypes.ContainerSyncDiff{
+		Changed:    []string{},
+		Extraneous: []string{},
+	}
+	existing := make(map[string]os.FileInfo)
+	err := root.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == dir {
+			return nil
+		}
+		rel, err := root.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = root.ToSlash(rel)
+		if _, ok := entries[rel]; !ok {
+			diff.Extraneous = append(diff.Extraneous, rel)
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		existing[rel] = fi
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range paths {
+		if fi, ok := existing[p]; ok {
+			same, err := sameSyncEntry(root, root.Join(dir, root.FromSlash(p)), fi, entries[p])
+			if err != nil {
+				return nil, err
+			}
+			if same {
+				continue
+			}
+		}
+		diff.Changed = append(diff.Changed, p)
+	}
+	return diff, nil
+}
+
+// sameSyncEntry returns whether the file p of root, whose information is fi,
+// is the same as the entry e of a sync manifest. Regular files of the same
+// size are compared by digest, with the algorithm of the entry.
+func sameSyncEntry(root containerfs.ContainerFS, p string, fi os.FileInfo, e *containertypes.ContainerSyncEntry) (bool, error) {
+	const modeMask = os.ModeType | os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky
+	if fi.Mode()&modeMask != e.Mode&modeMask {
+		return false, nil
+	}
+
+	switch {
+	case fi.Mode()&os.ModeSymlink != 0:
+		target, err := root.Readlink(p)
+		return target == e.Linkname, err
+	case fi.Mode().IsRegular():
+		if fi.Size() != e.Size || e.Digest == "" {
+			return false, nil
+		}
+		expected := digest.Digest(e.Digest)
+		dgst, err := fileDigest(root, p, fi, expected.Algorithm())
+		return dgst == expected, err
+	default:
+		return true, nil
+	}
+}
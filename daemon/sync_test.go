@@ -0,0 +1,57 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/internal/testutil"
+	"github.com/docker/docker/pkg/containerfs"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+func TestSyncDiff(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sync-")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "app", "lib"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "app", "same"), []byte("test"), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "app", "modified"), []byte("tset"), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "app", "mode"), []byte("test"), 0600))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "app", "lib", "old"), []byte("old"), 0644))
+	assert.NilError(t, os.Symlink("same", filepath.Join(dir, "app", "link")))
+
+	const testDigest = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	root := containerfs.NewLocalContainerFS(dir)
+	diff, err := syncDiff(root, filepath.Join(dir, "app"), []containertypes.ContainerSyncEntry{
+		{Path: "same", Mode: 0644, Size: 4, Digest: testDigest},
+		{Path: "modified", Mode: 0644, Size: 4, Digest: testDigest},
+		{Path: "mode", Mode: 0644, Size: 4, Digest: testDigest},
+		{Path: "link", Mode: os.ModeSymlink | 0777, Linkname: "other"},
+		{Path: "conf", Mode: os.ModeDir | 0755},
+		{Path: "conf/new", Mode: 0644, Size: 4, Digest: testDigest},
+	})
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(diff.Changed, []string{"modified", "mode", "link", "conf", "conf/new"}))
+	assert.Check(t, is.DeepEqual(diff.Extraneous, []string{"lib"}))
+
+	_, err = syncDiff(root, filepath.Join(dir, "app"), []containertypes.ContainerSyncEntry{
+		{Path: "conf/new", Mode: 0644},
+	})
+	testutil.ErrorContains(t, err, "missing directory conf of conf/new")
+
+	_, err = syncDiff(root, filepath.Join(dir, "app"), []containertypes.ContainerSyncEntry{
+		{Path: "same", Mode: 0644},
+		{Path: "./same", Mode: 0644},
+	})
+	testutil.ErrorContains(t, err, "duplicate path same")
+
+	_, err = syncDiff(root, filepath.Join(dir, "app"), []containertypes.ContainerSyncEntry{
+		{Path: "same", Mode: 0644, Digest: "sha256:invalid"},
+	})
+	testutil.ErrorContains(t, err, "invalid digest of same")
+}
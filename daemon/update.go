@@ -1,12 +1,16 @@
 package daemon // import "github.com/docker/docker/daemon"
 
 import (
-	"context"
 	"fmt"
+	"strings"
 
 	"github.com/docker/docker/api/types/container"
+	containerpkg "github.com/docker/docker/container"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/layer"
+	units "github.com/docker/go-units"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 // ContainerUpdate updates configuration of the container
@@ -41,10 +45,14 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 	}
 
 	restoreConfig := false
+	restoreStorageSize := false
 	backupHostConfig := *container.HostConfig
 	defer func() {
 		if restoreConfig {
 			container.Lock()
+			if restoreStorageSize {
+				daemon.restoreStorageSize(container, backupHostConfig.StorageOpt)
+			}
 			container.HostConfig = &backupHostConfig
 			container.CheckpointTo(daemon.containersReplica)
 			container.Unlock()
@@ -56,11 +64,30 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 	}
 
 	container.Lock()
+	// The size of the writable layer is changed first, as it is the update
+	// most likely to be refused by the storage driver.
+	if len(hostConfig.StorageOpt) > 0 {
+		if err := daemon.updateStorageSize(container, hostConfig.StorageOpt); err != nil {
+			container.Unlock()
+			return errCannotUpdate(container.ID, err)
+		}
+		restoreStorageSize = true
+	}
 	if err := container.UpdateContainer(hostConfig); err != nil {
 		restoreConfig = true
 		container.Unlock()
 		return errCannotUpdate(container.ID, err)
 	}
+	if size, ok := hostConfig.StorageOpt["size"]; ok {
+		// The storage options are shared with backupHostConfig, copy them.
+		storageOpt := map[string]string{"size": size}
+		for k, v := range container.HostConfig.StorageOpt {
+			if strings.ToLower(k) != "size" {
+				storageOpt[k] = v
+			}
+		}
+		container.HostConfig.StorageOpt = storageOpt
+	}
 	if err := container.CheckpointTo(daemon.containersReplica); err != nil {
 		restoreConfig = true
 		container.Unlock()
@@ -78,7 +105,7 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 	// If container is running (including paused), we need to update configs
 	// to the real world.
 	if container.IsRunning() && !container.IsRestarting() {
-		if err := daemon.containerd.UpdateResources(context.Background(), container.ID, toContainerdResources(hostConfig.Resources)); err != nil {
+		if err := daemon.updateResources(container, hostConfig.Resources); err != nil {
 			restoreConfig = true
 			// TODO: it would be nice if containerd responded with better errors here so we can classify this better.
 			return errCannotUpdate(container.ID, errdefs.System(err))
@@ -90,6 +117,54 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 	return nil
 }
 
+// updateStorageSize sets the size quota of the writable layer of the
+// container c to the "size" storage option, the only storage option which
+// can be updated.
+func (daemon *Daemon) updateStorageSize(c *containerpkg.Container, storageOpt map[string]string) error {
+	var size int64
+	for k, v := range storageOpt {
+		if k != "size" {
+			return errdefs.InvalidParameter(errors.Errorf("storage option %s cannot be updated", k))
+		}
+		var err error
+		if size, err = units.RAMInBytes(v); err != nil {
+			return errdefs.InvalidParameter(err)
+		}
+		if size <= 0 {
+			return errdefs.InvalidParameter(errors.Errorf("invalid storage size: %s", v))
+		}
+	}
+	return daemon.setStorageSize(c, uint64(size))
+}
+
+// restoreStorageSize sets the size quota of the writable layer of the
+// container c back to the "size" storage option it had before a failed
+// update.
+func (daemon *Daemon) restoreStorageSize(c *containerpkg.Container, storageOpt map[string]string) {
+	size, err := units.RAMInBytes(storageOpt["size"])
+	if err != nil || size <= 0 {
+		logrus.WithField("container", c.ID).Warn("the previous size of the writable layer is the default of the storage driver and cannot be restored")
+		return
+	}
+	if err := daemon.setStorageSize(c, uint64(size)); err != nil {
+		logrus.WithError(err).WithField("container", c.ID).Error("failed to restore the size of the writable layer")
+	}
+}
+
+func (daemon *Daemon) setStorageSize(c *containerpkg.Container, size uint64) error {
+	err := daemon.imageService.SetLayerQuota(c.ID, c.OS, size)
+	switch {
+	case err == nil:
+		return nil
+	case err == layer.ErrQuotaNotSupported:
+		return errdefs.NotImplemented(errors.Errorf("the %s storage driver does not support changing the size of a container", daemon.imageService.GraphDriverForOS(c.OS)))
+	case errdefs.IsNotImplemented(err):
+		return err
+	default:
+		return errdefs.System(err)
+	}
+}
+
 func errCannotUpdate(containerID string, err error) error {
 	return errors.Wrap(err, "Cannot update container "+containerID)
 }
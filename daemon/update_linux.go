@@ -1,13 +1,27 @@
 package daemon // import "github.com/docker/docker/daemon"
 
 import (
+	"context"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
+	containerpkg "github.com/docker/docker/container"
 	"github.com/docker/docker/libcontainerd"
+	"github.com/docker/docker/pkg/sysinfo"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
+// updateResources applies the updated resources to the running container
+// c. With the cgroup v2 unified hierarchy, all the resources of the
+// container, which c.HostConfig holds once updated, are written to its
+// cgroup.
+func (daemon *Daemon) updateResources(c *containerpkg.Container, resources container.Resources) error {
+	if sysinfo.IsCgroup2UnifiedMode() {
+		return applyCgroup2Resources(c.GetPID(), c.HostConfig.Resources)
+	}
+	return daemon.containerd.UpdateResources(context.Background(), c.ID, toContainerdResources(resources))
+}
+
 func toContainerdResources(resources container.Resources) *libcontainerd.Resources {
 	var r libcontainerd.Resources
 
@@ -1,10 +1,18 @@
 package daemon // import "github.com/docker/docker/daemon"
 
 import (
+	"context"
+
 	"github.com/docker/docker/api/types/container"
+	containerpkg "github.com/docker/docker/container"
 	"github.com/docker/docker/libcontainerd"
 )
 
+// updateResources applies the updated resources to the running container c.
+func (daemon *Daemon) updateResources(c *containerpkg.Container, resources container.Resources) error {
+	return daemon.containerd.UpdateResources(context.Background(), c.ID, toContainerdResources(resources))
+}
+
 func toContainerdResources(resources container.Resources) *libcontainerd.Resources {
 	// We don't support update, so do nothing
 	return nil
@@ -0,0 +1,134 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	mounttypes "github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/volume"
+	"github.com/pkg/errors"
+)
+
+// VolumeExport writes a tar archive of the content of the volume with the
+// given name to out, compressed with gzip if compress is true. Unless force
+// is true, the volume must not be mounted read-write by a running container.
+func (daemon *Daemon) VolumeExport(name string, compress, force bool, out io.Writer) error {
+	v, err := daemon.getArchiveVolume(name)
+	if err != nil {
+		return err
+	}
+	if !force {
+		if err := daemon.checkVolumeNotInUse(name, true); err != nil {
+			return err
+		}
+	}
+
+	data, err := v.Export()
+	if err != nil {
+		return volumeArchiveError(v, err)
+	}
+	defer data.Close()
+
+	// Stream the entire contents of the volume out
+	if !compress {
+		if _, err := io.Copy(out, data); err != nil {
+			return err
+		}
+	} else {
+		// The gzip writer is closed explicitly, as the archive is
+		// truncated if its trailer cannot be written.
+		gw := gzip.NewWriter(out)
+		_, err := io.Copy(gw, data)
+		if cErr := gw.Close(); err == nil {
+			err = cErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	daemon.LogVolumeEvent(v.Name(), "export", map[string]string{"driver": v.DriverName()})
+	return nil
+}
+
+// VolumeImport replaces the content of the volume with the given name with
+// the content of a tar archive, which may be compressed. Unless force is
+// true, the volume must not be mounted by a running container.
+func (daemon *Daemon) VolumeImport(name string, force bool, content io.Reader) error {
+	v, err := daemon.getArchiveVolume(name)
+	if err != nil {
+		return err
+	}
+	if !force {
+		if err := daemon.checkVolumeNotInUse(name, false); err != nil {
+			return err
+		}
+	}
+
+	if err := v.Import(content); err != nil {
+		return volumeArchiveError(v, err)
+	}
+
+	daemon.LogVolumeEvent(v.Name(), "import", map[string]string{"driver": v.DriverName()})
+	return nil
+}
+
+func (daemon *Daemon) getArchiveVolume(name string) (volume.ArchiveVolume, error) {
+	v, err := daemon.getVolume(name)
+	if err != nil {
+		return nil, err
+	}
+	av, ok := v.(volume.ArchiveVolume)
+	if !ok {
+		return nil, volumeArchiveError(v, volume.ErrArchiveNotSupported)
+	}
+	return av, nil
+}
+
+// checkVolumeNotInUse returns a conflict error if the volume with the given
+// name is mounted by running containers, read-write only if rwOnly is true.
+func (daemon *Daemon) checkVolumeNotInUse(name string, rwOnly bool) error {
+	all, err := daemon.containersReplica.Snapshot().All()
+	if err != nil {
+		return errdefs.System(err)
+	}
+
+	var users []string
+	for _, c := range all {
+		if !c.Running {
+			continue
+		}
+		for _, m := range c.Mounts {
+			if m.Type == mounttypes.TypeVolume && m.Name == name && (m.RW || !rwOnly) {
+				users = append(users, stringid.TruncateID(c.ID))
+				break
+			}
+		}
+	}
+	if len(users) == 0 {
+		return nil
+	}
+
+	mode := ""
+	if rwOnly {
+		mode = " read-write"
+	}
+	return errdefs.Conflict(fmt.Errorf("volume %s is mounted%s by running containers: %s, stop them or use force", name, mode, strings.Join(users, ", ")))
+}
+
+// volumeArchiveError returns the error err of the driver of the volume v
+// for an export or import, as an error of the API.
+func volumeArchiveError(v volume.Volume, err error) error {
+	switch {
+	case err == volume.ErrArchiveNotSupported:
+		return errdefs.NotImplemented(errors.Errorf("the %s volume driver does not support export and import of volumes", v.DriverName()))
+	case errdefs.IsNotImplemented(err), errdefs.IsInvalidParameter(err):
+		return err
+	default:
+		return errdefs.System(err)
+	}
+}
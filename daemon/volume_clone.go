@@ -0,0 +1,105 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/volume"
+	volumestore "github.com/docker/docker/volume/store"
+	"github.com/pkg/errors"
+)
+
+// VolumeClone creates a volume with the specified name, opts and labels as a
+// copy of the volume from, or of its given snapshot. The volume is created
+// with the driver of the volume from, which must match driverName if it is
+// not empty. The volume from must not be mounted read-write by a running
+// container, unless the volume is copied from a snapshot.
+func (daemon *Daemon) VolumeClone(name, driverName, from, snapshot string, opts, labels map[string]string) (*types.Volume, error) {
+	if name == "" {
+		name = stringid.GenerateNonCryptoID()
+	}
+
+	src, err := daemon.getVolume(from)
+	if err != nil {
+		return nil, err
+	}
+	if driverName != "" && driverName != src.DriverName() {
+		return nil, errdefs.InvalidParameter(errors.Errorf("cannot create a volume with the %s driver from volume %s of the %s driver", driverName, from, src.DriverName()))
+	}
+	if snapshot == "" {
+		if err := daemon.checkVolumeNotInUse(src.Name(), true); err != nil {
+			return nil, err
+		}
+	}
+
+	v, err := daemon.volumes.Clone(src, snapshot, name, opts, labels)
+	if err != nil {
+		return nil, volumeCloneError(src, err)
+	}
+
+	attributes := map[string]string{"driver": v.DriverName(), "from": src.Name()}
+	if snapshot != "" {
+		attributes["snapshot"] = snapshot
+	}
+	daemon.LogVolumeEvent(v.Name(), "create", attributes)
+	apiV := volumeToAPIType(v)
+	apiV.Mountpoint = v.Path()
+	return apiV, nil
+}
+
+// VolumeSnapshotCreate saves a snapshot of the content of the volume with the
+// given name, from which volumes can later be cloned. Unless forced, the
+// volume must not be mounted read-write by a running container.
+func (daemon *Daemon) VolumeSnapshotCreate(name string, options types.VolumeSnapshotCreateOptions) error {
+	v, err := daemon.getVolume(name)
+	if err != nil {
+		return err
+	}
+	if !options.Force {
+		if err := daemon.checkVolumeNotInUse(v.Name(), true); err != nil {
+			return err
+		}
+	}
+
+	if err := daemon.volumes.Snapshot(v, options.Name); err != nil {
+		return volumeCloneError(v, err)
+	}
+
+	daemon.LogVolumeEvent(v.Name(), "snapshot", map[string]string{"driver": v.DriverName(), "snapshot": options.Name})
+	return nil
+}
+
+// VolumeSnapshotDelete removes a snapshot of the volume with the given name.
+func (daemon *Daemon) VolumeSnapshotDelete(name, snapshot string) error {
+	v, err := daemon.getVolume(name)
+	if err != nil {
+		return err
+	}
+
+	if err := daemon.volumes.RemoveSnapshot(v, snapshot); err != nil {
+		return volumeCloneError(v, err)
+	}
+
+	daemon.LogVolumeEvent(v.Name(), "snapshot-delete", map[string]string{"driver": v.DriverName(), "snapshot": snapshot})
+	return nil
+}
+
+func (daemon *Daemon) getVolume(name string) (volume.Volume, error) {
+	v, err := daemon.volumes.Get(name)
+	if err != nil {
+		if volumestore.IsNotExist(err) {
+			return nil, volumeNotFound(name)
+		}
+		return nil, errdefs.System(err)
+	}
+	return v, nil
+}
+
+// volumeCloneError returns the error err of the volume store for a clone or
+// snapshot of the volume v, as an error of the API.
+func volumeCloneError(v volume.Volume, err error) error {
+	if errors.Cause(err) == volume.ErrCloneNotSupported {
+		return errdefs.NotImplemented(errors.Errorf("the %s volume driver does not support clones and snapshots of volumes", v.DriverName()))
+	}
+	return err
+}
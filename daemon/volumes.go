@@ -15,6 +15,7 @@ import (
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/volume"
 	volumemounts "github.com/docker/docker/volume/mounts"
+	volumestore "github.com/docker/docker/volume/store"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -44,6 +45,40 @@ func volumeToAPIType(v volume.Volume) *types.Volume {
 	return tv
 }
 
+// volumeUsageToAPIType converts the usage history of a volume to the type
+// used by the Engine API.
+func volumeUsageToAPIType(u volumestore.Usage) *types.VolumeUsageHistory {
+	h := &types.VolumeUsageHistory{
+		CreatedBy: u.CreatedBy,
+		Consumers: make([]types.VolumeConsumer, 0, len(u.Consumers)),
+	}
+	if !u.LastMounted.IsZero() {
+		h.LastMounted = u.LastMounted.Format(time.RFC3339)
+	}
+	if !u.LastUnmounted.IsZero() {
+		h.LastUnmounted = u.LastUnmounted.Format(time.RFC3339)
+	}
+	for _, c := range u.Consumers {
+		h.Consumers = append(h.Consumers, types.VolumeConsumer{
+			Container:   c.Container,
+			LastMounted: c.LastMounted.Format(time.RFC3339),
+		})
+	}
+	return h
+}
+
+// logVolumeMountEvent logs a mount or unmount event of a volume by a
+// container, and records it in the usage history of the volume.
+func (daemon *Daemon) logVolumeMountEvent(name, action string, attributes map[string]string) {
+	switch action {
+	case "mount":
+		daemon.volumes.RecordMount(name, attributes["driver"], attributes["container"])
+	case "unmount":
+		daemon.volumes.RecordUnmount(name)
+	}
+	daemon.LogVolumeEvent(name, action, attributes)
+}
+
 // Len returns the number of mounts. Used in sorting.
 func (m mounts) Len() int {
 	return len(m)
@@ -82,6 +117,11 @@ func (daemon *Daemon) registerMountPoints(container *container.Container, hostCo
 		// clean up the container mountpoints once return with error
 		if retErr != nil {
 			for _, m := range mountPoints {
+				if m.Image != nil {
+					if err := daemon.releaseImageMount(container, m); err != nil {
+						logrus.WithError(err).Warnf("failed to release image mount %s", m.Destination)
+					}
+				}
 				if m.Volume == nil {
 					continue
 				}
@@ -96,6 +136,11 @@ func (daemon *Daemon) registerMountPoints(container *container.Container, hostCo
 			if v.Volume != nil {
 				daemon.volumes.Dereference(v.Volume, container.ID)
 			}
+			if v.Image != nil {
+				if err := daemon.releaseImageMount(container, v); err != nil {
+					logrus.WithError(err).Warnf("failed to release image mount %s", destination)
+				}
+			}
 		}
 	}
 
@@ -129,6 +174,16 @@ func (daemon *Daemon) registerMountPoints(container *container.Container, hostCo
 				CopyData:    false,
 			}
 
+			if cp.Type == mounttypes.TypeImage {
+				// the image is mounted from a layer of this container
+				dereferenceIfExists(cp.Destination)
+				if err := daemon.createImageMount(container, cp); err != nil {
+					return err
+				}
+				mountPoints[cp.Destination] = cp
+				continue
+			}
+
 			if len(cp.Source) == 0 {
 				v, err := daemon.volumes.GetWithRef(cp.Name, cp.Driver, container.ID)
 				if err != nil {
@@ -163,7 +218,7 @@ func (daemon *Daemon) registerMountPoints(container *container.Container, hostCo
 
 		if bind.Type == mounttypes.TypeVolume {
 			// create the volume
-			v, err := daemon.volumes.CreateWithRef(bind.Name, bind.Driver, container.ID, nil, nil)
+			v, err := daemon.volumes.CreateWithRef(bind.Name, bind.Driver, container.ID, nil, mountVolumeLabels(bind.Spec))
 			if err != nil {
 				return err
 			}
@@ -205,9 +260,9 @@ func (daemon *Daemon) registerMountPoints(container *container.Container, hostCo
 				if cfg.VolumeOptions.DriverConfig != nil {
 					driverOpts = cfg.VolumeOptions.DriverConfig.Options
 				}
-				v, err = daemon.volumes.CreateWithRef(mp.Name, mp.Driver, container.ID, driverOpts, cfg.VolumeOptions.Labels)
+				v, err = daemon.volumes.CreateWithRef(mp.Name, mp.Driver, container.ID, driverOpts, mountVolumeLabels(cfg))
 			} else {
-				v, err = daemon.volumes.CreateWithRef(mp.Name, mp.Driver, container.ID, nil, nil)
+				v, err = daemon.volumes.CreateWithRef(mp.Name, mp.Driver, container.ID, nil, mountVolumeLabels(cfg))
 			}
 			if err != nil {
 				return err
@@ -230,6 +285,11 @@ func (daemon *Daemon) registerMountPoints(container *container.Container, hostCo
 
 		binds[mp.Destination] = true
 		dereferenceIfExists(mp.Destination)
+		if mp.Type == mounttypes.TypeImage {
+			if err := daemon.createImageMount(container, mp); err != nil {
+				return err
+			}
+		}
 		mountPoints[mp.Destination] = mp
 	}
 
@@ -250,6 +310,24 @@ func (daemon *Daemon) registerMountPoints(container *container.Container, hostCo
 	return nil
 }
 
+// mountVolumeLabels returns the labels of the volume of a volume mount. A
+// volume created for a mount without a source is labeled as anonymous, for
+// the lifecycle policies of the volume reaper.
+func mountVolumeLabels(cfg mounttypes.Mount) map[string]string {
+	var labels map[string]string
+	if cfg.VolumeOptions != nil {
+		labels = cfg.VolumeOptions.Labels
+	}
+	if cfg.Source != "" {
+		return labels
+	}
+	anonymous := map[string]string{volume.AnonymousLabel: ""}
+	for k, v := range labels {
+		anonymous[k] = v
+	}
+	return anonymous
+}
+
 // lazyInitializeVolume initializes a mountpoint's volume if needed.
 // This happens after a daemon restart.
 func (daemon *Daemon) lazyInitializeVolume(containerID string, m *volumemounts.MountPoint) error {
@@ -36,6 +36,9 @@ func (daemon *Daemon) setupMounts(c *container.Container) ([]container.Mount, er
 		if err := daemon.lazyInitializeVolume(c.ID, m); err != nil {
 			return nil, err
 		}
+		if err := daemon.lazyInitializeImage(c, m); err != nil {
+			return nil, err
+		}
 		// If the daemon is being shutdown, we should not let a container start if it is trying to
 		// mount the socket the daemon is listening on. During daemon shutdown, the socket
 		// (/var/run/docker.sock by default) doesn't exist anymore causing the call to m.Setup to
@@ -66,7 +69,7 @@ func (daemon *Daemon) setupMounts(c *container.Container) ([]container.Mount, er
 					"read/write":  strconv.FormatBool(m.RW),
 					"propagation": string(m.Propagation),
 				}
-				daemon.LogVolumeEvent(m.Volume.Name(), "mount", attributes)
+				daemon.logVolumeMountEvent(m.Volume.Name(), "mount", attributes)
 			}
 			mounts = append(mounts, mnt)
 		}
@@ -28,6 +28,9 @@ func (daemon *Daemon) setupMounts(c *container.Container) ([]container.Mount, er
 		if err != nil {
 			return nil, err
 		}
+		if mount.Volume != nil {
+			daemon.volumes.RecordMount(mount.Volume.Name(), mount.Volume.DriverName(), c.ID)
+		}
 
 		mnts = append(mnts, container.Mount{
 			Source:      s,
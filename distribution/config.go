@@ -9,6 +9,7 @@ import (
 
 	"github.com/docker/distribution"
 	"github.com/docker/distribution/manifest/schema2"
+	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/distribution/xfer"
@@ -63,6 +64,18 @@ type ImagePullConfig struct {
 	// OS is the requested operating system of the image being pulled to ensure it can be validated
 	// when the host OS supports multiple image operating systems.
 	OS string
+	// SignatureVerifier checks the manifest digest of the image against
+	// the signature policy before any layer is downloaded. This value is
+	// optional, when excluded images are not verified.
+	SignatureVerifier SignatureVerifier
+}
+
+// SignatureVerifier verifies that an image manifest is signed as required
+// by the signature policy.
+type SignatureVerifier interface {
+	// Verify returns an error if the manifest with digest dgst in the
+	// repository of ref is not acceptable.
+	Verify(ref reference.Named, dgst digest.Digest) error
 }
 
 // ImagePushConfig stores push configuration.
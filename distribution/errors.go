@@ -12,6 +12,7 @@ import (
 	"github.com/docker/distribution/registry/api/v2"
 	"github.com/docker/distribution/registry/client"
 	"github.com/docker/distribution/registry/client/auth"
+	"github.com/docker/docker/distribution/signature"
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/errdefs"
 	"github.com/sirupsen/logrus"
@@ -106,6 +107,8 @@ func TranslatePullError(err error, ref reference.Named) error {
 		}
 	case xfer.DoNotRetry:
 		return TranslatePullError(v.Err, ref)
+	case signature.VerificationError:
+		return v
 	}
 
 	return errdefs.Unknown(err)
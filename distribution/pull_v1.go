@@ -41,6 +41,13 @@ func (p *v1Puller) Pull(ctx context.Context, ref reference.Named, os string) err
 		// Allowing fallback, because HTTPS v1 is before HTTP v2
 		return fallbackError{err: ErrNoSupport{Err: errors.New("Cannot pull by digest with v1 registry")}}
 	}
+	if p.config.SignatureVerifier != nil {
+		// Images from v1 registries have no manifest digest that could
+		// have been signed.
+		if err := p.config.SignatureVerifier.Verify(ref, ""); err != nil {
+			return err
+		}
+	}
 
 	tlsConfig, err := p.config.RegistryService.TLSConfig(p.repoInfo.Index.Name)
 	if err != nil {
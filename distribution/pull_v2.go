@@ -378,6 +378,12 @@ func (p *v2Puller) pullV2Tag(ctx context.Context, ref reference.Named, os string
 	// the other side speaks the v2 protocol.
 	p.confirmedV2 = true
 
+	if p.config.SignatureVerifier != nil {
+		if err := p.verifySignature(ref, manifest); err != nil {
+			return false, err
+		}
+	}
+
 	logrus.Debugf("Pulling ref from V2 registry: %s", reference.FamiliarString(ref))
 	progress.Message(p.config.ProgressOutput, tagOrDigest, "Pulling from "+reference.FamiliarName(p.repo.Named()))
 
@@ -437,6 +443,23 @@ func (p *v2Puller) pullV2Tag(ctx context.Context, ref reference.Named, os string
 	return true, nil
 }
 
+// verifySignature checks the digest of the manifest that was fetched for
+// ref against the signature policy. For manifest lists, the digest of the
+// list itself is verified, as this is the digest that is recorded for the
+// image.
+func (p *v2Puller) verifySignature(ref reference.Named, manifest distribution.Manifest) error {
+	var (
+		dgst digest.Digest
+		err  error
+	)
+	if m, ok := manifest.(*schema1.SignedManifest); ok {
+		dgst = digest.FromBytes(m.Canonical)
+	} else if dgst, err = schema2ManifestDigest(ref, manifest); err != nil {
+		return err
+	}
+	return p.config.SignatureVerifier.Verify(ref, dgst)
+}
+
 func (p *v2Puller) pullSchema1(ctx context.Context, ref reference.Reference, unverifiedManifest *schema1.SignedManifest, requestedOS string) (id digest.Digest, manifestDigest digest.Digest, err error) {
 	var verifiedManifest *schema1.Manifest
 	verifiedManifest, err = verifySchema1Manifest(unverifiedManifest, ref)
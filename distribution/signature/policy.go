@@ -0,0 +1,88 @@
+package signature // import "github.com/docker/docker/distribution/signature"
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/pkg/errors"
+)
+
+// Policy describes which repositories require signed images, and which
+// public keys are trusted to sign them.
+type Policy struct {
+	// Store is the root directory of the signature store. If empty, the
+	// daemon uses the "signatures" directory in its data-root.
+	Store string `json:"store,omitempty"`
+	// Rules is the list of rules of the policy. When more than one rule
+	// applies to a repository, the rule with the longest scope wins.
+	Rules []Rule `json:"rules"`
+}
+
+// Rule requires images in a registry or repository to be signed by at
+// least one of the given keys.
+type Rule struct {
+	// Scope is a registry hostname (e.g. "registry.example.com"), or a
+	// fully qualified repository name or name prefix (e.g.
+	// "docker.io/library" or "docker.io/library/busybox").
+	Scope string `json:"scope"`
+	// Keys is a list of paths to PEM-encoded public keys. An image is
+	// accepted if any of these keys produced one of its signatures.
+	Keys []string `json:"keys"`
+}
+
+// LoadPolicy reads a JSON-encoded policy from path.
+func LoadPolicy(path string) (*Policy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open signature policy")
+	}
+	defer f.Close()
+
+	var p Policy
+	if err := json.NewDecoder(f).Decode(&p); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse signature policy %s", path)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate checks that every rule of the policy has a scope and at least
+// one key, and that no two rules share the same scope.
+func (p *Policy) Validate() error {
+	seen := make(map[string]struct{}, len(p.Rules))
+	for _, r := range p.Rules {
+		scope := strings.TrimSuffix(r.Scope, "/")
+		if scope == "" {
+			return errors.New("signature policy rule has an empty scope")
+		}
+		if len(r.Keys) == 0 {
+			return errors.Errorf("signature policy rule for %q has no keys", r.Scope)
+		}
+		if _, ok := seen[scope]; ok {
+			return errors.Errorf("duplicate signature policy rule for %q", r.Scope)
+		}
+		seen[scope] = struct{}{}
+	}
+	return nil
+}
+
+// match returns the index of the rule that applies to the repository of
+// ref, or -1 if the policy does not cover it.
+func (p *Policy) match(ref reference.Named) int {
+	name := ref.Name()
+	match, matchLen := -1, -1
+	for i, r := range p.Rules {
+		scope := strings.TrimSuffix(r.Scope, "/")
+		if name != scope && !strings.HasPrefix(name, scope+"/") {
+			continue
+		}
+		if len(scope) > matchLen {
+			match, matchLen = i, len(scope)
+		}
+	}
+	return match
+}
@@ -0,0 +1,100 @@
+package signature // import "github.com/docker/docker/distribution/signature"
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// Store provides the detached signatures of image manifests.
+type Store interface {
+	// Get returns all signatures stored for the manifest with digest dgst
+	// in the repository of ref. It returns an empty list, and no error,
+	// if there are no signatures.
+	Get(ref reference.Named, dgst digest.Digest) ([][]byte, error)
+}
+
+// FileStore is a Store keeping signatures on the local filesystem.
+//
+// Signatures of a manifest are stored as individual files in a directory
+// named after the repository and the manifest digest:
+//
+//	<root>/<repository>@<algorithm>=<hex>/signature-<n>
+//
+// For example:
+//
+//	<root>/docker.io/library/busybox@sha256=4b8d.../signature-1
+type FileStore struct {
+	root string
+}
+
+// NewFileStore returns a FileStore rooted at root.
+func NewFileStore(root string) *FileStore {
+	return &FileStore{root: root}
+}
+
+// Path returns the directory holding the signatures of the manifest with
+// digest dgst in the repository of ref.
+func (s *FileStore) Path(ref reference.Named, dgst digest.Digest) string {
+	return filepath.Join(s.root, filepath.FromSlash(ref.Name())+"@"+dgst.Algorithm().String()+"="+dgst.Hex())
+}
+
+// Get implements Store.
+func (s *FileStore) Get(ref reference.Named, dgst digest.Digest) ([][]byte, error) {
+	if err := dgst.Validate(); err != nil {
+		return nil, err
+	}
+	dir := s.Path(ref, dgst)
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to read signature store")
+	}
+
+	var sigs [][]byte
+	for _, fi := range fis {
+		if !fi.Mode().IsRegular() || !strings.HasPrefix(fi.Name(), "signature-") {
+			continue
+		}
+		sig, err := ioutil.ReadFile(filepath.Join(dir, fi.Name()))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read signature")
+		}
+		sigs = append(sigs, sig)
+	}
+	return sigs, nil
+}
+
+// Add stores sig as an additional signature of the manifest with digest
+// dgst in the repository of ref.
+func (s *FileStore) Add(ref reference.Named, dgst digest.Digest, sig []byte) error {
+	if err := dgst.Validate(); err != nil {
+		return err
+	}
+	dir := s.Path(ref, dgst)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	for i := 1; ; i++ {
+		f, err := os.OpenFile(filepath.Join(dir, fmt.Sprintf("signature-%d", i)), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			if os.IsExist(err) {
+				continue
+			}
+			return err
+		}
+		_, err = f.Write(sig)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		return err
+	}
+}
@@ -0,0 +1,148 @@
+package signature // import "github.com/docker/docker/distribution/signature"
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/docker/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// VerificationError is returned when an image is rejected by the signature
+// policy.
+type VerificationError struct {
+	Ref    reference.Named
+	Digest digest.Digest
+	Reason string
+}
+
+func (e VerificationError) Error() string {
+	if e.Digest == "" {
+		return fmt.Sprintf("image %s rejected by signature policy: %s", reference.FamiliarString(e.Ref), e.Reason)
+	}
+	return fmt.Sprintf("image %s@%s rejected by signature policy: %s", reference.FamiliarName(e.Ref), e.Digest, e.Reason)
+}
+
+// Forbidden implements the errdefs.ErrForbidden interface.
+func (VerificationError) Forbidden() {}
+
+// Verifier checks image manifest digests against a Policy, using the
+// signatures of a Store.
+//
+// A signature is a detached signature of the manifest digest string
+// (for example "sha256:4b8d..."), made with SHA-256 and one of the keys
+// of the applicable rule: PKCS #1 v1.5 for RSA keys, and ASN.1 DER-encoded
+// ECDSA for EC keys. Such a signature can be created with:
+//
+//	printf '%s' sha256:4b8d... | openssl dgst -sha256 -sign key.pem -out signature-1
+type Verifier struct {
+	policy *Policy
+	keys   [][]crypto.PublicKey // by rule index
+	store  Store
+}
+
+// NewVerifier loads the public keys referenced by policy and returns a
+// Verifier for it.
+func NewVerifier(policy *Policy, store Store) (*Verifier, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	v := &Verifier{
+		policy: policy,
+		keys:   make([][]crypto.PublicKey, len(policy.Rules)),
+		store:  store,
+	}
+	for i, r := range policy.Rules {
+		for _, path := range r.Keys {
+			key, err := loadPublicKey(path)
+			if err != nil {
+				return nil, err
+			}
+			v.keys[i] = append(v.keys[i], key)
+		}
+	}
+	return v, nil
+}
+
+// Requires returns whether the policy requires images in the repository of
+// ref to be signed.
+func (v *Verifier) Requires(ref reference.Named) bool {
+	return v.policy.match(ref) >= 0
+}
+
+// Verify checks that the manifest with digest dgst in the repository of
+// ref is acceptable under the policy. Images in repositories not covered
+// by the policy are always accepted. An empty dgst denotes an image that
+// has no manifest digest, which is rejected if the policy covers ref.
+func (v *Verifier) Verify(ref reference.Named, dgst digest.Digest) error {
+	rule := v.policy.match(ref)
+	if rule < 0 {
+		return nil
+	}
+	if dgst == "" {
+		return VerificationError{Ref: ref, Reason: "image has no manifest digest"}
+	}
+
+	sigs, err := v.store.Get(ref, dgst)
+	if err != nil {
+		return err
+	}
+	if len(sigs) == 0 {
+		return VerificationError{Ref: ref, Digest: dgst, Reason: "no signature found"}
+	}
+
+	hashed := sha256.Sum256([]byte(dgst.String()))
+	for _, sig := range sigs {
+		for _, key := range v.keys[rule] {
+			if verifySignature(key, hashed[:], sig) {
+				return nil
+			}
+		}
+	}
+	return VerificationError{Ref: ref, Digest: dgst, Reason: "no signature matches a trusted key"}
+}
+
+func verifySignature(key crypto.PublicKey, hashed, sig []byte) bool {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, hashed, sig) == nil
+	case *ecdsa.PublicKey:
+		var esig struct {
+			R, S *big.Int
+		}
+		if rest, err := asn1.Unmarshal(sig, &esig); err != nil || len(rest) != 0 {
+			return false
+		}
+		return ecdsa.Verify(k, hashed, esig.R, esig.S)
+	}
+	return false
+}
+
+func loadPublicKey(path string) (crypto.PublicKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read signature policy key")
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.Errorf("no PEM data found in key %s", path)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse key %s", path)
+	}
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return key, nil
+	}
+	return nil, errors.Errorf("unsupported key type %T in %s", key, path)
+}
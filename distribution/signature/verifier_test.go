@@ -0,0 +1,113 @@
+package signature // import "github.com/docker/docker/distribution/signature"
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/errdefs"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+	"github.com/opencontainers/go-digest"
+)
+
+func writePublicKey(t *testing.T, dir, name string, key *ecdsa.PrivateKey) string {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NilError(t, err)
+	path := filepath.Join(dir, name)
+	err = ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600)
+	assert.NilError(t, err)
+	return path
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, dgst digest.Digest) []byte {
+	hashed := sha256.Sum256([]byte(dgst.String()))
+	sig, err := key.Sign(rand.Reader, hashed[:], nil)
+	assert.NilError(t, err)
+	return sig
+}
+
+func TestVerify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signature-test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	trusted, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	untrusted, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+
+	policy := &Policy{
+		Rules: []Rule{
+			{Scope: "registry.example.com/team", Keys: []string{writePublicKey(t, dir, "trusted.pem", trusted)}},
+		},
+	}
+	store := NewFileStore(filepath.Join(dir, "store"))
+	v, err := NewVerifier(policy, store)
+	assert.NilError(t, err)
+
+	signed, err := reference.ParseNormalizedNamed("registry.example.com/team/app")
+	assert.NilError(t, err)
+	unscoped, err := reference.ParseNormalizedNamed("registry.example.com/teams/app")
+	assert.NilError(t, err)
+
+	good := digest.FromString("good")
+	bad := digest.FromString("bad")
+	unsigned := digest.FromString("unsigned")
+	assert.NilError(t, store.Add(signed, good, sign(t, untrusted, good)))
+	assert.NilError(t, store.Add(signed, good, sign(t, trusted, good)))
+	assert.NilError(t, store.Add(signed, bad, sign(t, trusted, good)))
+
+	assert.Check(t, v.Requires(signed))
+	assert.Check(t, !v.Requires(unscoped))
+
+	assert.Check(t, v.Verify(signed, good))
+	assert.Check(t, v.Verify(unscoped, unsigned))
+
+	for _, dgst := range []digest.Digest{bad, unsigned, ""} {
+		err = v.Verify(signed, dgst)
+		assert.Check(t, is.ErrorContains(err, "rejected by signature policy"))
+		assert.Check(t, errdefs.IsForbidden(err))
+	}
+}
+
+func TestPolicyMatchLongestScope(t *testing.T) {
+	p := &Policy{
+		Rules: []Rule{
+			{Scope: "docker.io", Keys: []string{"a"}},
+			{Scope: "docker.io/library/", Keys: []string{"b"}},
+		},
+	}
+	assert.NilError(t, p.Validate())
+
+	ref, err := reference.ParseNormalizedNamed("busybox")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(p.match(ref), 1))
+
+	ref, err = reference.ParseNormalizedNamed("example/app")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(p.match(ref), 0))
+
+	ref, err = reference.ParseNormalizedNamed("quay.io/example/app")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(p.match(ref), -1))
+}
+
+func TestPolicyValidate(t *testing.T) {
+	p := &Policy{Rules: []Rule{{Scope: "docker.io"}}}
+	assert.Check(t, is.ErrorContains(p.Validate(), "has no keys"))
+
+	p = &Policy{Rules: []Rule{{Keys: []string{"a"}}}}
+	assert.Check(t, is.ErrorContains(p.Validate(), "empty scope"))
+
+	p = &Policy{Rules: []Rule{{Scope: "docker.io", Keys: []string{"a"}}, {Scope: "docker.io/", Keys: []string{"b"}}}}
+	assert.Check(t, is.ErrorContains(p.Validate(), "duplicate"))
+}
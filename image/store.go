@@ -3,6 +3,7 @@ package image // import "github.com/docker/docker/image"
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -24,6 +25,9 @@ type Store interface {
 	GetParent(id ID) (ID, error)
 	SetLastUpdated(id ID) error
 	GetLastUpdated(id ID) (time.Time, error)
+	SetLastUsed(id ID) error
+	TouchLastUsed(id ID) error
+	GetLastUsed(id ID) (time.Time, int64, error)
 	Children(id ID) []ID
 	Map() map[ID]*Image
 	Heads() map[ID]*Image
@@ -293,6 +297,65 @@ func (is *store) GetLastUpdated(id ID) (time.Time, error) {
 	return time.Parse(time.RFC3339Nano, string(bytes))
 }
 
+// SetLastUsed sets the last used time for the image ID to the current time
+// and increments its use count
+func (is *store) SetLastUsed(id ID) error {
+	is.Lock()
+	defer is.Unlock()
+
+	_, count, err := is.getLastUsed(id)
+	if err != nil {
+		// A corrupted record is reset rather than blocking the use of the
+		// image.
+		logrus.Warnf("failed to read the usage of image %s: %v", id, err)
+		count = 0
+	}
+	if err := is.fs.SetMetadata(id.Digest(), "useCount", []byte(strconv.FormatInt(count+1, 10))); err != nil {
+		return err
+	}
+	lastUsed := []byte(time.Now().Format(time.RFC3339Nano))
+	return is.fs.SetMetadata(id.Digest(), "lastUsed", lastUsed)
+}
+
+// TouchLastUsed sets the last used time for the image ID to the current time
+// without incrementing its use count
+func (is *store) TouchLastUsed(id ID) error {
+	is.Lock()
+	defer is.Unlock()
+
+	lastUsed := []byte(time.Now().Format(time.RFC3339Nano))
+	return is.fs.SetMetadata(id.Digest(), "lastUsed", lastUsed)
+}
+
+// GetLastUsed returns the last used time and the use count for the image ID
+func (is *store) GetLastUsed(id ID) (time.Time, int64, error) {
+	is.RLock()
+	defer is.RUnlock()
+
+	return is.getLastUsed(id)
+}
+
+func (is *store) getLastUsed(id ID) (time.Time, int64, error) {
+	bytes, err := is.fs.GetMetadata(id.Digest(), "lastUsed")
+	if err != nil || len(bytes) == 0 {
+		// Never used
+		return time.Time{}, 0, nil
+	}
+	lastUsed, err := time.Parse(time.RFC3339Nano, string(bytes))
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	bytes, err = is.fs.GetMetadata(id.Digest(), "useCount")
+	if err != nil || len(bytes) == 0 {
+		return lastUsed, 0, nil
+	}
+	count, err := strconv.ParseInt(string(bytes), 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	return lastUsed, count, nil
+}
+
 func (is *store) Children(id ID) []ID {
 	is.RLock()
 	defer is.RUnlock()
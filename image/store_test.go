@@ -172,6 +172,36 @@ func TestGetAndSetLastUpdated(t *testing.T) {
 	assert.Check(t, cmp.Equal(updated.IsZero(), false))
 }
 
+func TestGetAndSetLastUsed(t *testing.T) {
+	store, cleanup := defaultImageStore(t)
+	defer cleanup()
+
+	id, err := store.Create([]byte(`{"comment": "abc1", "rootfs": {"type": "layers"}}`))
+	assert.NilError(t, err)
+
+	used, count, err := store.GetLastUsed(id)
+	assert.NilError(t, err)
+	assert.Check(t, cmp.Equal(used.IsZero(), true))
+	assert.Check(t, cmp.Equal(count, int64(0)))
+
+	assert.Check(t, store.SetLastUsed(id))
+	assert.Check(t, store.SetLastUsed(id))
+
+	used, count, err = store.GetLastUsed(id)
+	assert.NilError(t, err)
+	assert.Check(t, cmp.Equal(used.IsZero(), false))
+	assert.Check(t, cmp.Equal(count, int64(2)))
+
+	id, err = store.Create([]byte(`{"comment": "abc2", "rootfs": {"type": "layers"}}`))
+	assert.NilError(t, err)
+	assert.Check(t, store.TouchLastUsed(id))
+
+	used, count, err = store.GetLastUsed(id)
+	assert.NilError(t, err)
+	assert.Check(t, cmp.Equal(used.IsZero(), false))
+	assert.Check(t, cmp.Equal(count, int64(0)))
+}
+
 func TestStoreLen(t *testing.T) {
 	store, cleanup := defaultImageStore(t)
 	defer cleanup()
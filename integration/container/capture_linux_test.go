@@ -0,0 +1,78 @@
+package container // import "github.com/docker/docker/integration/container"
+
+import (
+	"context"
+	"encoding/binary"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/integration/internal/container"
+	"github.com/docker/docker/internal/test/request"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+	"github.com/gotestyourself/gotestyourself/skip"
+)
+
+func TestCapture(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType != "linux")
+	skip.If(t, testEnv.IsRemoteDaemon())
+
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	// the echo requests are sent to an address of TEST-NET-1 (RFC 5737), which
+	// is never reachable, but the packets still leave the container
+	cID := container.Run(t, ctx, client, container.WithCmd("ping", "192.0.2.1"))
+
+	// tcpdump -ddd icmp
+	filter := "6,40 0 0 12,21 0 3 2048,48 0 0 23,21 0 1 1,6 0 0 262144,6 0 0 0"
+	body, err := client.ContainerCapture(ctx, cID, "bridge", types.ContainerCaptureOptions{
+		Filter:   filter,
+		SnapLen:  64,
+		Duration: 3 * time.Second,
+	})
+	assert.NilError(t, err)
+	defer body.Close()
+	b, err := ioutil.ReadAll(body)
+	assert.NilError(t, err)
+
+	assert.Assert(t, len(b) > 24+16, "expected at least one packet, got %d bytes", len(b))
+	assert.Check(t, is.Equal(binary.LittleEndian.Uint32(b[0:]), uint32(0xa1b2c3d4)))
+	assert.Check(t, is.Equal(binary.LittleEndian.Uint32(b[16:]), uint32(64)))
+	assert.Check(t, is.Equal(binary.LittleEndian.Uint32(b[20:]), uint32(1)))
+	record := b[24:]
+	capLen := binary.LittleEndian.Uint32(record[8:])
+	assert.Check(t, capLen <= 64)
+	assert.Assert(t, len(record) >= 16+int(capLen))
+	packet := record[16 : 16+capLen]
+	// an IPv4 ICMP packet
+	assert.Check(t, is.Equal(binary.BigEndian.Uint16(packet[12:]), uint16(0x0800)))
+	assert.Check(t, is.Equal(packet[23], byte(1)))
+}
+
+func TestCaptureInvalid(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType != "linux")
+
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	cID := container.Run(t, ctx, client, container.WithCmd("top"))
+
+	for _, tc := range []struct {
+		network  string
+		options  types.ContainerCaptureOptions
+		expected string
+	}{
+		{network: "bridge", options: types.ContainerCaptureOptions{Filter: "icmp"}, expected: "invalid capture filter"},
+		{network: "bridge", options: types.ContainerCaptureOptions{SnapLen: 1 << 20}, expected: "invalid snap length"},
+		{network: "bridge", options: types.ContainerCaptureOptions{Duration: time.Hour}, expected: "invalid capture duration"},
+		{network: "host", expected: "is not connected to the network host"},
+	} {
+		_, err := client.ContainerCapture(ctx, cID, tc.network, tc.options)
+		assert.Check(t, is.ErrorContains(err, tc.expected))
+	}
+}
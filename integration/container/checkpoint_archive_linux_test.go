@@ -0,0 +1,67 @@
+package container // import "github.com/docker/docker/integration/container"
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/integration/internal/container"
+	"github.com/docker/docker/internal/test/daemon"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+	"github.com/gotestyourself/gotestyourself/poll"
+	"github.com/gotestyourself/gotestyourself/skip"
+)
+
+// TestCheckpointExportUsernsRemap checks that the owners of the filesystem
+// changes of a checkpoint archive exported from a daemon with user namespace
+// remapping are those seen in the container.
+func TestCheckpointExportUsernsRemap(t *testing.T) {
+	skip.If(t, testEnv.IsRemoteDaemon(), "cannot start daemon on remote test run")
+
+	d := daemon.New(t, daemon.WithExperimental)
+	d.StartWithBusybox(t, "--userns-remap=default", "--iptables=false")
+	defer d.Stop(t)
+
+	client, err := d.NewClient()
+	assert.NilError(t, err)
+	ctx := context.Background()
+
+	cID := container.Run(t, ctx, client, container.WithCmd("touch", "/foo"))
+	poll.WaitOn(t, container.IsStopped(ctx, client, cID), poll.WithDelay(100*time.Millisecond))
+
+	// The checkpoint is not restored, its files do not need to come from
+	// CRIU.
+	checkpointDir, err := ioutil.TempDir("", "checkpoint-export")
+	assert.NilError(t, err)
+	defer os.RemoveAll(checkpointDir)
+	assert.NilError(t, os.Mkdir(filepath.Join(checkpointDir, "cp"), 0700))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(checkpointDir, "cp", "dump.log"), []byte("test"), 0600))
+
+	rdr, err := client.CheckpointExport(ctx, cID, types.CheckpointExportOptions{
+		CheckpointID:  "cp",
+		CheckpointDir: checkpointDir,
+	})
+	assert.NilError(t, err)
+	defer rdr.Close()
+
+	tr := tar.NewReader(rdr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			t.Fatal("rootfs/foo not found in the checkpoint archive")
+		}
+		assert.NilError(t, err)
+		if hdr.Name == "rootfs/foo" {
+			assert.Check(t, is.Equal(hdr.Uid, 0))
+			assert.Check(t, is.Equal(hdr.Gid, 0))
+			return
+		}
+	}
+}
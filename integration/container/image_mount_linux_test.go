@@ -0,0 +1,74 @@
+package container // import "github.com/docker/docker/integration/container"
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/integration/internal/container"
+	"github.com/docker/docker/internal/test/request"
+	"github.com/docker/docker/internal/testutil"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+	"github.com/gotestyourself/gotestyourself/skip"
+)
+
+func TestImageMount(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType != "linux")
+
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	cID := container.Run(t, ctx, client, container.WithCmd("top"), container.WithMount(mount.Mount{
+		Type:         mount.TypeImage,
+		Source:       "busybox",
+		Target:       "/image",
+		ImageOptions: &mount.ImageOptions{Subpath: "bin"},
+	}))
+
+	inspect, err := client.ContainerInspect(ctx, cID)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(inspect.Mounts, 1))
+	m := inspect.Mounts[0]
+	assert.Check(t, is.Equal(m.Type, mount.TypeImage))
+	assert.Check(t, is.Equal(m.Destination, "/image"))
+	assert.Check(t, !m.RW)
+	img, _, err := client.ImageInspectWithRaw(ctx, "busybox")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(m.Name, img.ID))
+
+	res, err := container.Exec(ctx, client, cID, []string{"ls", "/image/busybox"})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(res.ExitCode, 0), res.Combined())
+
+	res, err = container.Exec(ctx, client, cID, []string{"touch", "/image/file"})
+	assert.NilError(t, err)
+	assert.Check(t, res.ExitCode != 0)
+	assert.Check(t, is.Contains(res.Stderr(), "Read-only file system"))
+
+	// the image is in use by the container
+	_, err = client.ImageRemove(ctx, img.ID, types.ImageRemoveOptions{})
+	assert.Check(t, is.ErrorContains(err, "being used by running container"))
+
+	err = client.ContainerRemove(ctx, cID, types.ContainerRemoveOptions{Force: true})
+	assert.NilError(t, err)
+}
+
+func TestImageMountInvalid(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType != "linux")
+
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	for expected, m := range map[string]mount.Mount{
+		"must not be outside of the image": {Type: mount.TypeImage, Source: "busybox", Target: "/image", ImageOptions: &mount.ImageOptions{Subpath: "../etc"}},
+		"No such image":                    {Type: mount.TypeImage, Source: "no-such-image:latest", Target: "/image"},
+	} {
+		_, err := client.ContainerCreate(ctx, &containertypes.Config{Image: "busybox"}, &containertypes.HostConfig{Mounts: []mount.Mount{m}}, nil, "")
+		testutil.ErrorContains(t, err, expected)
+	}
+}
@@ -0,0 +1,102 @@
+package container // import "github.com/docker/docker/integration/container"
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/integration/internal/container"
+	"github.com/docker/docker/internal/test/request"
+	"github.com/docker/docker/internal/testutil"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+	"github.com/gotestyourself/gotestyourself/skip"
+)
+
+func TestContainerSnapshotErrors(t *testing.T) {
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	cID := container.Create(t, ctx, client)
+
+	err := client.ContainerSnapshotCreate(ctx, cID, types.ContainerSnapshotCreateOptions{Name: "../snapshot"})
+	testutil.ErrorContains(t, err, "Invalid snapshot name")
+
+	err = client.ContainerSnapshotRollback(ctx, cID, "missing")
+	testutil.ErrorContains(t, err, "snapshot missing does not exist")
+
+	err = client.ContainerSnapshotDelete(ctx, cID, "missing")
+	testutil.ErrorContains(t, err, "snapshot missing does not exist")
+
+	snapshots, err := client.ContainerSnapshotList(ctx, cID)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(snapshots, 0))
+}
+
+func TestContainerSnapshotRollback(t *testing.T) {
+	skip.If(t, testEnv.OSType == "windows")
+
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	cID := container.Create(t, ctx, client)
+	writeContainerFile(ctx, t, client, cID, "foo", "before")
+
+	err := client.ContainerSnapshotCreate(ctx, cID, types.ContainerSnapshotCreateOptions{Name: "before"})
+	if errdefs.IsNotImplemented(err) {
+		t.Skip("the storage driver does not support snapshots of containers")
+	}
+	assert.NilError(t, err)
+
+	writeContainerFile(ctx, t, client, cID, "foo", "after")
+	assert.Check(t, is.Equal(readContainerFile(ctx, t, client, cID, "/foo"), "after"))
+
+	err = client.ContainerSnapshotRollback(ctx, cID, "before")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(readContainerFile(ctx, t, client, cID, "/foo"), "before"))
+
+	// Only the zfs storage driver cannot roll back to a snapshot older than
+	// the most recent one.
+	writeContainerFile(ctx, t, client, cID, "foo", "after")
+	err = client.ContainerSnapshotCreate(ctx, cID, types.ContainerSnapshotCreateOptions{Name: "after"})
+	assert.NilError(t, err)
+	err = client.ContainerSnapshotRollback(ctx, cID, "before")
+	if testEnv.DaemonInfo.Driver == "zfs" {
+		testutil.ErrorContains(t, err, "can only roll back to the most recent snapshot")
+		return
+	}
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(readContainerFile(ctx, t, client, cID, "/foo"), "before"))
+}
+
+// writeContainerFile writes the file name with the given content to the root
+// directory of the container cID.
+func writeContainerFile(ctx context.Context, t *testing.T, apiclient client.APIClient, cID, name, content string) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	assert.NilError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte(content))
+	assert.NilError(t, err)
+	assert.NilError(t, tw.Close())
+	assert.NilError(t, apiclient.CopyToContainer(ctx, cID, "/", &buf, types.CopyToContainerOptions{}))
+}
+
+// readContainerFile returns the content of the file p of the container cID.
+func readContainerFile(ctx context.Context, t *testing.T, apiclient client.APIClient, cID, p string) string {
+	rdr, _, err := apiclient.CopyFromContainer(ctx, cID, p)
+	assert.NilError(t, err)
+	defer rdr.Close()
+	tr := tar.NewReader(rdr)
+	_, err = tr.Next()
+	assert.NilError(t, err)
+	content, err := ioutil.ReadAll(tr)
+	assert.NilError(t, err)
+	return string(content)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/integration/internal/container"
 	"github.com/docker/docker/internal/test/request"
 	"github.com/docker/docker/internal/testutil"
@@ -63,3 +64,41 @@ func TestUpdateRestartWithAutoRemove(t *testing.T) {
 	})
 	testutil.ErrorContains(t, err, "Restart policy cannot be updated because AutoRemove is enabled for the container")
 }
+
+func TestUpdateStorageOpt(t *testing.T) {
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	cID := container.Create(t, ctx, client)
+
+	_, err := client.ContainerUpdate(ctx, cID, containertypes.UpdateConfig{
+		StorageOpt: map[string]string{"foo": "bar"},
+	})
+	testutil.ErrorContains(t, err, "storage option foo cannot be updated")
+
+	_, err = client.ContainerUpdate(ctx, cID, containertypes.UpdateConfig{
+		StorageOpt: map[string]string{"size": "0"},
+	})
+	testutil.ErrorContains(t, err, "invalid storage size: 0")
+}
+
+func TestUpdateStorageSize(t *testing.T) {
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	cID := container.Create(t, ctx, client)
+
+	_, err := client.ContainerUpdate(ctx, cID, containertypes.UpdateConfig{
+		StorageOpt: map[string]string{"size": "1G"},
+	})
+	if errdefs.IsNotImplemented(err) {
+		t.Skip("the storage driver does not support changing the size of a container")
+	}
+	assert.NilError(t, err)
+
+	inspect, err := client.ContainerInspect(ctx, cID)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("1G", inspect.HostConfig.StorageOpt["size"]))
+}
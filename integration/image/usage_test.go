@@ -0,0 +1,44 @@
+package image // import "github.com/docker/docker/integration/image"
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/integration/internal/container"
+	"github.com/docker/docker/internal/test/request"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+func TestImageUseCount(t *testing.T) {
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	cID := container.Create(t, ctx, client)
+	commitResp, err := client.ContainerCommit(ctx, cID, types.ContainerCommitOptions{
+		Changes:   []string{"LABEL test-image-use-count=1"},
+		Reference: "test-image-use-count",
+	})
+	assert.NilError(t, err)
+
+	img, _, err := client.ImageInspectWithRaw(ctx, commitResp.ID)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(img.Metadata.UseCount, int64(0)))
+	assert.Check(t, img.Metadata.LastUsedTime.IsZero())
+
+	// creating a container updates the last used time, but the use is only
+	// counted when the container is started
+	container.Create(t, ctx, client, container.WithImage("test-image-use-count"))
+	img, _, err = client.ImageInspectWithRaw(ctx, commitResp.ID)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(img.Metadata.UseCount, int64(0)))
+	assert.Check(t, !img.Metadata.LastUsedTime.IsZero())
+
+	container.Run(t, ctx, client, container.WithImage("test-image-use-count"))
+	img, _, err = client.ImageInspectWithRaw(ctx, commitResp.ID)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(img.Metadata.UseCount, int64(1)))
+	assert.Check(t, !img.Metadata.LastUsedTime.IsZero())
+}
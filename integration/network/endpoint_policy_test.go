@@ -0,0 +1,62 @@
+package network // import "github.com/docker/docker/integration/network"
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	networktypes "github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/integration/internal/container"
+	"github.com/docker/docker/internal/test/request"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+	"github.com/gotestyourself/gotestyourself/skip"
+)
+
+func TestEndpointPolicy(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType != "linux")
+	skip.If(t, testEnv.IsRemoteDaemon())
+
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	netName := "endpoint-policy"
+	_, err := client.NetworkCreate(ctx, netName, types.NetworkCreate{Driver: "bridge"})
+	assert.NilError(t, err)
+	defer client.NetworkRemove(ctx, netName)
+
+	settings := &networktypes.EndpointSettings{
+		Bandwidth:    &networktypes.EndpointBandwidth{IngressRate: 1024 * 1024, EgressRate: 512 * 1024},
+		EgressPolicy: &networktypes.EndpointEgressPolicy{Deny: []string{"192.0.2.0/24"}},
+	}
+	cID := container.Run(t, ctx, client, container.WithCmd("top"), container.WithNetworkMode(netName), container.WithEndpointSettings(netName, settings))
+	defer client.ContainerRemove(ctx, cID, types.ContainerRemoveOptions{Force: true})
+
+	inspect, err := client.ContainerInspect(ctx, cID)
+	assert.NilError(t, err)
+	ep := inspect.NetworkSettings.Networks[netName]
+	assert.Assert(t, ep != nil)
+	assert.Check(t, is.DeepEqual(ep.Bandwidth, settings.Bandwidth))
+	assert.Check(t, is.DeepEqual(ep.EgressPolicy, settings.EgressPolicy))
+
+	// the bandwidth limits are removed, and the egress policy replaced
+	policy := &networktypes.EndpointEgressPolicy{Allow: []string{"172.16.0.0/12"}}
+	err = client.NetworkEndpointUpdate(ctx, netName, cID, &networktypes.EndpointSettings{EgressPolicy: policy})
+	assert.NilError(t, err)
+
+	inspect, err = client.ContainerInspect(ctx, cID)
+	assert.NilError(t, err)
+	ep = inspect.NetworkSettings.Networks[netName]
+	assert.Assert(t, ep != nil)
+	assert.Check(t, is.Nil(ep.Bandwidth))
+	assert.Check(t, is.DeepEqual(ep.EgressPolicy, policy))
+
+	err = client.NetworkEndpointUpdate(ctx, netName, cID, &networktypes.EndpointSettings{
+		EgressPolicy: &networktypes.EndpointEgressPolicy{Deny: []string{"192.0.2.1"}},
+	})
+	assert.Check(t, is.ErrorContains(err, "invalid egress policy"))
+
+	err = client.NetworkEndpointUpdate(ctx, "host", cID, &networktypes.EndpointSettings{})
+	assert.Check(t, is.ErrorContains(err, "is not connected to the network"))
+}
@@ -1,14 +1,17 @@
 package volume
 
 import (
+	"archive/tar"
 	"context"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
+	mounttypes "github.com/docker/docker/api/types/mount"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/integration/internal/container"
 	"github.com/docker/docker/internal/test/request"
@@ -16,6 +19,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/gotestyourself/gotestyourself/assert"
 	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+	"github.com/gotestyourself/gotestyourself/poll"
 	"github.com/gotestyourself/gotestyourself/skip"
 )
 
@@ -33,11 +37,12 @@ func TestVolumesCreateAndList(t *testing.T) {
 
 	expected := types.Volume{
 		// Ignore timestamp of CreatedAt
-		CreatedAt:  vol.CreatedAt,
-		Driver:     "local",
-		Scope:      "local",
-		Name:       name,
-		Mountpoint: fmt.Sprintf("%s/volumes/%s/_data", testEnv.DaemonInfo.DockerRootDir, name),
+		CreatedAt:    vol.CreatedAt,
+		Driver:       "local",
+		Scope:        "local",
+		Name:         name,
+		Mountpoint:   fmt.Sprintf("%s/volumes/%s/_data", testEnv.DaemonInfo.DockerRootDir, name),
+		UsageHistory: &types.VolumeUsageHistory{},
 	}
 	assert.Check(t, is.DeepEqual(vol, expected, cmpopts.EquateEmpty()))
 
@@ -93,11 +98,12 @@ func TestVolumesInspect(t *testing.T) {
 
 	expected := types.Volume{
 		// Ignore timestamp of CreatedAt
-		CreatedAt:  vol.CreatedAt,
-		Driver:     "local",
-		Scope:      "local",
-		Name:       name,
-		Mountpoint: fmt.Sprintf("%s/volumes/%s/_data", testEnv.DaemonInfo.DockerRootDir, name),
+		CreatedAt:    vol.CreatedAt,
+		Driver:       "local",
+		Scope:        "local",
+		Name:         name,
+		Mountpoint:   fmt.Sprintf("%s/volumes/%s/_data", testEnv.DaemonInfo.DockerRootDir, name),
+		UsageHistory: &types.VolumeUsageHistory{},
 	}
 	assert.Check(t, is.DeepEqual(vol, expected, cmpopts.EquateEmpty()))
 
@@ -114,3 +120,160 @@ func getPrefixAndSlashFromDaemonPlatform() (prefix, slash string) {
 	}
 	return "", "/"
 }
+
+func TestVolumesExportImport(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType == "windows", "FIXME")
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	src := t.Name() + "Source"
+	dst := t.Name() + "Destination"
+	cID := container.Run(t, ctx, client, container.WithBind(src, "/data"), container.WithCmd("sh", "-c", "echo hello > /data/file"))
+	poll.WaitOn(t, container.IsInState(ctx, client, cID, "exited"), poll.WithDelay(100*time.Millisecond))
+
+	_, err := client.VolumeCreate(ctx, volumetypes.VolumesCreateBody{Name: dst})
+	assert.NilError(t, err)
+
+	rc, err := client.VolumeExport(ctx, src, types.VolumeExportOptions{Compress: true})
+	assert.NilError(t, err)
+	err = client.VolumeImport(ctx, dst, rc, types.VolumeImportOptions{})
+	rc.Close()
+	assert.NilError(t, err)
+
+	rc, err = client.VolumeExport(ctx, dst, types.VolumeExportOptions{})
+	assert.NilError(t, err)
+	defer rc.Close()
+	var names []string
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+		names = append(names, hdr.Name)
+	}
+	assert.Check(t, is.Contains(names, "file"))
+
+	container.Run(t, ctx, client, container.WithBind(src, "/data"), container.WithCmd("top"))
+	_, err = client.VolumeExport(ctx, src, types.VolumeExportOptions{})
+	testutil.ErrorContains(t, err, "mounted read-write by running containers")
+}
+
+func TestVolumesCloneAndSnapshot(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType == "windows", "FIXME")
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	src := t.Name() + "Source"
+	cID := container.Run(t, ctx, client, container.WithBind(src, "/data"), container.WithCmd("sh", "-c", "echo hello > /data/file"))
+	poll.WaitOn(t, container.IsInState(ctx, client, cID, "exited"), poll.WithDelay(100*time.Millisecond))
+
+	err := client.VolumeSnapshotCreate(ctx, src, types.VolumeSnapshotCreateOptions{Name: "seed"})
+	assert.NilError(t, err)
+	err = client.VolumeSnapshotCreate(ctx, src, types.VolumeSnapshotCreateOptions{Name: "seed"})
+	testutil.ErrorContains(t, err, "already exists")
+
+	v, err := client.VolumeInspect(ctx, src)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(v.Status["Snapshots"], []interface{}{"seed"}))
+
+	cID = container.Run(t, ctx, client, container.WithBind(src, "/data"), container.WithCmd("rm", "/data/file"))
+	poll.WaitOn(t, container.IsInState(ctx, client, cID, "exited"), poll.WithDelay(100*time.Millisecond))
+
+	for _, tc := range []struct {
+		name     string
+		snapshot string
+		exitCode int
+	}{
+		{name: t.Name() + "Clone", exitCode: 1},
+		{name: t.Name() + "Snapshot", snapshot: "seed", exitCode: 0},
+	} {
+		v, err := client.VolumeCreate(ctx, volumetypes.VolumesCreateBody{Name: tc.name, From: src, FromSnapshot: tc.snapshot})
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(v.Driver, "local"))
+
+		cID := container.Run(t, ctx, client, container.WithBind(tc.name, "/data"), container.WithCmd("test", "-f", "/data/file"))
+		poll.WaitOn(t, container.IsInState(ctx, client, cID, "exited"), poll.WithDelay(100*time.Millisecond))
+		inspect, err := client.ContainerInspect(ctx, cID)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(inspect.State.ExitCode, tc.exitCode), tc.name)
+	}
+
+	err = client.VolumeSnapshotDelete(ctx, src, "seed")
+	assert.NilError(t, err)
+	_, err = client.VolumeCreate(ctx, volumetypes.VolumesCreateBody{From: src, FromSnapshot: "seed"})
+	testutil.ErrorContains(t, err, "snapshot seed does not exist")
+
+	container.Run(t, ctx, client, container.WithBind(src, "/data"), container.WithCmd("top"))
+	_, err = client.VolumeCreate(ctx, volumetypes.VolumesCreateBody{From: src})
+	testutil.ErrorContains(t, err, "mounted read-write by running containers")
+}
+
+func TestVolumesOwnership(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType == "windows", "FIXME")
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	uid, gid := 1000, 1001
+	vol := t.Name() + "Mount"
+	cID := container.Run(t, ctx, client,
+		container.WithMount(mounttypes.Mount{
+			Type:          mounttypes.TypeVolume,
+			Source:        vol,
+			Target:        "/etc",
+			VolumeOptions: &mounttypes.VolumeOptions{UID: &uid, GID: &gid, Mode: 0750},
+		}),
+		container.WithCmd("sh", "-c", `[ "$(stat -c %u:%g:%a /etc /etc/passwd)" = "$(printf '1000:1001:750\n1000:1001:644')" ]`))
+	poll.WaitOn(t, container.IsInState(ctx, client, cID, "exited"), poll.WithDelay(100*time.Millisecond))
+	inspect, err := client.ContainerInspect(ctx, cID)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(inspect.State.ExitCode, 0))
+
+	// the ownership is only set when the volume is used for the first time
+	vol = t.Name() + "Bind"
+	cID = container.Run(t, ctx, client, container.WithBind(vol, "/data:uid=1000"), container.WithCmd("sh", "-c", "touch /data/file && chown 2000 /data"))
+	poll.WaitOn(t, container.IsInState(ctx, client, cID, "exited"), poll.WithDelay(100*time.Millisecond))
+	cID = container.Run(t, ctx, client, container.WithBind(vol, "/data:uid=1000"), container.WithCmd("sh", "-c", `[ "$(stat -c %u /data)" = 2000 ]`))
+	poll.WaitOn(t, container.IsInState(ctx, client, cID, "exited"), poll.WithDelay(100*time.Millisecond))
+	inspect, err = client.ContainerInspect(ctx, cID)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(inspect.State.ExitCode, 0))
+}
+
+func TestVolumesUsageHistory(t *testing.T) {
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	vol := t.Name()
+	prefix, slash := getPrefixAndSlashFromDaemonPlatform()
+	cID := container.Run(t, ctx, client, container.WithBind(vol, prefix+slash+"data"), container.WithCmd("true"))
+	poll.WaitOn(t, container.IsInState(ctx, client, cID, "exited"), poll.WithDelay(100*time.Millisecond))
+
+	v, err := client.VolumeInspect(ctx, vol)
+	assert.NilError(t, err)
+	assert.Assert(t, v.UsageHistory != nil)
+	assert.Check(t, is.Equal(v.UsageHistory.CreatedBy, cID))
+	assert.Check(t, v.UsageHistory.LastMounted != "")
+	assert.Assert(t, is.Len(v.UsageHistory.Consumers, 1))
+	assert.Check(t, is.Equal(v.UsageHistory.Consumers[0].Container, cID))
+
+	err = client.ContainerRemove(ctx, cID, types.ContainerRemoveOptions{})
+	assert.NilError(t, err)
+
+	// the volume was used recently, it is kept
+	report, err := client.VolumesPrune(ctx, filters.NewArgs(filters.Arg("unused-for", "1h")))
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(report.VolumesDeleted, 0))
+
+	report, err = client.VolumesPrune(ctx, filters.NewArgs(filters.Arg("unused-for", "0s")))
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(report.VolumesDeleted, []string{vol}))
+
+	_, err = client.VolumesPrune(ctx, filters.NewArgs(filters.Arg("unused-for", "1 day")))
+	testutil.ErrorContains(t, err, "invalid unused-for filter")
+}
@@ -55,6 +55,15 @@ var (
 	// ErrNotSupported is used when the action is not supported
 	// on the current host operating system.
 	ErrNotSupported = errors.New("not support on this host operating system")
+
+	// ErrQuotaNotSupported is used when the size quota of a read-write
+	// layer is attempted to be changed with a storage driver which cannot
+	// change it.
+	ErrQuotaNotSupported = errors.New("changing the size of a read-write layer is not supported by the storage driver")
+
+	// ErrSnapshotNotSupported is used when a snapshot of a read-write layer
+	// is attempted with a storage driver which cannot take snapshots.
+	ErrSnapshotNotSupported = errors.New("snapshots of read-write layers are not supported by the storage driver")
 )
 
 // ChainID is the content-addressable ID of a layer.
@@ -201,6 +210,31 @@ type DescribableStore interface {
 	RegisterWithDescriptor(io.Reader, ChainID, distribution.Descriptor) (Layer, error)
 }
 
+// QuotaStore represents a layer store capable of changing the size quota
+// of a read-write layer after it was created.
+type QuotaStore interface {
+	// SetRWLayerQuota sets the size quota of the read-write layer with the
+	// given name to size bytes. It returns ErrQuotaNotSupported if the
+	// driver cannot change it.
+	SetRWLayerQuota(name string, size uint64) error
+}
+
+// SnapshotStore represents a layer store capable of saving the content of
+// read-write layers to named snapshots, and rolling them back to these.
+// The methods return ErrSnapshotNotSupported if the driver cannot take
+// snapshots, and are only meant to be used on unmounted layers.
+type SnapshotStore interface {
+	// SnapshotRWLayer saves the content of the read-write layer with the
+	// given name to a new snapshot.
+	SnapshotRWLayer(name, snapshot string) error
+	// RollbackRWLayer restores the content of the read-write layer with
+	// the given name from one of its snapshots.
+	RollbackRWLayer(name, snapshot string) error
+	// RemoveRWLayerSnapshot removes a snapshot of the read-write layer
+	// with the given name.
+	RemoveRWLayerSnapshot(name, snapshot string) error
+}
+
 // CreateChainID returns ID for a layerDigest slice
 func CreateChainID(dgsts []DiffID) ChainID {
 	return createChainIDFromParent("", dgsts...)
@@ -565,6 +565,69 @@ func (ls *layerStore) GetMountID(id string) (string, error) {
 	return mount.mountID, nil
 }
 
+// protoDriver returns the storage driver, or the driver wrapped by it if it
+// is a NaiveDiffDriver, for the optional interfaces of the wrapped driver.
+func (ls *layerStore) protoDriver() graphdriver.ProtoDriver {
+	if nd, ok := ls.driver.(*graphdriver.NaiveDiffDriver); ok {
+		return nd.ProtoDriver
+	}
+	return ls.driver
+}
+
+// SetRWLayerQuota implements QuotaStore.
+func (ls *layerStore) SetRWLayerQuota(name string, size uint64) error {
+	qd, ok := ls.protoDriver().(graphdriver.QuotaDriver)
+	if !ok {
+		return ErrQuotaNotSupported
+	}
+	mountID, err := ls.GetMountID(name)
+	if err != nil {
+		return err
+	}
+	return qd.SetQuota(mountID, size)
+}
+
+// snapshotDriver returns the driver and the mount ID of the read-write layer
+// with the given name for SnapshotStore.
+func (ls *layerStore) snapshotDriver(name string) (graphdriver.SnapshotDriver, string, error) {
+	sd, ok := ls.protoDriver().(graphdriver.SnapshotDriver)
+	if !ok {
+		return nil, "", ErrSnapshotNotSupported
+	}
+	mountID, err := ls.GetMountID(name)
+	if err != nil {
+		return nil, "", err
+	}
+	return sd, mountID, nil
+}
+
+// SnapshotRWLayer implements SnapshotStore.
+func (ls *layerStore) SnapshotRWLayer(name, snapshot string) error {
+	sd, mountID, err := ls.snapshotDriver(name)
+	if err != nil {
+		return err
+	}
+	return sd.Snapshot(mountID, snapshot)
+}
+
+// RollbackRWLayer implements SnapshotStore.
+func (ls *layerStore) RollbackRWLayer(name, snapshot string) error {
+	sd, mountID, err := ls.snapshotDriver(name)
+	if err != nil {
+		return err
+	}
+	return sd.Rollback(mountID, snapshot)
+}
+
+// RemoveRWLayerSnapshot implements SnapshotStore.
+func (ls *layerStore) RemoveRWLayerSnapshot(name, snapshot string) error {
+	sd, mountID, err := ls.snapshotDriver(name)
+	if err != nil {
+		return err
+	}
+	return sd.RemoveSnapshot(mountID, snapshot)
+}
+
 func (ls *layerStore) ReleaseRWLayer(l RWLayer) ([]Metadata, error) {
 	ls.mountL.Lock()
 	defer ls.mountL.Unlock()
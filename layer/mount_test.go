@@ -2,11 +2,13 @@ package layer // import "github.com/docker/docker/layer"
 
 import (
 	"io/ioutil"
+	"os"
 	"runtime"
 	"sort"
 	"testing"
 
 	"github.com/containerd/continuity/driver"
+	"github.com/docker/docker/daemon/graphdriver"
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/containerfs"
 )
@@ -206,6 +208,133 @@ func TestMountChanges(t *testing.T) {
 	})
 }
 
+type quotaTestDriver struct {
+	graphdriver.Driver
+	quotas map[string]uint64
+}
+
+func (d *quotaTestDriver) SetQuota(id string, size uint64) error {
+	d.quotas[id] = size
+	return nil
+}
+
+func TestSetRWLayerQuota(t *testing.T) {
+	td, err := ioutil.TempDir("", "layerstore-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+
+	graph, graphcleanup := newTestGraphDriver(t)
+	defer graphcleanup()
+
+	// Hide the SetQuota method of the vfs driver.
+	ls, err := newStoreFromGraphDriver(td, &struct{ graphdriver.Driver }{graph}, runtime.GOOS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ls.CreateRWLayer("quota-mount", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := ls.(QuotaStore).SetRWLayerQuota("quota-mount", 1<<30); err != ErrQuotaNotSupported {
+		t.Fatalf("Unexpected error %v, expected %v", err, ErrQuotaNotSupported)
+	}
+
+	// The driver is wrapped as the drivers without their own diff methods.
+	driver := &quotaTestDriver{Driver: graph, quotas: make(map[string]uint64)}
+	ls, err = newStoreFromGraphDriver(td, graphdriver.NewNaiveDiffDriver(driver, nil, nil), runtime.GOOS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ls.(QuotaStore).SetRWLayerQuota("quota-mount", 1<<30); err != nil {
+		t.Fatal(err)
+	}
+	mountID, err := ls.GetMountID("quota-mount")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := driver.quotas[mountID]; size != 1<<30 {
+		t.Fatalf("Unexpected quota %d, expected %d", size, 1<<30)
+	}
+
+	if err := ls.(QuotaStore).SetRWLayerQuota("missing-mount", 1<<30); err != ErrMountDoesNotExist {
+		t.Fatalf("Unexpected error %v, expected %v", err, ErrMountDoesNotExist)
+	}
+}
+
+func TestRWLayerSnapshots(t *testing.T) {
+	ls, _, cleanup := newTestStore(t)
+	defer cleanup()
+
+	m, err := ls.CreateRWLayer("snapshot-mount", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pathFS, err := m.Mount("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := driver.WriteFile(pathFS, pathFS.Join(pathFS.Path(), "testfile1.txt"), []byte("snapshot data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Unmount(); err != nil {
+		t.Fatal(err)
+	}
+
+	ss := ls.(SnapshotStore)
+	if err := ss.SnapshotRWLayer("snapshot-mount", "snapshot"); err != nil {
+		t.Fatal(err)
+	}
+
+	pathFS, err = m.Mount("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := driver.WriteFile(pathFS, pathFS.Join(pathFS.Path(), "testfile1.txt"), []byte("mount data!"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := driver.WriteFile(pathFS, pathFS.Join(pathFS.Path(), "testfile2.txt"), []byte("mount data!"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Unmount(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ss.RollbackRWLayer("snapshot-mount", "snapshot"); err != nil {
+		t.Fatal(err)
+	}
+
+	pathFS, err = m.Mount("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := driver.ReadFile(pathFS, pathFS.Join(pathFS.Path(), "testfile1.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "snapshot data"; string(b) != expected {
+		t.Fatalf("Unexpected test file contents %q, expected %q", string(b), expected)
+	}
+	if _, err := pathFS.Stat(pathFS.Join(pathFS.Path(), "testfile2.txt")); !os.IsNotExist(err) {
+		t.Fatalf("Unexpected error %v, expected test file to be removed", err)
+	}
+	if err := m.Unmount(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ss.RemoveRWLayerSnapshot("snapshot-mount", "snapshot"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.RollbackRWLayer("snapshot-mount", "snapshot"); err == nil {
+		t.Fatal("Expected rollback to a removed snapshot to fail")
+	}
+
+	if err := ss.SnapshotRWLayer("missing-mount", "snapshot"); err != ErrMountDoesNotExist {
+		t.Fatalf("Unexpected error %v, expected %v", err, ErrMountDoesNotExist)
+	}
+}
+
 func assertChange(t *testing.T, actual, expected archive.Change) {
 	if actual.Path != expected.Path {
 		t.Fatalf("Unexpected change path %s, expected %s", actual.Path, expected.Path)
@@ -0,0 +1,152 @@
+package sysinfo // import "github.com/docker/docker/pkg/sysinfo"
+
+import (
+	"io/ioutil"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// Cgroup2Root is the mount point of the cgroup v2 unified hierarchy.
+	Cgroup2Root = "/sys/fs/cgroup"
+
+	cgroup2SuperMagic = 0x63677270
+)
+
+var (
+	isUnifiedOnce sync.Once
+	isUnified     bool
+)
+
+// IsCgroup2UnifiedMode returns whether the host uses the cgroup v2 unified
+// hierarchy, in which all the controllers are mounted at /sys/fs/cgroup.
+func IsCgroup2UnifiedMode() bool {
+	isUnifiedOnce.Do(func() {
+		var st unix.Statfs_t
+		if err := unix.Statfs(Cgroup2Root, &st); err == nil {
+			isUnified = st.Type == cgroup2SuperMagic
+		}
+	})
+	return isUnified
+}
+
+// checkCgroup2 fills sysInfo from the controllers enabled in the cgroup v2
+// unified hierarchy.
+func checkCgroup2(sysInfo *SysInfo, quiet bool) {
+	data, err := ioutil.ReadFile(path.Join(Cgroup2Root, "cgroup.controllers"))
+	if err != nil {
+		logrus.Warnf("Failed to read cgroup v2 controllers: %v", err)
+		return
+	}
+	controllers := make(map[string]bool)
+	for _, c := range strings.Fields(string(data)) {
+		controllers[c] = true
+	}
+
+	sysInfo.CgroupUnified = true
+	// Devices are controlled with eBPF programs, there is no device
+	// controller in cgroup v2.
+	sysInfo.CgroupDevicesEnabled = true
+	sysInfo.cgroupMemInfo = checkCgroup2Mem(controllers, quiet)
+	sysInfo.cgroupCPUInfo = checkCgroup2CPU(controllers, quiet)
+	sysInfo.cgroupBlkioInfo = checkCgroup2IO(controllers, quiet)
+	sysInfo.cgroupCpusetInfo = checkCgroup2Cpuset(controllers, quiet)
+	sysInfo.cgroupPids = cgroupPids{PidsLimit: controllers["pids"]}
+	if !quiet && !controllers["pids"] {
+		logrus.Warn("Unable to find pids controller in cgroup v2")
+	}
+}
+
+// checkCgroup2Mem reads the memory information of the memory controller.
+// Kernel memory is accounted with the rest of the memory, and cgroup v2 has
+// no swappiness nor OOM killer control.
+func checkCgroup2Mem(controllers map[string]bool, quiet bool) cgroupMemInfo {
+	if !controllers["memory"] {
+		if !quiet {
+			logrus.Warn("Unable to find memory controller in cgroup v2")
+		}
+		return cgroupMemInfo{}
+	}
+	return cgroupMemInfo{
+		MemoryLimit:       true,
+		SwapLimit:         true,
+		MemoryReservation: true,
+		MemoryHigh:        true,
+		MemoryLow:         true,
+	}
+}
+
+// checkCgroup2CPU reads the cpu information of the cpu controller. cgroup
+// v2 has no real-time scheduling control.
+func checkCgroup2CPU(controllers map[string]bool, quiet bool) cgroupCPUInfo {
+	if !controllers["cpu"] {
+		if !quiet {
+			logrus.Warn("Unable to find cpu controller in cgroup v2")
+		}
+		return cgroupCPUInfo{}
+	}
+	return cgroupCPUInfo{
+		CPUShares:    true,
+		CPUCfsPeriod: true,
+		CPUCfsQuota:  true,
+	}
+}
+
+// checkCgroup2IO reads the block IO information of the io controller.
+// io.latency depends on a kernel option and is not available in the root
+// cgroup, so it is looked up in the cgroup of the daemon.
+func checkCgroup2IO(controllers map[string]bool, quiet bool) cgroupBlkioInfo {
+	if !controllers["io"] {
+		if !quiet {
+			logrus.Warn("Unable to find io controller in cgroup v2")
+		}
+		return cgroupBlkioInfo{}
+	}
+	latency := false
+	if own, err := cgroups.ParseCgroupFile("/proc/self/cgroup"); err == nil {
+		latency = cgroupEnabled(path.Join(Cgroup2Root, own[""]), "io.latency")
+	}
+	if !quiet && !latency {
+		logrus.Warn("Your kernel does not support cgroup io.latency")
+	}
+	return cgroupBlkioInfo{
+		BlkioWeight:          true,
+		BlkioWeightDevice:    true,
+		BlkioReadBpsDevice:   true,
+		BlkioWriteBpsDevice:  true,
+		BlkioReadIOpsDevice:  true,
+		BlkioWriteIOpsDevice: true,
+		BlkioLatencyTarget:   latency,
+	}
+}
+
+// checkCgroup2Cpuset reads the cpuset information of the cpuset controller.
+func checkCgroup2Cpuset(controllers map[string]bool, quiet bool) cgroupCpusetInfo {
+	if !controllers["cpuset"] {
+		if !quiet {
+			logrus.Warn("Unable to find cpuset controller in cgroup v2")
+		}
+		return cgroupCpusetInfo{}
+	}
+
+	cpus, err := ioutil.ReadFile(path.Join(Cgroup2Root, "cpuset.cpus.effective"))
+	if err != nil {
+		return cgroupCpusetInfo{}
+	}
+
+	mems, err := ioutil.ReadFile(path.Join(Cgroup2Root, "cpuset.mems.effective"))
+	if err != nil {
+		return cgroupCpusetInfo{}
+	}
+
+	return cgroupCpusetInfo{
+		Cpuset: true,
+		Cpus:   strings.TrimSpace(string(cpus)),
+		Mems:   strings.TrimSpace(string(mems)),
+	}
+}
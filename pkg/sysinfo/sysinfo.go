@@ -27,6 +27,9 @@ type SysInfo struct {
 
 	// Whether the cgroup has the mountpoint of "devices" or not
 	CgroupDevicesEnabled bool
+
+	// Whether the host uses the cgroup v2 unified hierarchy or not
+	CgroupUnified bool
 }
 
 type cgroupMemInfo struct {
@@ -47,6 +50,12 @@ type cgroupMemInfo struct {
 
 	// Whether kernel memory limit is supported or not
 	KernelMemory bool
+
+	// Whether memory high limit (memory.high) is supported or not
+	MemoryHigh bool
+
+	// Whether memory protection (memory.low) is supported or not
+	MemoryLow bool
 }
 
 type cgroupCPUInfo struct {
@@ -84,6 +93,9 @@ type cgroupBlkioInfo struct {
 
 	// Whether Block IO write limit in IO per second is supported or not
 	BlkioWriteIOpsDevice bool
+
+	// Whether Block IO latency target (io.latency) is supported or not
+	BlkioLatencyTarget bool
 }
 
 type cgroupCpusetInfo struct {
@@ -31,19 +31,23 @@ func findCgroupMountpoints() (map[string]string, error) {
 // whenever an error occurs or misconfigurations are present.
 func New(quiet bool) *SysInfo {
 	sysInfo := &SysInfo{}
-	cgMounts, err := findCgroupMountpoints()
-	if err != nil {
-		logrus.Warnf("Failed to parse cgroup information: %v", err)
+	if IsCgroup2UnifiedMode() {
+		checkCgroup2(sysInfo, quiet)
 	} else {
-		sysInfo.cgroupMemInfo = checkCgroupMem(cgMounts, quiet)
-		sysInfo.cgroupCPUInfo = checkCgroupCPU(cgMounts, quiet)
-		sysInfo.cgroupBlkioInfo = checkCgroupBlkioInfo(cgMounts, quiet)
-		sysInfo.cgroupCpusetInfo = checkCgroupCpusetInfo(cgMounts, quiet)
-		sysInfo.cgroupPids = checkCgroupPids(quiet)
-	}
+		cgMounts, err := findCgroupMountpoints()
+		if err != nil {
+			logrus.Warnf("Failed to parse cgroup information: %v", err)
+		} else {
+			sysInfo.cgroupMemInfo = checkCgroupMem(cgMounts, quiet)
+			sysInfo.cgroupCPUInfo = checkCgroupCPU(cgMounts, quiet)
+			sysInfo.cgroupBlkioInfo = checkCgroupBlkioInfo(cgMounts, quiet)
+			sysInfo.cgroupCpusetInfo = checkCgroupCpusetInfo(cgMounts, quiet)
+			sysInfo.cgroupPids = checkCgroupPids(quiet)
+		}
 
-	_, ok := cgMounts["devices"]
-	sysInfo.CgroupDevicesEnabled = ok
+		_, ok := cgMounts["devices"]
+		sysInfo.CgroupDevicesEnabled = ok
+	}
 
 	sysInfo.IPv4ForwardingDisabled = !readProcBool("/proc/sys/net/ipv4/ip_forward")
 	sysInfo.BridgeNFCallIPTablesDisabled = !readProcBool("/proc/sys/net/bridge/bridge-nf-call-iptables")
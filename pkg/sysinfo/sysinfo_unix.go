@@ -7,3 +7,8 @@ func New(quiet bool) *SysInfo {
 	sysInfo := &SysInfo{}
 	return sysInfo
 }
+
+// IsCgroup2UnifiedMode returns false on non linux.
+func IsCgroup2UnifiedMode() bool {
+	return false
+}
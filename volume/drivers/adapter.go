@@ -86,6 +86,40 @@ func (a *volumeDriverAdapter) Scope() string {
 	return cap.Scope
 }
 
+// Clone creates a volume with the given name as a copy of the volume src, or
+// of its given snapshot, if the plugin declares the Clone capability.
+func (a *volumeDriverAdapter) Clone(src volume.Volume, snapshot, name string, opts map[string]string) (volume.Volume, error) {
+	if !a.getCapabilities().Clone {
+		return nil, volume.ErrCloneNotSupported
+	}
+	if err := a.proxy.Clone(src.Name(), snapshot, name, opts); err != nil {
+		return nil, err
+	}
+	return &volumeAdapter{
+		proxy:      a.proxy,
+		name:       name,
+		driverName: a.name,
+		scopePath:  a.scopePath,
+	}, nil
+}
+
+// Snapshot saves a snapshot of the volume v with the given name, if the
+// plugin declares the Clone capability.
+func (a *volumeDriverAdapter) Snapshot(v volume.Volume, name string) error {
+	if !a.getCapabilities().Clone {
+		return volume.ErrCloneNotSupported
+	}
+	return a.proxy.Snapshot(v.Name(), name)
+}
+
+// RemoveSnapshot removes the snapshot with the given name of the volume v.
+func (a *volumeDriverAdapter) RemoveSnapshot(v volume.Volume, name string) error {
+	if !a.getCapabilities().Clone {
+		return volume.ErrCloneNotSupported
+	}
+	return a.proxy.RemoveSnapshot(v.Name(), name)
+}
+
 func (a *volumeDriverAdapter) getCapabilities() volume.Capability {
 	if a.capabilities != nil {
 		return *a.capabilities
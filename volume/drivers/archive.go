@@ -0,0 +1,75 @@
+package drivers // import "github.com/docker/docker/volume/drivers"
+
+import (
+	"errors"
+	"io"
+	"net/url"
+
+	"github.com/docker/docker/volume"
+)
+
+// The export and import of volumes stream tar archives to and from the
+// plugin, which the generated proxy does not support.
+
+type streamClient interface {
+	Stream(string, interface{}) (io.ReadCloser, error)
+	SendFile(string, io.Reader, interface{}) error
+}
+
+type volumeDriverProxyExportRequest struct {
+	Name string
+}
+
+type volumeDriverProxyImportResponse struct {
+	Err string
+}
+
+// Export returns a tar archive of the content of the volume with the given
+// name, which is the body of the response to VolumeDriver.Export.
+func (pp *volumeDriverProxy) Export(name string) (io.ReadCloser, error) {
+	c, ok := pp.client.(streamClient)
+	if !ok {
+		return nil, volume.ErrArchiveNotSupported
+	}
+	return c.Stream("VolumeDriver.Export", volumeDriverProxyExportRequest{Name: name})
+}
+
+// Import sends a tar archive to replace the content of the volume with the
+// given name as the body of VolumeDriver.Import, with the name of the volume
+// in the query.
+func (pp *volumeDriverProxy) Import(name string, content io.Reader) error {
+	c, ok := pp.client.(streamClient)
+	if !ok {
+		return volume.ErrArchiveNotSupported
+	}
+
+	var ret volumeDriverProxyImportResponse
+	if err := c.SendFile("VolumeDriver.Import?name="+url.QueryEscape(name), content, &ret); err != nil {
+		return err
+	}
+	if ret.Err != "" {
+		return errors.New(ret.Err)
+	}
+	return nil
+}
+
+// archiveSupported returns whether the plugin declares the Archive
+// capability.
+func (a *volumeAdapter) archiveSupported() bool {
+	cap, err := a.proxy.Capabilities()
+	return err == nil && cap.Archive
+}
+
+func (a *volumeAdapter) Export() (io.ReadCloser, error) {
+	if !a.archiveSupported() {
+		return nil, volume.ErrArchiveNotSupported
+	}
+	return a.proxy.Export(a.name)
+}
+
+func (a *volumeAdapter) Import(content io.Reader) error {
+	if !a.archiveSupported() {
+		return volume.ErrArchiveNotSupported
+	}
+	return a.proxy.Import(a.name, content)
+}
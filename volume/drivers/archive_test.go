@@ -0,0 +1,85 @@
+package drivers // import "github.com/docker/docker/volume/drivers"
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/docker/docker/pkg/plugins"
+	"github.com/docker/docker/volume"
+	"github.com/docker/go-connections/tlsconfig"
+)
+
+func newArchiveTestAdapter(t *testing.T, archive bool, imported *string) (*volumeAdapter, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/VolumeDriver.Capabilities", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.docker.plugins.v1+json")
+		fmt.Fprintf(w, `{"Capabilities": {"Scope": "local", "Archive": %t}}`, archive)
+	})
+
+	mux.HandleFunc("/VolumeDriver.Export", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-tar")
+		fmt.Fprint(w, "archive")
+	})
+
+	mux.HandleFunc("/VolumeDriver.Import", func(w http.ResponseWriter, r *http.Request) {
+		if name := r.URL.Query().Get("name"); name != "volume" {
+			http.Error(w, "unexpected volume "+name, http.StatusBadRequest)
+			return
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		*imported = string(b)
+		w.Header().Set("Content-Type", "application/vnd.docker.plugins.v1+json")
+		fmt.Fprintln(w, `{}`)
+	})
+
+	u, _ := url.Parse(server.URL)
+	client, err := plugins.NewClient("tcp://"+u.Host, &tlsconfig.Options{InsecureSkipVerify: true})
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+
+	return &volumeAdapter{proxy: &volumeDriverProxy{client}, name: "volume"}, server.Close
+}
+
+func TestVolumeArchive(t *testing.T) {
+	var imported string
+	a, cleanup := newArchiveTestAdapter(t, true, &imported)
+	defer cleanup()
+
+	rc, err := a.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+
+	if err := a.Import(rc); err != nil {
+		t.Fatal(err)
+	}
+	if imported != "archive" {
+		t.Fatalf("expected the exported archive to be imported, got %q", imported)
+	}
+}
+
+func TestVolumeArchiveNotSupported(t *testing.T) {
+	var imported string
+	a, cleanup := newArchiveTestAdapter(t, false, &imported)
+	defer cleanup()
+
+	if _, err := a.Export(); err != volume.ErrArchiveNotSupported {
+		t.Fatalf("expected ErrArchiveNotSupported, got %v", err)
+	}
+	if err := a.Import(nil); err != volume.ErrArchiveNotSupported {
+		t.Fatalf("expected ErrArchiveNotSupported, got %v", err)
+	}
+}
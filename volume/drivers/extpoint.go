@@ -44,6 +44,13 @@ type volumeDriver interface {
 	Get(name string) (volume *proxyVolume, err error)
 	// Capabilities gets the list of capabilities of the driver
 	Capabilities() (capabilities volume.Capability, err error)
+	// Clone creates a volume with the given name as a copy of the volume src,
+	// or of its given snapshot
+	Clone(src, snapshot, name string, opts map[string]string) (err error)
+	// Snapshot saves a snapshot with the given name of the given volume
+	Snapshot(name, snapshot string) (err error)
+	// RemoveSnapshot removes the snapshot with the given name of the given volume
+	RemoveSnapshot(name, snapshot string) (err error)
 }
 
 // Store is an in-memory store for volume drivers
@@ -253,3 +253,94 @@ func (pp *volumeDriverProxy) Capabilities() (capabilities volume.Capability, err
 
 	return
 }
+
+type volumeDriverProxyCloneRequest struct {
+	Src      string
+	Snapshot string
+	Name     string
+	Opts     map[string]string
+}
+
+type volumeDriverProxyCloneResponse struct {
+	Err string
+}
+
+func (pp *volumeDriverProxy) Clone(src string, snapshot string, name string, opts map[string]string) (err error) {
+	var (
+		req volumeDriverProxyCloneRequest
+		ret volumeDriverProxyCloneResponse
+	)
+
+	req.Src = src
+	req.Snapshot = snapshot
+	req.Name = name
+	req.Opts = opts
+
+	if err = pp.CallWithOptions("VolumeDriver.Clone", req, &ret, plugins.WithRequestTimeout(longTimeout)); err != nil {
+		return
+	}
+
+	if ret.Err != "" {
+		err = errors.New(ret.Err)
+	}
+
+	return
+}
+
+type volumeDriverProxySnapshotRequest struct {
+	Name     string
+	Snapshot string
+}
+
+type volumeDriverProxySnapshotResponse struct {
+	Err string
+}
+
+func (pp *volumeDriverProxy) Snapshot(name string, snapshot string) (err error) {
+	var (
+		req volumeDriverProxySnapshotRequest
+		ret volumeDriverProxySnapshotResponse
+	)
+
+	req.Name = name
+	req.Snapshot = snapshot
+
+	if err = pp.CallWithOptions("VolumeDriver.Snapshot", req, &ret, plugins.WithRequestTimeout(longTimeout)); err != nil {
+		return
+	}
+
+	if ret.Err != "" {
+		err = errors.New(ret.Err)
+	}
+
+	return
+}
+
+type volumeDriverProxyRemoveSnapshotRequest struct {
+	Name     string
+	Snapshot string
+}
+
+type volumeDriverProxyRemoveSnapshotResponse struct {
+	Err string
+}
+
+func (pp *volumeDriverProxy) RemoveSnapshot(name string, snapshot string) (err error) {
+	var (
+		req volumeDriverProxyRemoveSnapshotRequest
+		ret volumeDriverProxyRemoveSnapshotResponse
+	)
+
+	req.Name = name
+	req.Snapshot = snapshot
+
+	if err = pp.CallWithOptions("VolumeDriver.RemoveSnapshot", req, &ret, plugins.WithRequestTimeout(shortTimeout)); err != nil {
+		return
+	}
+
+	if ret.Err != "" {
+		err = errors.New(ret.Err)
+	}
+
+	return
+}
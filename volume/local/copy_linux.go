@@ -0,0 +1,9 @@
+package local // import "github.com/docker/docker/volume/local"
+
+import "github.com/docker/docker/daemon/graphdriver/copy"
+
+// copyDir copies the content of the directory src to dst, with reflinks if
+// the filesystem supports them.
+func copyDir(src, dst string) error {
+	return copy.DirCopy(src, dst, copy.Content, true)
+}
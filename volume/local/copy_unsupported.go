@@ -0,0 +1,10 @@
+// +build !linux
+
+package local // import "github.com/docker/docker/volume/local"
+
+import "github.com/docker/docker/pkg/chrootarchive"
+
+// copyDir copies the content of the directory src to dst.
+func copyDir(src, dst string) error {
+	return chrootarchive.NewArchiver(nil).CopyWithTar(src, dst)
+}
@@ -0,0 +1,166 @@
+package local // import "github.com/docker/docker/volume/local"
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+)
+
+const (
+	encryptionFileName = "encryption.json"
+	encryptedImageName = "encrypted.img"
+
+	// volumeKeySize is the size of the keys of encrypted volumes.
+	volumeKeySize = 32
+)
+
+// KeyProvider provides the keys of the encrypted volumes. The key of a volume
+// is kept with the volume in the form wrapped by the provider, so that a
+// provider backed by a key management service only has to wrap and unwrap
+// keys.
+type KeyProvider interface {
+	// NewKey returns a new key for the volume with the given name, and the
+	// key wrapped to be kept with the volume.
+	NewKey(volume string) (key, wrapped []byte, err error)
+	// UnwrapKey returns the key of the volume with the given name from its
+	// wrapped form.
+	UnwrapKey(volume string, wrapped []byte) ([]byte, error)
+}
+
+// encryptionConfig is the configuration of an encrypted volume, persisted
+// with the volume.
+type encryptionConfig struct {
+	// Size is the size of the encrypted filesystem of the volume.
+	Size int64
+	// Device is the name of the device mapper device of the unlocked volume.
+	Device string
+	// Key is the key of the volume, wrapped by the key provider.
+	Key []byte
+}
+
+// SetKeyProvider sets the provider of the keys of the encrypted volumes.
+// Encrypted volumes cannot be created or mounted without a key provider.
+func (r *Root) SetKeyProvider(keys KeyProvider) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.keys = keys
+	for _, v := range r.volumes {
+		v.m.Lock()
+		v.keys = keys
+		v.m.Unlock()
+	}
+}
+
+// encryptedImagePath returns the path of the image file of the encrypted
+// filesystem of the volume.
+func (v *localVolume) encryptedImagePath() string {
+	return filepath.Join(filepath.Dir(v.path), encryptedImageName)
+}
+
+// volumeKey returns the key of the encrypted volume.
+func (v *localVolume) volumeKey() ([]byte, error) {
+	if v.keys == nil {
+		return nil, errors.New("no key provider is configured for encrypted volumes")
+	}
+	key, err := v.keys.UnwrapKey(v.name, v.encryption.Key)
+	return key, errors.Wrap(err, "error while unwrapping volume key")
+}
+
+func writeEncryptionConfig(dir string, config *encryptionConfig) error {
+	b, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(ioutil.WriteFile(filepath.Join(dir, encryptionFileName), b, 0600), "error while persisting volume encryption")
+}
+
+func readEncryptionConfig(dir string) (*encryptionConfig, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, encryptionFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var config encryptionConfig
+	if err := json.Unmarshal(b, &config); err != nil {
+		return nil, errors.Wrap(err, "error while unmarshaling volume encryption")
+	}
+	return &config, nil
+}
+
+// FileKeyProvider is a KeyProvider wrapping the keys of the volumes with a
+// master key read from a file, with AES-GCM.
+type FileKeyProvider struct {
+	aead cipher.AEAD
+}
+
+// NewFileKeyProvider returns a FileKeyProvider with the master key of the
+// file at the given path. The file contains a 256-bit key, raw or hex
+// encoded, and must not be accessible by group or others.
+func NewFileKeyProvider(path string) (*FileKeyProvider, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if fi.Mode().Perm()&0077 != 0 {
+		return nil, errors.Errorf("volume encryption key file %s must not be accessible by group or others", path)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key := b
+	if len(b) != volumeKeySize {
+		key, err = hex.DecodeString(strings.TrimSpace(string(b)))
+		if err != nil || len(key) != volumeKeySize {
+			return nil, errors.Errorf("volume encryption key file %s must contain a 256-bit key, raw or hex encoded", path)
+		}
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &FileKeyProvider{aead: aead}, nil
+}
+
+// NewKey returns a new random key, and the key encrypted with the master key.
+// The name of the volume is authenticated with the key.
+func (p *FileKeyProvider) NewKey(volume string) ([]byte, []byte, error) {
+	key := make([]byte, volumeKeySize)
+	nonce := make([]byte, p.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, nil, err
+	}
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, err
+	}
+	return key, p.aead.Seal(nonce, nonce, key, []byte(volume)), nil
+}
+
+// UnwrapKey decrypts the key of the volume with the master key.
+func (p *FileKeyProvider) UnwrapKey(volume string, wrapped []byte) ([]byte, error) {
+	n := p.aead.NonceSize()
+	if len(wrapped) < n {
+		return nil, errdefs.InvalidParameter(errors.New("invalid wrapped key"))
+	}
+	key, err := p.aead.Open(nil, wrapped[:n], wrapped[n:], []byte(volume))
+	if err != nil {
+		return nil, errdefs.Forbidden(errors.New("the volume key cannot be decrypted with the volume encryption key"))
+	}
+	return key, nil
+}
@@ -0,0 +1,121 @@
+package local // import "github.com/docker/docker/volume/local"
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/pkg/idtools"
+	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// encryptedFSType is the type of the filesystem of the encrypted volumes.
+const encryptedFSType = "ext4"
+
+// formatEncrypted creates the encrypted filesystem of the volume v, in a
+// LUKS-formatted image file next to its data directory, with a new key from
+// the key provider. The root of the filesystem is owned by rootIDs.
+func (v *localVolume) formatEncrypted(rootIDs idtools.IDPair) (retErr error) {
+	key, wrapped, err := v.keys.NewKey(v.name)
+	if err != nil {
+		return errors.Wrap(err, "error while creating volume key")
+	}
+	v.encryption.Key = wrapped
+	v.encryption.Device = "docker-volume-" + stringid.GenerateNonCryptoID()[:12]
+
+	img := v.encryptedImagePath()
+	f, err := os.OpenFile(img, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	err = f.Truncate(v.encryption.Size)
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := cryptsetup(key, "luksFormat", "--batch-mode", "--key-file=-", img); err != nil {
+		return err
+	}
+	if err := openEncrypted(img, v.encryption.Device, key); err != nil {
+		return err
+	}
+	defer func() {
+		if err := closeEncrypted(v.encryption.Device); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+
+	dev := filepath.Join("/dev/mapper", v.encryption.Device)
+	if out, err := exec.Command("mkfs."+encryptedFSType, "-q", "-m", "0", dev).CombinedOutput(); err != nil {
+		return errors.Errorf("error while creating the filesystem of the volume: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	if err := mount.Mount(dev, v.path, encryptedFSType, ""); err != nil {
+		return err
+	}
+	defer func() {
+		if err := mount.Unmount(v.path); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+
+	// The volume starts empty, so that the data of the image is copied to
+	// it on first use.
+	if err := os.RemoveAll(filepath.Join(v.path, "lost+found")); err != nil {
+		return err
+	}
+	return os.Chown(v.path, rootIDs.UID, rootIDs.GID)
+}
+
+// mountEncrypted unlocks the encrypted filesystem of the volume v and mounts
+// it on its data directory.
+func (v *localVolume) mountEncrypted() error {
+	key, err := v.volumeKey()
+	if err != nil {
+		return err
+	}
+	if err := openEncrypted(v.encryptedImagePath(), v.encryption.Device, key); err != nil {
+		return err
+	}
+	if err := mount.Mount(filepath.Join("/dev/mapper", v.encryption.Device), v.path, encryptedFSType, ""); err != nil {
+		if cErr := closeEncrypted(v.encryption.Device); cErr != nil {
+			logrus.WithError(cErr).Warnf("failed to lock volume %s", v.name)
+		}
+		return errors.Wrap(err, "error while mounting encrypted volume")
+	}
+	return nil
+}
+
+// openEncrypted unlocks the LUKS image file img with the key, as the device
+// mapper device with the given name.
+func openEncrypted(img, device string, key []byte) error {
+	return cryptsetup(key, "open", "--type", "luks", "--key-file=-", img, device)
+}
+
+// closeEncrypted locks the device mapper device with the given name, if it
+// is unlocked.
+func closeEncrypted(device string) error {
+	if _, err := os.Stat(filepath.Join("/dev/mapper", device)); os.IsNotExist(err) {
+		return nil
+	}
+	return cryptsetup(nil, "close", device)
+}
+
+// cryptsetup runs cryptsetup with the given arguments, and the key on its
+// standard input.
+func cryptsetup(key []byte, args ...string) error {
+	cmd := exec.Command("cryptsetup", args...)
+	cmd.Stdin = bytes.NewReader(key)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("cryptsetup %s failed: %v: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package local // import "github.com/docker/docker/volume/local"
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/pkg/idtools"
+	"github.com/gotestyourself/gotestyourself/skip"
+)
+
+func newTestKeyProvider(t *testing.T, dir string) *FileKeyProvider {
+	path := filepath.Join(dir, "key")
+	if err := ioutil.WriteFile(path, []byte(strings.Repeat("ab", volumeKeySize)+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewFileKeyProvider(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestFileKeyProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "invalid")
+	if err := ioutil.WriteFile(path, []byte("short"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileKeyProvider(path); err == nil || !strings.Contains(err.Error(), "must contain a 256-bit key") {
+		t.Fatalf("expected an invalid key to cause error, got %v", err)
+	}
+	if runtime.GOOS != "windows" {
+		if err := os.Chmod(path, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewFileKeyProvider(path); err == nil || !strings.Contains(err.Error(), "must not be accessible") {
+			t.Fatalf("expected a readable key file to cause error, got %v", err)
+		}
+	}
+
+	p := newTestKeyProvider(t, dir)
+	key, wrapped, err := p.NewKey("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(key) != volumeKeySize || bytes.Contains(wrapped, key) {
+		t.Fatalf("expected a wrapped %d bytes key, got %x wrapped as %x", volumeKeySize, key, wrapped)
+	}
+	unwrapped, err := p.UnwrapKey("test", wrapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(unwrapped, key) {
+		t.Fatalf("expected key %x, got %x", key, unwrapped)
+	}
+	if _, err := p.UnwrapKey("other", wrapped); err == nil {
+		t.Fatal("expected the key of another volume to fail to unwrap")
+	}
+
+	// a raw key is the same as its hex encoding
+	raw := filepath.Join(dir, "raw")
+	if err := ioutil.WriteFile(raw, bytes.Repeat([]byte{0xab}, volumeKeySize), 0600); err != nil {
+		t.Fatal(err)
+	}
+	p, err = NewFileKeyProvider(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unwrapped, err = p.UnwrapKey("test", wrapped); err != nil || !bytes.Equal(unwrapped, key) {
+		t.Fatalf("expected key %x, got %x: %v", key, unwrapped, err)
+	}
+}
+
+func TestCreateEncrypted(t *testing.T) {
+	skip.If(t, runtime.GOOS == "windows")
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, idtools.IDPair{UID: os.Getuid(), GID: os.Getegid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.Create("test", map[string]string{"encrypt": "true", "size": "10m"}); err == nil || !strings.Contains(err.Error(), "requires the daemon to be configured") {
+		t.Fatalf("expected encryption without key provider to cause error, got %v", err)
+	}
+	r.SetKeyProvider(newTestKeyProvider(t, rootDir))
+
+	for expected, opts := range map[string]map[string]string{
+		"invalid encrypt option":  {"encrypt": "maybe", "size": "10m"},
+		"requires the size":       {"encrypt": "true"},
+		"cannot be combined with": {"encrypt": "true", "size": "10m", "type": "tmpfs"},
+	} {
+		if _, err := r.Create("test", opts); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected %v to cause error %q, got %v", opts, expected, err)
+		}
+	}
+
+	if v, err := r.Create("plain", map[string]string{"encrypt": "false"}); err != nil {
+		t.Fatal(err)
+	} else if lv := v.(*localVolume); lv.encryption != nil || lv.opts != nil {
+		t.Fatalf("expected a plain volume, got %v", lv.encryption)
+	}
+
+	_, err = exec.LookPath("cryptsetup")
+	skip.If(t, err != nil || os.Getuid() != 0, "encrypted volumes require cryptsetup and root")
+
+	v, err := r.Create("test", map[string]string{"encrypt": "true", "size": "32m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := v.Status(); status["Encrypted"] != true {
+		t.Fatalf("expected an encrypted volume, got %v", status)
+	}
+
+	path, err := v.Mount("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries, err := ioutil.ReadDir(path); err != nil || len(entries) != 0 {
+		t.Fatalf("expected an empty volume, got %v: %v", entries, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(path, "secret"), []byte("data"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Unmount("1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(path, "secret")); !os.IsNotExist(err) {
+		t.Fatalf("expected the data of the locked volume not to be readable, got %v", err)
+	}
+
+	// the volume is unlocked with the key of the key provider after a restart
+	r, err = New(rootDir, idtools.IDPair{UID: os.Getuid(), GID: os.Getegid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.SetKeyProvider(newTestKeyProvider(t, rootDir))
+	v, err = r.Get("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err = v.Mount("2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := ioutil.ReadFile(filepath.Join(path, "secret")); err != nil || string(b) != "data" {
+		t.Fatalf("expected the data of the volume, got %q: %v", b, err)
+	}
+	if err := v.Unmount("2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Remove(v); err != nil {
+		t.Fatal(err)
+	}
+}
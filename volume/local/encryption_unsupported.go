@@ -0,0 +1,23 @@
+// +build !linux
+
+package local // import "github.com/docker/docker/volume/local"
+
+import (
+	"errors"
+
+	"github.com/docker/docker/pkg/idtools"
+)
+
+var errEncryptionNotSupported = errors.New("encrypted volumes are not supported on this platform")
+
+func (v *localVolume) formatEncrypted(rootIDs idtools.IDPair) error {
+	return errEncryptionNotSupported
+}
+
+func (v *localVolume) mountEncrypted() error {
+	return errEncryptionNotSupported
+}
+
+func closeEncrypted(device string) error {
+	return nil
+}
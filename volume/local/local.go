@@ -6,6 +6,7 @@ package local // import "github.com/docker/docker/volume/local"
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -13,12 +14,18 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/docker/docker/daemon/graphdriver/quota"
 	"github.com/docker/docker/daemon/names"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/chrootarchive"
 	"github.com/docker/docker/pkg/idtools"
+	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/volume"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 // VolumeDataPathName is the name of the directory where the volume data is stored.
@@ -27,6 +34,7 @@ import (
 const (
 	VolumeDataPathName = "_data"
 	volumesPathName    = "volumes"
+	snapshotsPathName  = "volume-snapshots"
 )
 
 var (
@@ -54,10 +62,18 @@ func New(scope string, rootIDs idtools.IDPair) (*Root, error) {
 	}
 
 	r := &Root{
-		scope:   scope,
-		path:    rootDirectory,
-		volumes: make(map[string]*localVolume),
-		rootIDs: rootIDs,
+		scope:         scope,
+		path:          rootDirectory,
+		snapshotsPath: filepath.Join(scope, snapshotsPathName),
+		volumes:       make(map[string]*localVolume),
+		rootIDs:       rootIDs,
+	}
+
+	// Try to enable project quota support for the size option of volumes.
+	if quotaCtl, err := quota.NewControl(rootDirectory); err == nil {
+		r.quotaCtl = quotaCtl
+	} else {
+		logrus.Debugf("local volume driver: project quotas are not supported: %v", err)
 	}
 
 	dirs, err := ioutil.ReadDir(rootDirectory)
@@ -72,11 +88,23 @@ func New(scope string, rootIDs idtools.IDPair) (*Root, error) {
 
 		name := filepath.Base(d.Name())
 		v := &localVolume{
-			driverName: r.Name(),
-			name:       name,
-			path:       r.DataPath(name),
+			driverName:    r.Name(),
+			name:          name,
+			path:          r.DataPath(name),
+			snapshotsPath: filepath.Join(r.snapshotsPath, name),
+			quotaCtl:      r.quotaCtl,
 		}
 		r.volumes[name] = v
+		if v.encryption, err = readEncryptionConfig(filepath.Join(rootDirectory, name)); err != nil {
+			return nil, errors.Wrapf(err, "error while reading volume encryption for volume: %s", name)
+		}
+		if v.encryption != nil {
+			// lock anything that may still be unlocked (for example, from an unclean shutdown)
+			mount.Unmount(v.path)
+			if err := closeEncrypted(v.encryption.Device); err != nil {
+				logrus.WithError(err).Warnf("failed to lock volume %s", name)
+			}
+		}
 		optsFilePath := filepath.Join(rootDirectory, name, "opts.json")
 		if b, err := ioutil.ReadFile(optsFilePath); err == nil {
 			opts := optsConfig{}
@@ -101,11 +129,29 @@ func New(scope string, rootIDs idtools.IDPair) (*Root, error) {
 // manages the creation/removal of volumes. It uses only standard vfs
 // commands to create/remove dirs within its provided scope.
 type Root struct {
-	m       sync.Mutex
-	scope   string
-	path    string
-	volumes map[string]*localVolume
-	rootIDs idtools.IDPair
+	m             sync.Mutex
+	scope         string
+	path          string
+	snapshotsPath string
+	volumes       map[string]*localVolume
+	rootIDs       idtools.IDPair
+	idMappings    *idtools.IDMappings
+	quotaCtl      *quota.Control
+	keys          KeyProvider
+}
+
+// SetIDMappings sets the user namespace mappings of the daemon, with which
+// the owners of the files of the volumes are translated when they are
+// exported and imported.
+func (r *Root) SetIDMappings(idMappings *idtools.IDMappings) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.idMappings = idMappings
+	for _, v := range r.volumes {
+		v.m.Lock()
+		v.idMappings = idMappings
+		v.m.Unlock()
+	}
 }
 
 // List lists all the volumes
@@ -145,8 +191,27 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 		return v, nil
 	}
 
-	path := r.DataPath(name)
-	if err := idtools.MkdirAllAndChown(path, 0755, r.rootIDs); err != nil {
+	v = &localVolume{
+		driverName:    r.Name(),
+		name:          name,
+		path:          r.DataPath(name),
+		snapshotsPath: filepath.Join(r.snapshotsPath, name),
+		quotaCtl:      r.quotaCtl,
+		keys:          r.keys,
+		idMappings:    r.idMappings,
+	}
+	if err := setOpts(v, opts); err != nil {
+		return nil, err
+	}
+	if v.quota.Size > 0 && r.quotaCtl == nil {
+		return nil, validationError("the size option requires a filesystem with project quotas, such as xfs or ext4 mounted with the prjquota option")
+	}
+	if v.encryption != nil && r.keys == nil {
+		return nil, validationError("the encrypt option requires the daemon to be configured with a volume encryption key")
+	}
+
+	path := v.path
+	if err := idtools.MkdirAllAndChown(filepath.Dir(path), 0755, r.rootIDs); err != nil {
 		return nil, errors.Wrapf(errdefs.System(err), "error while creating volume path '%s'", path)
 	}
 
@@ -154,19 +219,25 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 	defer func() {
 		if err != nil {
 			os.RemoveAll(filepath.Dir(path))
+			if r.quotaCtl != nil {
+				r.quotaCtl.RemoveQuota(filepath.Dir(path))
+			}
 		}
 	}()
 
-	v = &localVolume{
-		driverName: r.Name(),
-		name:       name,
-		path:       path,
+	if v.quota.Size > 0 {
+		// The quota is set on the volume directory before creating the data
+		// directory, which inherits its project id.
+		if err = r.quotaCtl.SetQuota(filepath.Dir(path), v.quota); err != nil {
+			return nil, errdefs.System(errors.Wrap(err, "error while setting volume quota"))
+		}
 	}
 
-	if len(opts) != 0 {
-		if err = setOpts(v, opts); err != nil {
-			return nil, err
-		}
+	if err = idtools.MkdirAllAndChown(path, 0755, r.rootIDs); err != nil {
+		return nil, errors.Wrapf(errdefs.System(err), "error while creating volume path '%s'", path)
+	}
+
+	if v.opts != nil {
 		var b []byte
 		b, err = json.Marshal(v.opts)
 		if err != nil {
@@ -177,6 +248,15 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 		}
 	}
 
+	if v.encryption != nil {
+		if err = v.formatEncrypted(r.rootIDs); err != nil {
+			return nil, errdefs.System(errors.Wrap(err, "error while creating encrypted volume"))
+		}
+		if err = writeEncryptionConfig(filepath.Dir(path), v.encryption); err != nil {
+			return nil, errdefs.System(err)
+		}
+	}
+
 	r.volumes[name] = v
 	return v, nil
 }
@@ -219,7 +299,16 @@ func (r *Root) Remove(v volume.Volume) error {
 	}
 
 	delete(r.volumes, lv.name)
-	return removePath(filepath.Dir(lv.path))
+	if err := removePath(filepath.Dir(lv.path)); err != nil {
+		return err
+	}
+	if err := removePath(lv.snapshotsPath); err != nil {
+		return err
+	}
+	if r.quotaCtl != nil {
+		r.quotaCtl.RemoveQuota(filepath.Dir(lv.path))
+	}
+	return nil
 }
 
 func removePath(path string) error {
@@ -274,12 +363,25 @@ type localVolume struct {
 	name string
 	// path is the path on the host where the data lives
 	path string
+	// snapshotsPath is the path on the host where the snapshots live,
+	// outside of the directory of the volume and its quota
+	snapshotsPath string
 	// driverName is the name of the driver that created the volume.
 	driverName string
 	// opts is the parsed list of options used to create the volume
 	opts *optsConfig
 	// active refcounts the active mounts
 	active activeMount
+	// quota is the size quota requested when creating the volume
+	quota quota.Quota
+	// quotaCtl is the project quota control of the driver, if supported
+	quotaCtl *quota.Control
+	// encryption is the configuration of an encrypted volume
+	encryption *encryptionConfig
+	// keys is the key provider of the driver, for encrypted volumes
+	keys KeyProvider
+	// idMappings are the user namespace mappings of the daemon, if any
+	idMappings *idtools.IDMappings
 }
 
 // Name returns the name of the given Volume.
@@ -307,7 +409,7 @@ func (v *localVolume) CachedPath() string {
 func (v *localVolume) Mount(id string) (string, error) {
 	v.m.Lock()
 	defer v.m.Unlock()
-	if v.opts != nil {
+	if v.needsMount() {
 		if !v.active.mounted {
 			if err := v.mount(); err != nil {
 				return "", errdefs.System(err)
@@ -329,7 +431,7 @@ func (v *localVolume) Unmount(id string) error {
 	// Essentially docker doesn't care if this fails, it will send an error, but
 	// ultimately there's nothing that can be done. If we don't decrement the count
 	// this volume can never be removed until a daemon restart occurs.
-	if v.opts != nil {
+	if v.needsMount() {
 		v.active.count--
 	}
 
@@ -341,17 +443,273 @@ func (v *localVolume) Unmount(id string) error {
 }
 
 func (v *localVolume) unmount() error {
-	if v.opts != nil {
+	if v.needsMount() {
 		if err := mount.Unmount(v.path); err != nil {
 			if mounted, mErr := mount.Mounted(v.path); mounted || mErr != nil {
 				return errdefs.System(errors.Wrapf(err, "error while unmounting volume path '%s'", v.path))
 			}
 		}
+		if v.encryption != nil {
+			if err := closeEncrypted(v.encryption.Device); err != nil {
+				return errdefs.System(errors.Wrap(err, "error while locking encrypted volume"))
+			}
+		}
 		v.active.mounted = false
 	}
 	return nil
 }
 
+// needsMount returns whether the data of the volume is only available once
+// it is mounted, for volumes with mount options and encrypted volumes.
+func (v *localVolume) needsMount() bool {
+	return v.opts != nil || v.encryption != nil
+}
+
+// Export returns a tar archive of the data of the volume. The volume stays
+// mounted until the archive is closed.
+func (v *localVolume) Export() (io.ReadCloser, error) {
+	id := "export-" + stringid.GenerateNonCryptoID()
+	path, err := v.Mount(id)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := archive.TarWithOptions(path, v.archiveOptions())
+	if err != nil {
+		v.Unmount(id)
+		return nil, errdefs.System(errors.Wrapf(err, "error while exporting volume path '%s'", path))
+	}
+	return ioutils.NewReadCloserWrapper(rc, func() error {
+		err := rc.Close()
+		if uErr := v.Unmount(id); err == nil {
+			err = uErr
+		}
+		return err
+	}), nil
+}
+
+// Import replaces the data of the volume with the content of the tar
+// archive. The archive is extracted to a temporary directory first, so that
+// the data is left untouched if the archive cannot be extracted.
+func (v *localVolume) Import(content io.Reader) error {
+	id := "import-" + stringid.GenerateNonCryptoID()
+	path, err := v.Mount(id)
+	if err != nil {
+		return err
+	}
+	defer v.Unmount(id)
+
+	// The temporary directory must be on the filesystem of the data, in
+	// the mounted filesystem of the volumes which need to be mounted.
+	tmp := filepath.Join(filepath.Dir(path), "."+id)
+	if v.needsMount() {
+		tmp = filepath.Join(path, "."+id)
+	}
+	if err := os.Mkdir(tmp, 0700); err != nil {
+		return errdefs.System(err)
+	}
+	defer removePath(tmp)
+
+	if err := chrootarchive.Untar(content, tmp, v.archiveOptions()); err != nil {
+		return errdefs.System(errors.Wrapf(err, "error while importing to volume path '%s'", path))
+	}
+
+	// The entries of the data directory are replaced rather than the
+	// directory itself, which may be bind mounted by containers.
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		return errdefs.System(errors.Wrapf(err, "error while reading volume path '%s'", path))
+	}
+	for _, e := range entries {
+		if e.Name() == filepath.Base(tmp) {
+			continue
+		}
+		if err := removePath(filepath.Join(path, e.Name())); err != nil {
+			return err
+		}
+	}
+	entries, err = ioutil.ReadDir(tmp)
+	if err != nil {
+		return errdefs.System(err)
+	}
+	for _, e := range entries {
+		if err := os.Rename(filepath.Join(tmp, e.Name()), filepath.Join(path, e.Name())); err != nil {
+			return errdefs.System(errors.Wrapf(err, "error while importing to volume path '%s'", path))
+		}
+	}
+	return nil
+}
+
+// archiveOptions returns the options to export and import the data of the
+// volume with, translating the owners of the files between the host and the
+// user namespace of the containers.
+func (v *localVolume) archiveOptions() *archive.TarOptions {
+	opts := &archive.TarOptions{Compression: archive.Uncompressed}
+	if v.idMappings != nil {
+		opts.UIDMaps = v.idMappings.UIDs()
+		opts.GIDMaps = v.idMappings.GIDs()
+	}
+	return opts
+}
+
+// Clone creates a volume with the given name and options with a copy of the
+// data of the volume src, or of its snapshot with the given name.
+func (r *Root) Clone(src volume.Volume, snapshot, name string, opts map[string]string) (volume.Volume, error) {
+	lsrc, err := r.getCloneVolume(src)
+	if err != nil {
+		return nil, err
+	}
+
+	srcPath := lsrc.path
+	if snapshot != "" {
+		if err := validateSnapshotName(snapshot); err != nil {
+			return nil, err
+		}
+		srcPath = lsrc.snapshotPath(snapshot)
+		if _, err := os.Lstat(srcPath); err != nil {
+			if os.IsNotExist(err) {
+				return nil, errdefs.NotFound(errors.Errorf("snapshot %s does not exist for volume %s", snapshot, lsrc.name))
+			}
+			return nil, errdefs.System(err)
+		}
+	}
+
+	r.m.Lock()
+	_, exists := r.volumes[name]
+	r.m.Unlock()
+	if exists {
+		return nil, errdefs.Conflict(errors.Errorf("volume %s already exists", name))
+	}
+
+	v, err := r.Create(name, opts)
+	if err != nil {
+		return nil, err
+	}
+	lv := v.(*localVolume)
+	if !lv.needsMount() {
+		err = copyDir(srcPath, lv.path)
+	} else {
+		err = validationError("cannot clone to a volume with mount options or encryption")
+	}
+	if err != nil {
+		if rmErr := r.Remove(v); rmErr != nil {
+			logrus.WithError(rmErr).Errorf("failed to remove volume %s after failing to clone it", name)
+		}
+		if _, ok := err.(validationError); ok {
+			return nil, err
+		}
+		return nil, errdefs.System(errors.Wrapf(err, "error while cloning volume %s", lsrc.name))
+	}
+	return v, nil
+}
+
+// Snapshot saves a copy of the data of the volume to a snapshot with the
+// given name. The snapshots are kept outside of the directory of the volume,
+// and do not count towards its size quota.
+func (r *Root) Snapshot(v volume.Volume, name string) error {
+	lv, err := r.getCloneVolume(v)
+	if err != nil {
+		return err
+	}
+	if err := validateSnapshotName(name); err != nil {
+		return err
+	}
+
+	lv.m.Lock()
+	defer lv.m.Unlock()
+
+	path := lv.snapshotPath(name)
+	if _, err := os.Lstat(path); err == nil {
+		return errdefs.Conflict(errors.Errorf("snapshot %s already exists for volume %s", name, lv.name))
+	}
+	if err := idtools.MkdirAllAndChown(filepath.Dir(path), 0700, r.rootIDs); err != nil {
+		return errdefs.System(err)
+	}
+
+	// The snapshot is copied to a temporary directory, whose name is not a
+	// valid snapshot name, so that an incomplete snapshot is never used.
+	tmp := filepath.Join(filepath.Dir(path), "."+name)
+	if err := removePath(tmp); err != nil {
+		return err
+	}
+	if err := copyDir(lv.path, tmp); err != nil {
+		removePath(tmp)
+		return errdefs.System(errors.Wrapf(err, "error while taking snapshot %s of volume %s", name, lv.name))
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		removePath(tmp)
+		return errdefs.System(err)
+	}
+	return nil
+}
+
+// RemoveSnapshot removes the snapshot of the volume with the given name.
+func (r *Root) RemoveSnapshot(v volume.Volume, name string) error {
+	lv, err := r.getCloneVolume(v)
+	if err != nil {
+		return err
+	}
+	if err := validateSnapshotName(name); err != nil {
+		return err
+	}
+
+	lv.m.Lock()
+	defer lv.m.Unlock()
+
+	path := lv.snapshotPath(name)
+	if _, err := os.Lstat(path); err != nil {
+		if os.IsNotExist(err) {
+			return errdefs.NotFound(errors.Errorf("snapshot %s does not exist for volume %s", name, lv.name))
+		}
+		return errdefs.System(err)
+	}
+	return removePath(path)
+}
+
+func validateSnapshotName(name string) error {
+	if !volumeNameRegex.MatchString(name) {
+		return validationError(fmt.Sprintf("invalid snapshot name %q, only %q are allowed", name, names.RestrictedNameChars))
+	}
+	return nil
+}
+
+// getCloneVolume returns the local volume v, which can be cloned or
+// snapshotted if it has no mount options and is not encrypted.
+func (r *Root) getCloneVolume(v volume.Volume) (*localVolume, error) {
+	lv, ok := v.(*localVolume)
+	if !ok {
+		return nil, errdefs.System(errors.Errorf("unknown volume type %T", v))
+	}
+	if lv.opts != nil {
+		return nil, validationError("cannot clone or snapshot a volume with mount options")
+	}
+	if lv.encryption != nil {
+		return nil, validationError("cannot clone or snapshot an encrypted volume")
+	}
+	return lv, nil
+}
+
+// snapshotPath returns the path of the snapshot of the volume with the given
+// name.
+func (v *localVolume) snapshotPath(name string) string {
+	return filepath.Join(v.snapshotsPath, name)
+}
+
+// snapshots returns the names of the snapshots of the volume.
+func (v *localVolume) snapshots() []string {
+	entries, err := ioutil.ReadDir(v.snapshotsPath)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
 func validateOpts(opts map[string]string) error {
 	for opt := range opts {
 		if !validOpts[opt] {
@@ -362,7 +720,35 @@ func validateOpts(opts map[string]string) error {
 }
 
 func (v *localVolume) Status() map[string]interface{} {
-	return nil
+	if v.encryption != nil {
+		return map[string]interface{}{"Encrypted": true, "Size": v.encryption.Size}
+	}
+	var status map[string]interface{}
+	if snapshots := v.snapshots(); len(snapshots) > 0 {
+		status = map[string]interface{}{"Snapshots": snapshots}
+	}
+	if v.quotaCtl == nil {
+		return status
+	}
+
+	// Only the volumes created with the size option have a quota, and
+	// their disk usage is accounted by the filesystem.
+	dir := filepath.Dir(v.path)
+	var q quota.Quota
+	if err := v.quotaCtl.GetQuota(dir, &q); err != nil || q.Size == 0 {
+		return status
+	}
+	usage, err := v.quotaCtl.GetUsage(dir)
+	if err != nil {
+		logrus.WithError(err).Warnf("failed to get the disk usage of volume %s", v.name)
+		return status
+	}
+	if status == nil {
+		status = make(map[string]interface{})
+	}
+	status["Quota"] = q.Size
+	status["Usage"] = usage
+	return status
 }
 
 // getAddress finds out address/hostname from options
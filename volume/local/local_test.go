@@ -11,9 +11,15 @@ import (
 
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/docker/pkg/reexec"
+	"github.com/docker/docker/volume"
 	"github.com/gotestyourself/gotestyourself/skip"
 )
 
+func init() {
+	reexec.Init()
+}
+
 func TestGetAddress(t *testing.T) {
 	cases := map[string]string{
 		"addr=11.11.11.1":   "11.11.11.1",
@@ -333,3 +339,232 @@ func TestRelaodNoOpts(t *testing.T) {
 		}
 	}
 }
+
+func TestExportImport(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, idtools.IDPair{UID: os.Geteuid(), GID: os.Getegid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := r.Create("source", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(src.Path(), "dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src.Path(), "dir", "file"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dst, err := r.Create("destination", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dst.Path(), "stale"), []byte("stale"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rc, err := src.(volume.ArchiveVolume).Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+
+	if err := dst.(volume.ArchiveVolume).Import(rc); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dst.Path(), "dir", "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "data" {
+		t.Fatalf("expected imported file content to be %q, got %q", "data", data)
+	}
+	if _, err := os.Stat(filepath.Join(dst.Path(), "stale")); !os.IsNotExist(err) {
+		t.Fatalf("expected stale file to be removed by the import, got %v", err)
+	}
+}
+
+func TestImportInvalidArchive(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, idtools.IDPair{UID: os.Geteuid(), GID: os.Getegid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := r.Create("test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(v.Path(), "file"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.(volume.ArchiveVolume).Import(strings.NewReader("not a tar archive")); err == nil {
+		t.Fatal("expected import of an invalid archive to fail")
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(v.Path(), "file"))
+	if err != nil {
+		t.Fatalf("expected the data of the volume to be kept, got %v", err)
+	}
+	if string(data) != "data" {
+		t.Fatalf("expected file content to be %q, got %q", "data", data)
+	}
+	entries, err := ioutil.ReadDir(filepath.Dir(v.Path()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected the temporary directory of the import to be removed, got %d entries", len(entries))
+	}
+}
+
+func TestCreateWithSize(t *testing.T) {
+	skip.If(t, runtime.GOOS == "windows")
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, idtools.IDPair{UID: os.Getuid(), GID: os.Getegid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.Create("test", map[string]string{"size": "invalid"}); err == nil || !strings.Contains(err.Error(), "invalid size") {
+		t.Fatalf("expected invalid size to cause error, got %v", err)
+	}
+	if _, err := r.Create("test", map[string]string{"size": "1m", "type": "tmpfs"}); err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Fatalf("expected size with mount options to cause error, got %v", err)
+	}
+
+	vol, err := r.Create("test", map[string]string{"size": "1m"})
+	if r.quotaCtl == nil {
+		if err == nil || !strings.Contains(err.Error(), "requires a filesystem with project quotas") {
+			t.Fatalf("expected size without project quotas to cause error, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(r.path, "test")); !os.IsNotExist(err) {
+			t.Fatalf("expected the volume directory not to be created, got %v", err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := vol.(*localVolume)
+	if v.opts != nil {
+		t.Fatalf("expected no mount options, got %v", v.opts)
+	}
+	status := v.Status()
+	if status["Quota"] != uint64(1024*1024) {
+		t.Fatalf("expected a quota of 1m, got %v", status)
+	}
+	if _, ok := status["Usage"].(uint64); !ok {
+		t.Fatalf("expected the usage of the volume, got %v", status)
+	}
+}
+
+func TestCloneAndSnapshot(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, idtools.IDPair{UID: os.Geteuid(), GID: os.Getegid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := r.Create("source", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src.Path(), "file"), []byte("seed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Snapshot(src, "seed"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Snapshot(src, "seed"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected a second snapshot with the same name to fail, got %v", err)
+	}
+	if err := r.Snapshot(src, "../escape"); err == nil {
+		t.Fatal("expected an invalid snapshot name to fail")
+	}
+	if snapshots := src.Status()["Snapshots"]; !reflect.DeepEqual(snapshots, []string{"seed"}) {
+		t.Fatalf("expected the seed snapshot in the status of the volume, got %v", snapshots)
+	}
+	if _, err := os.Stat(filepath.Join(rootDir, snapshotsPathName, "source", "seed", "file")); err != nil {
+		t.Fatalf("expected the snapshot to be stored outside of the volume directory, got %v", err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(src.Path(), "file"), []byte("modified"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := r.Clone(src, "", "clone", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromSnapshot, err := r.Clone(src, "seed", "from-snapshot", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Clone(src, "", "clone", nil); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected a clone to an existing volume to fail, got %v", err)
+	}
+	if _, err := r.Clone(src, "missing", "other", nil); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Fatalf("expected a clone of a missing snapshot to fail, got %v", err)
+	}
+
+	for path, expected := range map[string]string{
+		filepath.Join(clone.Path(), "file"):        "modified",
+		filepath.Join(fromSnapshot.Path(), "file"): "seed",
+	} {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != expected {
+			t.Fatalf("expected %s to contain %q, got %q", path, expected, data)
+		}
+	}
+
+	if err := r.RemoveSnapshot(src, "seed"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RemoveSnapshot(src, "seed"); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Fatalf("expected removing a missing snapshot to fail, got %v", err)
+	}
+	if status := src.Status(); status != nil {
+		t.Fatalf("expected no status after removing the snapshot, got %v", status)
+	}
+
+	if err := r.Snapshot(src, "seed"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Remove(src); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(rootDir, snapshotsPathName, "source")); !os.IsNotExist(err) {
+		t.Fatalf("expected the snapshots to be removed with the volume, got %v", err)
+	}
+}
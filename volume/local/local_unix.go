@@ -10,6 +10,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -17,15 +18,18 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/docker/docker/pkg/mount"
+	units "github.com/docker/go-units"
 )
 
 var (
 	oldVfsDir = filepath.Join("vfs", "dir")
 
 	validOpts = map[string]bool{
-		"type":   true, // specify the filesystem type for mount, e.g. nfs
-		"o":      true, // generic mount options
-		"device": true, // device to mount from
+		"type":    true, // specify the filesystem type for mount, e.g. nfs
+		"o":       true, // generic mount options
+		"device":  true, // device to mount from
+		"size":    true, // quota of the volume, cannot be combined with mount options
+		"encrypt": true, // encrypt the volume, with a filesystem of the given size
 	}
 )
 
@@ -63,6 +67,22 @@ func setOpts(v *localVolume, opts map[string]string) error {
 		return err
 	}
 
+	if _, ok := opts["encrypt"]; ok {
+		return setEncryptionOpts(v, opts)
+	}
+
+	if size, ok := opts["size"]; ok {
+		if len(opts) > 1 {
+			return validationError("the size option cannot be combined with mount options")
+		}
+		sz, err := units.RAMInBytes(size)
+		if err != nil || sz <= 0 {
+			return validationError(fmt.Sprintf("invalid size: %q", size))
+		}
+		v.quota.Size = uint64(sz)
+		return nil
+	}
+
 	v.opts = &optsConfig{
 		MountType:   opts["type"],
 		MountOpts:   opts["o"],
@@ -71,7 +91,38 @@ func setOpts(v *localVolume, opts map[string]string) error {
 	return nil
 }
 
+// setEncryptionOpts sets the encryption of the volume from the encrypt and
+// size options, which cannot be combined with mount options.
+func setEncryptionOpts(v *localVolume, opts map[string]string) error {
+	encrypt, err := strconv.ParseBool(opts["encrypt"])
+	if err != nil {
+		return validationError(fmt.Sprintf("invalid encrypt option: %q", opts["encrypt"]))
+	}
+	if !encrypt {
+		rest := make(map[string]string, len(opts)-1)
+		for k, v := range opts {
+			if k != "encrypt" {
+				rest[k] = v
+			}
+		}
+		return setOpts(v, rest)
+	}
+	size, ok := opts["size"]
+	if !ok || len(opts) > 2 {
+		return validationError("the encrypt option requires the size option, and cannot be combined with mount options")
+	}
+	sz, err := units.RAMInBytes(size)
+	if err != nil || sz <= 0 {
+		return validationError(fmt.Sprintf("invalid size: %q", size))
+	}
+	v.encryption = &encryptionConfig{Size: sz}
+	return nil
+}
+
 func (v *localVolume) mount() error {
+	if v.encryption != nil {
+		return v.mountEncrypted()
+	}
 	if v.opts.MountDevice == "" {
 		return fmt.Errorf("missing device in volume options")
 	}
@@ -0,0 +1,86 @@
+// +build linux freebsd
+
+package local // import "github.com/docker/docker/volume/local"
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/docker/docker/pkg/idtools"
+	"github.com/docker/docker/volume"
+	"github.com/gotestyourself/gotestyourself/skip"
+)
+
+func TestExportImportIDMappings(t *testing.T) {
+	skip.If(t, os.Getuid() != 0, "requires root")
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	idMaps := []idtools.IDMap{{ContainerID: 0, HostID: 100000, Size: 65536}}
+	r, err := New(rootDir, idtools.IDPair{UID: 100000, GID: 100000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.SetIDMappings(idtools.NewIDMappingsFromMaps(idMaps, idMaps))
+
+	v, err := r.Create("test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(v.Path(), "file")
+	if err := ioutil.WriteFile(file, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Lchown(file, 101000, 101000); err != nil {
+		t.Fatal(err)
+	}
+
+	rc, err := v.(volume.ArchiveVolume).Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr := tar.NewReader(bytes.NewReader(buf.Bytes()))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			t.Fatal("expected file in the exported archive")
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Name == "file" {
+			if hdr.Uid != 1000 || hdr.Gid != 1000 {
+				t.Fatalf("expected the exported owner to be 1000:1000, got %d:%d", hdr.Uid, hdr.Gid)
+			}
+			break
+		}
+	}
+
+	if err := v.(volume.ArchiveVolume).Import(&buf); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Lstat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := fi.Sys().(*syscall.Stat_t)
+	if st.Uid != 101000 || st.Gid != 101000 {
+		t.Fatalf("expected the imported owner to be 101000:101000, got %d:%d", st.Uid, st.Gid)
+	}
+}
@@ -0,0 +1,52 @@
+package mounts // import "github.com/docker/docker/volume/mounts"
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/pkg/symlink"
+	"github.com/pkg/errors"
+)
+
+// ImageLayer is the root filesystem of an image, mounted in a container by a
+// mount point of type image.
+type ImageLayer interface {
+	// Mount mounts the root filesystem with the given mount label, and
+	// returns its path.
+	Mount(mountLabel string) (string, error)
+	// Unmount unmounts the root filesystem.
+	Unmount() error
+}
+
+// validateImageSubpath validates the subpath of the root filesystem of an
+// image to mount. It must be relative, and stay within the root filesystem.
+func validateImageSubpath(subpath string) error {
+	if path.IsAbs(filepath.ToSlash(subpath)) {
+		return fmt.Errorf("invalid subpath %q: must be relative to the root of the image", subpath)
+	}
+	if p := path.Clean(filepath.ToSlash(subpath)); p == ".." || strings.HasPrefix(p, "../") {
+		return fmt.Errorf("invalid subpath %q: must not be outside of the image", subpath)
+	}
+	return nil
+}
+
+// imageSubpath returns the path, in the root filesystem of the image mounted
+// at root, of the subpath of the mount point. Symlinks are resolved within
+// the root filesystem.
+func (m *MountPoint) imageSubpath(root string) (string, error) {
+	if m.Spec.ImageOptions == nil || m.Spec.ImageOptions.Subpath == "" {
+		return root, nil
+	}
+	subpath := m.Spec.ImageOptions.Subpath
+	p, err := symlink.FollowSymlinkInScope(filepath.Join(root, subpath), root)
+	if err != nil {
+		return "", errors.Wrapf(err, "error while resolving subpath %q of image '%s'", subpath, m.Spec.Source)
+	}
+	if _, err := os.Stat(p); err != nil {
+		return "", errors.Wrapf(err, "error while resolving subpath %q of image '%s'", subpath, m.Spec.Source)
+	}
+	return p, nil
+}
@@ -0,0 +1,117 @@
+// +build !windows
+
+package mounts // import "github.com/docker/docker/volume/mounts"
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/pkg/idtools"
+)
+
+type fakeImageLayer struct {
+	root    string
+	mounted int
+}
+
+func (l *fakeImageLayer) Mount(mountLabel string) (string, error) {
+	l.mounted++
+	return l.root, nil
+}
+
+func (l *fakeImageLayer) Unmount() error {
+	if l.mounted == 0 {
+		return errors.New("not mounted")
+	}
+	l.mounted--
+	return nil
+}
+
+func TestValidateImageMount(t *testing.T) {
+	parser := NewParser("linux")
+	cases := []struct {
+		input    mount.Mount
+		expected error
+	}{
+		{mount.Mount{Type: mount.TypeImage, Target: "/foo", Source: "busybox"}, nil},
+		{mount.Mount{Type: mount.TypeImage, Target: "/foo", Source: "busybox", ImageOptions: &mount.ImageOptions{Subpath: "bin/../etc"}}, nil},
+		{mount.Mount{Type: mount.TypeImage, Target: "/foo"}, errMissingField("Source")},
+		{mount.Mount{Type: mount.TypeImage, Target: "/foo", Source: "busybox", VolumeOptions: &mount.VolumeOptions{}}, errExtraField("VolumeOptions")},
+		{mount.Mount{Type: mount.TypeImage, Target: "/foo", Source: "busybox", ImageOptions: &mount.ImageOptions{Subpath: "/etc"}}, fmt.Errorf("must be relative to the root of the image")},
+		{mount.Mount{Type: mount.TypeImage, Target: "/foo", Source: "busybox", ImageOptions: &mount.ImageOptions{Subpath: "etc/../.."}}, fmt.Errorf("must not be outside of the image")},
+		{mount.Mount{Type: mount.TypeVolume, Target: "/foo", ImageOptions: &mount.ImageOptions{}}, errExtraField("ImageOptions")},
+	}
+	for i, x := range cases {
+		err := parser.ValidateMountConfig(&x.input)
+		if err == nil && x.expected == nil {
+			continue
+		}
+		if (err == nil && x.expected != nil) || (x.expected == nil && err != nil) || !strings.Contains(err.Error(), x.expected.Error()) {
+			t.Errorf("expected %q, got %q, case: %d", x.expected, err, i)
+		}
+	}
+
+	mp, err := parser.ParseMountSpec(mount.Mount{Type: mount.TypeImage, Target: "/foo/", Source: "busybox"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mp.Name != "busybox" || mp.Destination != "/foo" || mp.RW || mp.Propagation != parser.DefaultPropagationMode() {
+		t.Fatalf("expected a read-only mount point of the image, got %+v", mp)
+	}
+}
+
+func TestSetupImageMount(t *testing.T) {
+	root, err := ioutil.TempDir("", "test-image-mount")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	if err := os.MkdirAll(filepath.Join(root, "etc", "app"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("/etc/app", filepath.Join(root, "config")); err != nil {
+		t.Fatal(err)
+	}
+
+	for subpath, expected := range map[string]string{
+		"":       root,
+		"etc":    filepath.Join(root, "etc"),
+		"config": filepath.Join(root, "etc", "app"),
+	} {
+		l := &fakeImageLayer{root: root}
+		m := &MountPoint{
+			Type:  mount.TypeImage,
+			Image: l,
+			Spec:  mount.Mount{Type: mount.TypeImage, Source: "busybox", ImageOptions: &mount.ImageOptions{Subpath: subpath}},
+		}
+		path, err := m.Setup("", idtools.IDPair{}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if path != expected || l.mounted != 1 {
+			t.Fatalf("expected subpath %q to be mounted from %s, got %s (mounted %d times)", subpath, expected, path, l.mounted)
+		}
+		if err := m.Cleanup(); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.Cleanup(); err != nil || l.mounted != 0 {
+			t.Fatalf("expected the image to be unmounted once, got %d mounts: %v", l.mounted, err)
+		}
+	}
+
+	l := &fakeImageLayer{root: root}
+	m := &MountPoint{
+		Type:  mount.TypeImage,
+		Image: l,
+		Spec:  mount.Mount{Type: mount.TypeImage, Source: "busybox", ImageOptions: &mount.ImageOptions{Subpath: "missing"}},
+	}
+	if _, err := m.Setup("", idtools.IDPair{}, nil); err == nil || l.mounted != 0 {
+		t.Fatalf("expected a missing subpath to cause error and unmount the image, got %d mounts: %v", l.mounted, err)
+	}
+}
@@ -76,6 +76,9 @@ func (p *linuxParser) validateMountConfigImpl(mnt *mount.Mount, validateBindSour
 		if mnt.VolumeOptions != nil {
 			return &errMountConfig{mnt, errExtraField("VolumeOptions")}
 		}
+		if mnt.ImageOptions != nil {
+			return &errMountConfig{mnt, errExtraField("ImageOptions")}
+		}
 
 		if err := linuxValidateAbsolute(mnt.Source); err != nil {
 			return &errMountConfig{mnt, err}
@@ -92,10 +95,17 @@ func (p *linuxParser) validateMountConfigImpl(mnt *mount.Mount, validateBindSour
 		if mnt.BindOptions != nil {
 			return &errMountConfig{mnt, errExtraField("BindOptions")}
 		}
+		if mnt.ImageOptions != nil {
+			return &errMountConfig{mnt, errExtraField("ImageOptions")}
+		}
 
 		if len(mnt.Source) == 0 && mnt.ReadOnly {
 			return &errMountConfig{mnt, fmt.Errorf("must not set ReadOnly mode when using anonymous volumes")}
 		}
+
+		if err := validateOwnership(mnt.VolumeOptions); err != nil {
+			return &errMountConfig{mnt, err}
+		}
 	case mount.TypeTmpfs:
 		if len(mnt.Source) != 0 {
 			return &errMountConfig{mnt, errExtraField("Source")}
@@ -103,6 +113,24 @@ func (p *linuxParser) validateMountConfigImpl(mnt *mount.Mount, validateBindSour
 		if _, err := p.ConvertTmpfsOptions(mnt.TmpfsOptions, mnt.ReadOnly); err != nil {
 			return &errMountConfig{mnt, err}
 		}
+	case mount.TypeImage:
+		if len(mnt.Source) == 0 {
+			return &errMountConfig{mnt, errMissingField("Source")}
+		}
+		if mnt.BindOptions != nil {
+			return &errMountConfig{mnt, errExtraField("BindOptions")}
+		}
+		if mnt.VolumeOptions != nil {
+			return &errMountConfig{mnt, errExtraField("VolumeOptions")}
+		}
+		if mnt.TmpfsOptions != nil {
+			return &errMountConfig{mnt, errExtraField("TmpfsOptions")}
+		}
+		if opts := mnt.ImageOptions; opts != nil {
+			if err := validateImageSubpath(opts.Subpath); err != nil {
+				return &errMountConfig{mnt, err}
+			}
+		}
 	default:
 		return &errMountConfig{mnt, errors.New("mount type unknown")}
 	}
@@ -167,8 +195,13 @@ func linuxValidMountMode(mode string) bool {
 	propagationModeCount := 0
 	copyModeCount := 0
 	consistencyModeCount := 0
+	ownershipModeCount := make(map[string]int)
 
 	for _, o := range strings.Split(mode, ",") {
+		if name, ok := ownershipModeName(o); ok {
+			ownershipModeCount[name]++
+			continue
+		}
 		switch {
 		case rwModes[o]:
 			rwModeCount++
@@ -189,6 +222,11 @@ func linuxValidMountMode(mode string) bool {
 	if rwModeCount > 1 || labelModeCount > 1 || propagationModeCount > 1 || copyModeCount > 1 || consistencyModeCount > 1 {
 		return false
 	}
+	for _, count := range ownershipModeCount {
+		if count > 1 {
+			return false
+		}
+	}
 	return true
 }
 
@@ -260,6 +298,12 @@ func (p *linuxParser) ParseMountRaw(raw, volumeDriver string) (*MountPoint, erro
 		}
 		spec.VolumeOptions.NoCopy = !copyData
 	}
+	if hasOwnershipModes(mode) {
+		if spec.VolumeOptions == nil {
+			spec.VolumeOptions = &mount.VolumeOptions{}
+		}
+		getOwnershipModes(mode, spec.VolumeOptions)
+	}
 	if linuxHasPropagation(mode) {
 		spec.BindOptions = &mount.BindOptions{
 			Propagation: linuxGetPropagation(mode),
@@ -317,6 +361,11 @@ func (p *linuxParser) parseMountSpec(cfg mount.Mount, validateBindSourceExists b
 		}
 	case mount.TypeTmpfs:
 		// NOP
+	case mount.TypeImage:
+		// The root filesystem of the image is always mounted read-only
+		mp.Name = cfg.Source
+		mp.RW = false
+		mp.Propagation = linuxDefaultPropagationMode
 	}
 	return mp, nil
 }
@@ -346,6 +395,10 @@ func (p *linuxParser) ParseVolumesFrom(spec string) (string, string, error) {
 		if _, isSet := getCopyMode(mode, p.DefaultCopyMode()); isSet {
 			return "", "", errInvalidMode(mode)
 		}
+		// Nor ownership modes, the volumes are already in use
+		if hasOwnershipModes(mode) {
+			return "", "", errInvalidMode(mode)
+		}
 	}
 	return id, mode, nil
 }
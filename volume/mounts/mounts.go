@@ -12,6 +12,7 @@ import (
 	"github.com/docker/docker/volume"
 	"github.com/opencontainers/selinux/go-selinux/label"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 // MountPoint is the intersection point between a volume and a container. It
@@ -39,6 +40,9 @@ type MountPoint struct {
 	// Volume is the volume providing data to this mountpoint.
 	// This is nil unless `Type` is set to `TypeVolume`
 	Volume volume.Volume `json:"-"`
+	// Image is the root filesystem of the image providing data to this mountpoint.
+	// This is nil unless `Type` is set to `TypeImage`
+	Image ImageLayer `json:"-"`
 
 	// Mode is the comma separated list of options supplied by the user when creating
 	// the bind/volume mount.
@@ -70,6 +74,17 @@ type MountPoint struct {
 
 // Cleanup frees resources used by the mountpoint
 func (m *MountPoint) Cleanup() error {
+	if m.Image != nil {
+		if m.active == 0 {
+			return nil
+		}
+		if err := m.Image.Unmount(); err != nil {
+			return errors.Wrapf(err, "error unmounting image %s", m.Spec.Source)
+		}
+		m.active--
+		return nil
+	}
+
 	if m.Volume == nil || m.ID == "" {
 		return nil
 	}
@@ -85,8 +100,8 @@ func (m *MountPoint) Cleanup() error {
 	return nil
 }
 
-// Setup sets up a mount point by either mounting the volume if it is
-// configured, or creating the source directory if supplied.
+// Setup sets up a mount point by either mounting the volume or the image if
+// it is configured, or creating the source directory if supplied.
 // The, optional, checkFun parameter allows doing additional checking
 // before creating the source directory on the host.
 func (m *MountPoint) Setup(mountLabel string, rootIDs idtools.IDPair, checkFun func(m *MountPoint) error) (path string, err error) {
@@ -127,6 +142,22 @@ func (m *MountPoint) Setup(mountLabel string, rootIDs idtools.IDPair, checkFun f
 		return path, nil
 	}
 
+	if m.Image != nil {
+		root, err := m.Image.Mount(mountLabel)
+		if err != nil {
+			return "", errors.Wrapf(err, "error while mounting image '%s'", m.Spec.Source)
+		}
+		m.active++
+		path, err := m.imageSubpath(root)
+		if err != nil {
+			if cErr := m.Cleanup(); cErr != nil {
+				logrus.WithError(cErr).Warnf("failed to unmount image %s", m.Spec.Source)
+			}
+			return "", err
+		}
+		return path, nil
+	}
+
 	if len(m.Source) == 0 {
 		return "", fmt.Errorf("Unable to setup mount point, neither source nor volume defined")
 	}
@@ -256,6 +256,9 @@ func TestParseMountRaw(t *testing.T) {
 			"/hostPath:/containerPath:rslave,ro,Z",
 			"/hostPath:/containerPath:ro,rshared,Z",
 			"/hostPath:/containerPath:ro,Z,rprivate",
+			"name:/containerPath:uid=1000",
+			"name:/containerPath:uid=1000,gid=1000,mode=0750",
+			"name:/containerPath:ro,nocopy,gid=0,mode=2775",
 		},
 		invalid: map[string]string{
 			"":                                "invalid volume specification",
@@ -290,6 +293,12 @@ func TestParseMountRaw(t *testing.T) {
 			"name:/absolute-path:rslave":      "invalid volume specification",
 			"name:/absolute-path:private":     "invalid volume specification",
 			"name:/absolute-path:rprivate":    "invalid volume specification",
+			"name:/path:uid=-1":               `invalid mode`,
+			"name:/path:uid=1000,uid=0":       `invalid mode`,
+			"name:/path:mode=0800":            `invalid mode`,
+			"name:/path:mode=17777":           `invalid mode`,
+			"name:uid=1000":                   "invalid volume specification",
+			"/path:/path:uid=1000":            "field VolumeOptions must not be specified",
 		},
 	}
 
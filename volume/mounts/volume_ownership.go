@@ -0,0 +1,141 @@
+package mounts // import "github.com/docker/docker/volume/mounts"
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/mount"
+)
+
+// ownershipModes are the names of the options of a mount mode string which
+// set the owner and the permissions of a volume, such as uid=1000.
+var ownershipModes = map[string]bool{
+	"uid":  true,
+	"gid":  true,
+	"mode": true,
+}
+
+// permMask is the mask of the permissions which can be set on a volume.
+const permMask = os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky
+
+// ownershipModeName returns the name of the ownership option o, and whether
+// o is an ownership option with a valid value.
+func ownershipModeName(o string) (string, bool) {
+	kv := strings.SplitN(o, "=", 2)
+	if len(kv) != 2 || !ownershipModes[kv[0]] {
+		return "", false
+	}
+	if kv[0] == "mode" {
+		_, err := parsePerm(kv[1])
+		return kv[0], err == nil
+	}
+	_, err := parseID(kv[1])
+	return kv[0], err == nil
+}
+
+// getOwnershipModes sets the owner and the permissions of the volume options
+// opts from the ownership options of the mode string, which must be valid.
+// It returns whether any ownership option is set.
+func getOwnershipModes(mode string, opts *mount.VolumeOptions) bool {
+	var isSet bool
+	for _, o := range strings.Split(mode, ",") {
+		name, ok := ownershipModeName(o)
+		if !ok {
+			continue
+		}
+		value := o[len(name)+1:]
+		switch name {
+		case "uid":
+			uid, _ := parseID(value)
+			opts.UID = &uid
+		case "gid":
+			gid, _ := parseID(value)
+			opts.GID = &gid
+		case "mode":
+			opts.Mode, _ = parsePerm(value)
+		}
+		isSet = true
+	}
+	return isSet
+}
+
+func hasOwnershipModes(mode string) bool {
+	for _, o := range strings.Split(mode, ",") {
+		if _, ok := ownershipModeName(o); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func parseID(value string) (int, error) {
+	id, err := strconv.ParseUint(value, 10, 31)
+	return int(id), err
+}
+
+// parsePerm parses the octal permissions value, which may include the
+// setuid, setgid and sticky bits.
+func parsePerm(value string) (os.FileMode, error) {
+	v, err := strconv.ParseUint(value, 8, 32)
+	if err != nil {
+		return 0, err
+	}
+	if v&^07777 != 0 {
+		return 0, fmt.Errorf("invalid permissions: %s", value)
+	}
+	perm := os.FileMode(v) & os.ModePerm
+	if v&04000 != 0 {
+		perm |= os.ModeSetuid
+	}
+	if v&02000 != 0 {
+		perm |= os.ModeSetgid
+	}
+	if v&01000 != 0 {
+		perm |= os.ModeSticky
+	}
+	return perm, nil
+}
+
+// validateOwnership checks the owner and the permissions of the volume
+// options opts, if any.
+func validateOwnership(opts *mount.VolumeOptions) error {
+	if opts == nil {
+		return nil
+	}
+	if opts.UID != nil && *opts.UID < 0 {
+		return fmt.Errorf("invalid UID: %d", *opts.UID)
+	}
+	if opts.GID != nil && *opts.GID < 0 {
+		return fmt.Errorf("invalid GID: %d", *opts.GID)
+	}
+	if opts.Mode&^permMask != 0 {
+		return fmt.Errorf("invalid mode: %s", opts.Mode)
+	}
+	return nil
+}
+
+// hasOwnership returns whether the volume options opts set the owner or the
+// permissions of the volume.
+func hasOwnership(opts *mount.VolumeOptions) bool {
+	return opts != nil && (opts.UID != nil || opts.GID != nil || opts.Mode != 0)
+}
+
+// Ownership returns the owner and the permissions requested for the root of
+// the volume of the mount point, and of the data copied to it from the image.
+// The uid and gid are -1, and perm is 0, if they are not set.
+func (m *MountPoint) Ownership() (uid, gid int, perm os.FileMode) {
+	uid, gid = -1, -1
+	opts := m.Spec.VolumeOptions
+	if m.Type != mount.TypeVolume || opts == nil {
+		return uid, gid, 0
+	}
+	if opts.UID != nil {
+		uid = *opts.UID
+	}
+	if opts.GID != nil {
+		gid = *opts.GID
+	}
+	return uid, gid, opts.Mode
+}
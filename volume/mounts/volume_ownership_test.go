@@ -0,0 +1,74 @@
+package mounts // import "github.com/docker/docker/volume/mounts"
+
+import (
+	"os"
+	"testing"
+
+	"github.com/docker/docker/api/types/mount"
+)
+
+func TestParseMountRawOwnership(t *testing.T) {
+	p := &linuxParser{}
+
+	m, err := p.ParseMountRaw("name:/data:uid=1000,gid=0,mode=2750", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uid, gid, perm := m.Ownership()
+	if uid != 1000 || gid != 0 || perm != os.ModeSetgid|0750 {
+		t.Fatalf("expected 1000:0 %v, got %d:%d %v", os.ModeSetgid|0750, uid, gid, perm)
+	}
+
+	m, err = p.ParseMountRaw("name:/data:gid=1000", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uid, gid, perm = m.Ownership()
+	if uid != -1 || gid != 1000 || perm != 0 {
+		t.Fatalf("expected -1:1000 with no permissions, got %d:%d %v", uid, gid, perm)
+	}
+
+	m, err = p.ParseMountRaw("name:/data", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uid, gid, perm = m.Ownership()
+	if uid != -1 || gid != -1 || perm != 0 {
+		t.Fatalf("expected no ownership, got %d:%d %v", uid, gid, perm)
+	}
+
+	if _, _, err := p.ParseVolumesFrom("container:ro,uid=1000"); err == nil {
+		t.Fatal("expected an error for an ownership mode with volumes-from")
+	}
+}
+
+func TestValidateMountOwnership(t *testing.T) {
+	uid, negative := 1000, -1
+	cases := []struct {
+		opts  *mount.VolumeOptions
+		valid bool
+	}{
+		{&mount.VolumeOptions{UID: &uid, GID: &uid, Mode: 0750}, true},
+		{&mount.VolumeOptions{Mode: os.ModeSticky | 0777}, true},
+		{&mount.VolumeOptions{UID: &negative}, false},
+		{&mount.VolumeOptions{GID: &negative}, false},
+		{&mount.VolumeOptions{Mode: os.ModeDir | 0755}, false},
+	}
+
+	p := &linuxParser{}
+	for _, c := range cases {
+		err := p.ValidateMountConfig(&mount.Mount{Type: mount.TypeVolume, Target: "/data", VolumeOptions: c.opts})
+		if c.valid && err != nil {
+			t.Errorf("expected %+v to be valid, got %v", c.opts, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("expected %+v to be invalid", c.opts)
+		}
+	}
+
+	w := &windowsParser{}
+	err := w.ValidateMountConfig(&mount.Mount{Type: mount.TypeVolume, Target: `c:\data`, VolumeOptions: &mount.VolumeOptions{UID: &uid}})
+	if err == nil {
+		t.Error("expected ownership options to be invalid on Windows")
+	}
+}
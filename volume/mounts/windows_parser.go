@@ -272,6 +272,10 @@ func (p *windowsParser) validateMountConfigReg(mnt *mount.Mount, destRegex strin
 				return &errMountConfig{mnt, err}
 			}
 		}
+
+		if hasOwnership(mnt.VolumeOptions) {
+			return &errMountConfig{mnt, fmt.Errorf("UID, GID and Mode volume options are not supported on Windows")}
+		}
 	case mount.TypeNamedPipe:
 		if len(mnt.Source) == 0 {
 			return &errMountConfig{mnt, errMissingField("Source")}
@@ -0,0 +1,105 @@
+package store // import "github.com/docker/docker/volume/store"
+
+import (
+	"runtime"
+
+	"github.com/docker/docker/volume"
+	volumemounts "github.com/docker/docker/volume/mounts"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Clone creates a volume with the given name as a copy of the volume src, or
+// of its snapshot with the given name if snapshot is not empty. The volume is
+// created by the driver of src, which must support clones.
+func (s *VolumeStore) Clone(src volume.Volume, snapshot, name string, opts, labels map[string]string) (volume.Volume, error) {
+	name = normalizeVolumeName(name)
+	s.locks.Lock(name)
+	defer s.locks.Unlock(name)
+
+	v, err := s.clone(src, snapshot, name, opts, labels)
+	if err != nil {
+		if _, ok := err.(*OpErr); ok {
+			return nil, err
+		}
+		return nil, &OpErr{Err: err, Name: name, Op: "clone"}
+	}
+
+	s.setNamed(v, "")
+	return v, nil
+}
+
+// clone is the implementation of Clone.
+// It is expected that callers of this function hold the name lock.
+func (s *VolumeStore) clone(src volume.Volume, snapshot, name string, opts, labels map[string]string) (volume.Volume, error) {
+	parser := volumemounts.NewParser(runtime.GOOS)
+	if err := parser.ValidateVolumeName(name); err != nil {
+		return nil, err
+	}
+
+	driverName := src.DriverName()
+	v, err := s.checkConflict(name, driverName)
+	if err != nil {
+		return nil, err
+	}
+	if v != nil {
+		return nil, errors.Wrapf(errNameConflict, "volume '%s' already exists", name)
+	}
+
+	vd, err := s.drivers.CreateDriver(driverName)
+	if err != nil {
+		return nil, &OpErr{Op: "clone", Name: name, Err: err}
+	}
+
+	cd, ok := vd.(volume.CloneDriver)
+	if ok {
+		logrus.Debugf("Registering new volume reference: driver %q, name %q, cloned from %q", vd.Name(), name, src.Name())
+		v, err = cd.Clone(unwrapVolume(src), snapshot, name, opts)
+	} else {
+		err = volume.ErrCloneNotSupported
+	}
+	if err != nil {
+		if _, err := s.drivers.ReleaseDriver(driverName); err != nil {
+			logrus.WithError(err).WithField("driver", driverName).Error("Error releasing reference to volume driver")
+		}
+		return nil, err
+	}
+
+	return s.register(name, "", vd, v, labels, opts)
+}
+
+// Snapshot saves a snapshot with the given name of the volume v, whose driver
+// must support clones.
+func (s *VolumeStore) Snapshot(v volume.Volume, snapshot string) error {
+	return s.withCloneDriver(v, "snapshot", func(cd volume.CloneDriver, vol volume.Volume) error {
+		return cd.Snapshot(vol, snapshot)
+	})
+}
+
+// RemoveSnapshot removes the snapshot with the given name of the volume v.
+func (s *VolumeStore) RemoveSnapshot(v volume.Volume, snapshot string) error {
+	return s.withCloneDriver(v, "remove snapshot", func(cd volume.CloneDriver, vol volume.Volume) error {
+		return cd.RemoveSnapshot(vol, snapshot)
+	})
+}
+
+// withCloneDriver calls f with the driver of the volume v and the unwrapped
+// volume while holding the name lock of the volume.
+func (s *VolumeStore) withCloneDriver(v volume.Volume, op string, f func(volume.CloneDriver, volume.Volume) error) error {
+	name := normalizeVolumeName(v.Name())
+	s.locks.Lock(name)
+	defer s.locks.Unlock(name)
+
+	vd, err := s.drivers.GetDriver(v.DriverName())
+	if err != nil {
+		return &OpErr{Err: err, Name: v.DriverName(), Op: op}
+	}
+	cd, ok := vd.(volume.CloneDriver)
+	if !ok {
+		return &OpErr{Err: volume.ErrCloneNotSupported, Name: name, Op: op}
+	}
+	if err := f(cd, unwrapVolume(v)); err != nil {
+		return &OpErr{Err: err, Name: name, Op: op}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package store // import "github.com/docker/docker/volume/store"
+
+import (
+	"testing"
+
+	"github.com/docker/docker/volume"
+	volumetestutils "github.com/docker/docker/volume/testutils"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+	"github.com/pkg/errors"
+)
+
+type fakeCloneDriver struct {
+	volume.Driver
+	snapshots map[string]bool
+}
+
+func (d *fakeCloneDriver) Clone(src volume.Volume, snapshot, name string, opts map[string]string) (volume.Volume, error) {
+	if snapshot != "" && !d.snapshots[src.Name()+"/"+snapshot] {
+		return nil, errors.Errorf("no snapshot %s", snapshot)
+	}
+	return d.Driver.Create(name, opts)
+}
+
+func (d *fakeCloneDriver) Snapshot(v volume.Volume, name string) error {
+	d.snapshots[v.Name()+"/"+name] = true
+	return nil
+}
+
+func (d *fakeCloneDriver) RemoveSnapshot(v volume.Volume, name string) error {
+	delete(d.snapshots, v.Name()+"/"+name)
+	return nil
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := setupTest(t)
+	defer cleanup()
+	s.drivers.Register(&fakeCloneDriver{Driver: volumetestutils.NewFakeDriver("fake"), snapshots: map[string]bool{}}, "fake")
+	s.drivers.Register(volumetestutils.NewFakeDriver("noop"), "noop")
+
+	src, err := s.Create("src", "fake", nil, nil)
+	assert.NilError(t, err)
+
+	v, err := s.Clone(src, "", "clone", nil, map[string]string{"a": "b"})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(v.DriverName(), "fake"))
+	assert.Check(t, is.DeepEqual(v.(volume.DetailedVolume).Labels(), map[string]string{"a": "b"}))
+
+	_, err = s.Clone(src, "", "clone", nil, nil)
+	assert.Check(t, IsNameConflict(err), "expected a name conflict, got %v", err)
+
+	_, err = s.Clone(src, "snapshot", "fromsnapshot", nil, nil)
+	assert.Check(t, is.ErrorContains(err, "no snapshot snapshot"))
+
+	assert.NilError(t, s.Snapshot(src, "snapshot"))
+	_, err = s.Clone(src, "snapshot", "fromsnapshot", nil, nil)
+	assert.NilError(t, err)
+	assert.NilError(t, s.RemoveSnapshot(src, "snapshot"))
+
+	noop, err := s.Create("noop", "noop", nil, nil)
+	assert.NilError(t, err)
+	_, err = s.Clone(noop, "", "noopclone", nil, nil)
+	assert.Check(t, is.Equal(errors.Cause(err), volume.ErrCloneNotSupported))
+	err = s.Snapshot(noop, "snapshot")
+	assert.Check(t, is.Equal(errors.Cause(err), volume.ErrCloneNotSupported))
+}
@@ -2,6 +2,7 @@ package store // import "github.com/docker/docker/volume/store"
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/boltdb/bolt"
 	"github.com/docker/docker/errdefs"
@@ -16,6 +17,10 @@ type volumeMetadata struct {
 	Driver  string
 	Labels  map[string]string
 	Options map[string]string
+	Usage   Usage
+	// Unreferenced is the time at which the last reference to the volume
+	// was dropped, for the lifecycle policies of the reaper.
+	Unreferenced time.Time
 }
 
 func (s *VolumeStore) setMeta(name string, meta volumeMetadata) error {
@@ -0,0 +1,277 @@
+package store // import "github.com/docker/docker/volume/store"
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/volume"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultReaperInterval is the interval between two runs of the volume
+// reaper if none is configured.
+const defaultReaperInterval = 5 * time.Minute
+
+const (
+	// ReapReasonTTL is the reason of the removal of a volume which was
+	// unreferenced for longer than its time to live.
+	ReapReasonTTL = "ttl"
+	// ReapReasonProjectSize is the reason of the removal of a volume whose
+	// project exceeds its maximum total size.
+	ReapReasonProjectSize = "project-size"
+)
+
+// LifecyclePolicy is the policy applied by the volume reaper.
+type LifecyclePolicy struct {
+	// AnonymousTTL is the duration after which an anonymous volume is
+	// removed once no container references it. Zero keeps anonymous
+	// volumes. A volume with the volume.TTLLabel label uses the duration
+	// of the label instead, whether anonymous or not.
+	AnonymousTTL time.Duration
+	// ProjectLabel is the label whose value groups volumes in projects.
+	ProjectLabel string
+	// ProjectMaxSize is the maximum total size, in bytes, of the volumes of
+	// a project. Unreferenced volumes of a project are removed, least
+	// recently referenced first, until it fits. Zero disables the limit.
+	ProjectMaxSize int64
+	// Interval is the duration between two runs of the reaper.
+	Interval time.Duration
+	// Size returns the size of a volume in bytes, or -1 if unknown.
+	Size func(volume.Volume) int64
+	// Removed is called for each volume removed by the reaper.
+	Removed func(v volume.Volume, reason string)
+}
+
+// reapVolume is the information the reaper needs about a volume to decide
+// whether it can be removed.
+type reapVolume struct {
+	v            volume.Volume
+	inUse        bool
+	unreferenced time.Time // when the last reference was dropped
+	size         int64
+}
+
+// reapCandidate is a volume to remove, with the reason of its removal.
+type reapCandidate struct {
+	v      volume.Volume
+	reason string
+}
+
+// RunReaper removes the volumes selected by the lifecycle policy every
+// policy.Interval, until ctx is done.
+func (s *VolumeStore) RunReaper(ctx context.Context, policy LifecyclePolicy) {
+	interval := policy.Interval
+	if interval <= 0 {
+		interval = defaultReaperInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.hasLifecyclePolicy(policy) {
+				continue
+			}
+			if err := s.reap(ctx, policy); err != nil {
+				logrus.WithError(err).Warn("volume reaper failed")
+			}
+		}
+	}
+}
+
+// hasLifecyclePolicy returns whether the reaper may remove volumes: whether
+// the lifecycle policy sets a time to live or a maximum size, or a volume
+// created through the store has a time to live label. Otherwise, the volumes
+// of the drivers are not listed at all.
+func (s *VolumeStore) hasLifecyclePolicy(policy LifecyclePolicy) bool {
+	if policy.AnonymousTTL > 0 || policy.ProjectMaxSize > 0 {
+		return true
+	}
+	s.globalLock.RLock()
+	defer s.globalLock.RUnlock()
+	for _, labels := range s.labels {
+		if _, ok := labels[volume.TTLLabel]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// reap removes the volumes selected by the lifecycle policy.
+func (s *VolumeStore) reap(ctx context.Context, policy LifecyclePolicy) error {
+	ls, _, err := s.List()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, c := range selectReapCandidates(s.reapVolumes(ls, policy, now), policy, now) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		// The volume may have been referenced since it was selected, in which
+		// case it is not removed.
+		if err := s.Remove(c.v); err != nil {
+			if !IsInUse(err) {
+				logrus.WithError(err).WithField("volume", c.v.Name()).Warn("volume reaper failed to remove volume")
+			}
+			continue
+		}
+		logrus.WithField("volume", c.v.Name()).WithField("reason", c.reason).Info("volume reaper removed volume")
+		if policy.Removed != nil {
+			policy.Removed(c.v, c.reason)
+		}
+	}
+	return nil
+}
+
+// reapVolumes returns the reaper information of the volumes ls. A volume
+// without references whose last reference was never seen dropped, such as
+// after a restart of the daemon, is considered unreferenced from now on.
+func (s *VolumeStore) reapVolumes(ls []volume.Volume, policy LifecyclePolicy, now time.Time) []reapVolume {
+	vols := make([]reapVolume, 0, len(ls))
+	for _, v := range ls {
+		name := v.Name()
+		rv := reapVolume{v: v, size: -1}
+
+		s.locks.Lock(name)
+		s.globalLock.Lock()
+		rv.inUse = len(s.refs[name]) > 0
+		var seen bool
+		if !rv.inUse {
+			rv.unreferenced, seen = s.unreferenced[name]
+			if !seen {
+				rv.unreferenced = now
+				s.unreferenced[name] = now
+			}
+		}
+		s.globalLock.Unlock()
+		if !rv.inUse && !seen {
+			s.setUnreferenced(name, now)
+		}
+		s.locks.Unlock(name)
+
+		if policy.ProjectMaxSize > 0 && policy.Size != nil {
+			if _, ok := volumeLabels(v)[policy.ProjectLabel]; ok {
+				rv.size = policy.Size(v)
+			}
+		}
+		vols = append(vols, rv)
+	}
+	return vols
+}
+
+// selectReapCandidates returns the unreferenced volumes to remove at the
+// time now according to the lifecycle policy: first the volumes past their
+// time to live, then, for each project over its maximum size, its volumes
+// least recently referenced first until the project fits.
+func selectReapCandidates(vols []reapVolume, policy LifecyclePolicy, now time.Time) []reapCandidate {
+	var (
+		candidates []reapCandidate
+		projects   = make(map[string][]reapVolume)
+		sizes      = make(map[string]int64)
+	)
+	for _, rv := range vols {
+		labels := volumeLabels(rv.v)
+		if !rv.inUse {
+			if ttl, ok := volumeTTL(rv.v, labels, policy); ok && now.Sub(rv.unreferenced) >= ttl {
+				candidates = append(candidates, reapCandidate{v: rv.v, reason: ReapReasonTTL})
+				continue
+			}
+		}
+
+		if policy.ProjectMaxSize <= 0 {
+			continue
+		}
+		project, ok := labels[policy.ProjectLabel]
+		if !ok {
+			continue
+		}
+		if rv.size > 0 {
+			sizes[project] += rv.size
+		}
+		projects[project] = append(projects[project], rv)
+	}
+
+	names := make([]string, 0, len(projects))
+	for project := range projects {
+		names = append(names, project)
+	}
+	sort.Strings(names)
+	for _, project := range names {
+		size := sizes[project]
+		if size <= policy.ProjectMaxSize {
+			continue
+		}
+		pvols := projects[project]
+		sort.SliceStable(pvols, func(i, j int) bool {
+			return pvols[i].unreferenced.Before(pvols[j].unreferenced)
+		})
+		for _, rv := range pvols {
+			if size <= policy.ProjectMaxSize {
+				break
+			}
+			if rv.inUse || rv.size <= 0 {
+				continue
+			}
+			candidates = append(candidates, reapCandidate{v: rv.v, reason: ReapReasonProjectSize})
+			size -= rv.size
+		}
+	}
+	return candidates
+}
+
+// setUnreferenced records in the metadata of the volume with the given name
+// the time at which its last reference was dropped, or clears it if t is
+// zero, so that it is kept across restarts of the daemon. Errors are only
+// logged.
+// It is expected that callers of this function hold the name lock.
+func (s *VolumeStore) setUnreferenced(name string, t time.Time) {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		var meta volumeMetadata
+		if err := getMeta(tx, name, &meta); err != nil && !errdefs.IsNotFound(err) {
+			return err
+		}
+		if meta.Name == "" {
+			return nil
+		}
+		meta.Unreferenced = t
+		return setMeta(tx, name, meta)
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("volume", name).Warn("failed to record when the volume was last referenced")
+	}
+}
+
+// volumeTTL returns the time to live of the volume v with the given labels
+// once unreferenced, and whether it has one.
+func volumeTTL(v volume.Volume, labels map[string]string, policy LifecyclePolicy) (time.Duration, bool) {
+	if value, ok := labels[volume.TTLLabel]; ok {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl < 0 {
+			logrus.WithField("volume", v.Name()).Warnf("ignoring invalid %s label %q", volume.TTLLabel, value)
+			return 0, false
+		}
+		return ttl, true
+	}
+	if _, ok := labels[volume.AnonymousLabel]; ok && policy.AnonymousTTL > 0 {
+		return policy.AnonymousTTL, true
+	}
+	return 0, false
+}
+
+// volumeLabels returns the labels of the volume v, if any.
+func volumeLabels(v volume.Volume) map[string]string {
+	if dv, ok := v.(volume.DetailedVolume); ok {
+		return dv.Labels()
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package store // import "github.com/docker/docker/volume/store"
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/volume"
+	volumedrivers "github.com/docker/docker/volume/drivers"
+	volumetestutils "github.com/docker/docker/volume/testutils"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+func TestReap(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := setupTest(t)
+	defer cleanup()
+	s.drivers.Register(volumetestutils.NewFakeDriver("fake"), "fake")
+
+	anonymous := map[string]string{volume.AnonymousLabel: ""}
+	_, err := s.Create("expired", "fake", nil, anonymous)
+	assert.NilError(t, err)
+	_, err = s.Create("recent", "fake", nil, anonymous)
+	assert.NilError(t, err)
+	_, err = s.CreateWithRef("used", "fake", "container", nil, anonymous)
+	assert.NilError(t, err)
+	_, err = s.Create("named", "fake", nil, nil)
+	assert.NilError(t, err)
+	_, err = s.Create("labeled", "fake", nil, map[string]string{volume.TTLLabel: "1m"})
+	assert.NilError(t, err)
+
+	now := time.Now()
+	s.unreferenced["expired"] = now.Add(-2 * time.Hour)
+	s.unreferenced["recent"] = now.Add(-30 * time.Minute)
+	s.unreferenced["named"] = now.Add(-2 * time.Hour)
+	s.unreferenced["labeled"] = now.Add(-2 * time.Minute)
+
+	removed := map[string]string{}
+	policy := LifecyclePolicy{
+		AnonymousTTL: time.Hour,
+		Removed: func(v volume.Volume, reason string) {
+			removed[v.Name()] = reason
+		},
+	}
+	assert.NilError(t, s.reap(context.Background(), policy))
+	assert.Check(t, is.DeepEqual(removed, map[string]string{"expired": ReapReasonTTL, "labeled": ReapReasonTTL}))
+
+	ls, _, err := s.List()
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(ls, 3))
+}
+
+func TestReapUnknownUnreferenced(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := setupTest(t)
+	defer cleanup()
+	s.drivers.Register(volumetestutils.NewFakeDriver("fake"), "fake")
+
+	_, err := s.Create("anonymous", "fake", nil, map[string]string{volume.AnonymousLabel: ""})
+	assert.NilError(t, err)
+
+	// A volume whose last reference was never seen dropped is considered
+	// unreferenced from the first run of the reaper.
+	policy := LifecyclePolicy{AnonymousTTL: time.Hour}
+	assert.NilError(t, s.reap(context.Background(), policy))
+	_, err = s.Get("anonymous")
+	assert.NilError(t, err)
+	assert.Check(t, !s.unreferenced["anonymous"].IsZero())
+
+	v, err := s.GetWithRef("anonymous", "fake", "container")
+	assert.NilError(t, err)
+	_, ok := s.unreferenced["anonymous"]
+	assert.Check(t, !ok)
+	s.Dereference(v, "container")
+	_, ok = s.unreferenced["anonymous"]
+	assert.Check(t, ok)
+}
+
+func TestSelectReapCandidatesProjectSize(t *testing.T) {
+	now := time.Now()
+	project := func(name, project string, inUse bool, unreferenced time.Duration, size int64) reapVolume {
+		v := volumeWrapper{Volume: volumetestutils.NewFakeVolume(name, "fake"), labels: map[string]string{"project": project}}
+		return reapVolume{v: v, inUse: inUse, unreferenced: now.Add(-unreferenced), size: size}
+	}
+	vols := []reapVolume{
+		project("a1", "a", true, 0, 50),
+		project("a2", "a", false, time.Minute, 30),
+		project("a3", "a", false, time.Hour, 30),
+		project("a4", "a", false, 2*time.Hour, 10),
+		project("b1", "b", false, time.Hour, 100),
+		{v: volumetestutils.NewFakeVolume("other", "fake"), size: 1000},
+	}
+	policy := LifecyclePolicy{ProjectLabel: "project", ProjectMaxSize: 100}
+
+	var names []string
+	for _, c := range selectReapCandidates(vols, policy, now) {
+		assert.Check(t, is.Equal(c.reason, ReapReasonProjectSize))
+		names = append(names, c.v.Name())
+	}
+	// Project a has a total size of 120, the oldest unreferenced volumes are
+	// removed until it fits, and project b fits.
+	assert.Check(t, is.DeepEqual(names, []string{"a4", "a3"}))
+}
+
+func TestUnreferencedRestored(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "test-unreferenced-restored")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	drivers := volumedrivers.NewStore(nil)
+	drivers.Register(volumetestutils.NewFakeDriver("fake"), "fake")
+
+	s, err := New(dir, drivers)
+	assert.NilError(t, err)
+	v, err := s.CreateWithRef("anonymous", "fake", "container", nil, map[string]string{volume.AnonymousLabel: ""})
+	assert.NilError(t, err)
+	s.Dereference(v, "container")
+	unreferenced := s.unreferenced["anonymous"]
+	assert.Check(t, !unreferenced.IsZero())
+	s.Shutdown()
+
+	// The time at which the volume was last referenced survives a restart.
+	s, err = New(dir, drivers)
+	assert.NilError(t, err)
+	assert.Check(t, s.unreferenced["anonymous"].Equal(unreferenced))
+
+	// It is forgotten once the volume is referenced again.
+	_, err = s.GetWithRef("anonymous", "fake", "container")
+	assert.NilError(t, err)
+	s.Shutdown()
+
+	s, err = New(dir, drivers)
+	assert.NilError(t, err)
+	defer s.Shutdown()
+	_, ok := s.unreferenced["anonymous"]
+	assert.Check(t, !ok)
+}
+
+func TestHasLifecyclePolicy(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := setupTest(t)
+	defer cleanup()
+	s.drivers.Register(volumetestutils.NewFakeDriver("fake"), "fake")
+
+	_, err := s.Create("anonymous", "fake", nil, map[string]string{volume.AnonymousLabel: ""})
+	assert.NilError(t, err)
+	assert.Check(t, !s.hasLifecyclePolicy(LifecyclePolicy{}))
+	assert.Check(t, s.hasLifecyclePolicy(LifecyclePolicy{AnonymousTTL: time.Hour}))
+	assert.Check(t, s.hasLifecyclePolicy(LifecyclePolicy{ProjectLabel: "project", ProjectMaxSize: 100}))
+
+	_, err = s.Create("labeled", "fake", nil, map[string]string{volume.TTLLabel: "1m"})
+	assert.NilError(t, err)
+	assert.Check(t, s.hasLifecyclePolicy(LifecyclePolicy{}))
+}
@@ -65,6 +65,9 @@ func (s *VolumeStore) restore() {
 			s.options[v.Name()] = meta.Options
 			s.labels[v.Name()] = meta.Labels
 			s.names[v.Name()] = v
+			if !meta.Unreferenced.IsZero() {
+				s.unreferenced[v.Name()] = meta.Unreferenced
+			}
 			s.globalLock.Unlock()
 		}(meta)
 	}
@@ -1,6 +1,7 @@
 package store // import "github.com/docker/docker/volume/store"
 
 import (
+	"io"
 	"net"
 	"os"
 	"path/filepath"
@@ -65,6 +66,20 @@ func (v volumeWrapper) CachedPath() string {
 	return v.Volume.Path()
 }
 
+func (v volumeWrapper) Export() (io.ReadCloser, error) {
+	if vv, ok := v.Volume.(volume.ArchiveVolume); ok {
+		return vv.Export()
+	}
+	return nil, volume.ErrArchiveNotSupported
+}
+
+func (v volumeWrapper) Import(content io.Reader) error {
+	if vv, ok := v.Volume.(volume.ArchiveVolume); ok {
+		return vv.Import(content)
+	}
+	return volume.ErrArchiveNotSupported
+}
+
 // New initializes a VolumeStore to keep
 // reference counting of volumes in the system.
 func New(rootPath string, drivers *drivers.Store) (*VolumeStore, error) {
@@ -75,6 +90,8 @@ func New(rootPath string, drivers *drivers.Store) (*VolumeStore, error) {
 		labels:  make(map[string]map[string]string),
 		options: make(map[string]map[string]string),
 		drivers: drivers,
+
+		unreferenced: make(map[string]time.Time),
 	}
 
 	if rootPath != "" {
@@ -120,13 +137,20 @@ func (s *VolumeStore) setNamed(v volume.Volume, ref string) {
 
 	s.globalLock.Lock()
 	s.names[name] = v
+	var wasUnreferenced bool
 	if len(ref) > 0 {
 		if s.refs[name] == nil {
 			s.refs[name] = make(map[string]struct{})
 		}
 		s.refs[name][ref] = struct{}{}
+		_, wasUnreferenced = s.unreferenced[name]
+		delete(s.unreferenced, name)
 	}
 	s.globalLock.Unlock()
+
+	if wasUnreferenced {
+		s.setUnreferenced(name, time.Time{})
+	}
 }
 
 // hasRef returns true if the given name has at least one ref.
@@ -170,6 +194,7 @@ func (s *VolumeStore) Purge(name string) {
 	delete(s.refs, name)
 	delete(s.labels, name)
 	delete(s.options, name)
+	delete(s.unreferenced, name)
 	s.globalLock.Unlock()
 }
 
@@ -190,7 +215,10 @@ type VolumeStore struct {
 	labels map[string]map[string]string
 	// options stores volume options for each volume
 	options map[string]map[string]string
-	db      *bolt.DB
+	// unreferenced stores the time at which the last reference to a volume
+	// was dropped, for the lifecycle policies of the reaper.
+	unreferenced map[string]time.Time
+	db           *bolt.DB
 }
 
 // List proxies to all registered volume drivers to get the full list of volumes
@@ -289,7 +317,7 @@ func (s *VolumeStore) CreateWithRef(name, driverName, ref string, opts, labels m
 	s.locks.Lock(name)
 	defer s.locks.Unlock(name)
 
-	v, err := s.create(name, driverName, opts, labels)
+	v, err := s.create(name, driverName, ref, opts, labels)
 	if err != nil {
 		if _, ok := err.(*OpErr); ok {
 			return nil, err
@@ -383,7 +411,7 @@ func volumeExists(store *drivers.Store, v volume.Volume) (bool, error) {
 //  for the given volume name, an error is returned after checking if the reference is stale.
 // If the reference is stale, it will be purged and this create can continue.
 // It is expected that callers of this function hold any necessary locks.
-func (s *VolumeStore) create(name, driverName string, opts, labels map[string]string) (volume.Volume, error) {
+func (s *VolumeStore) create(name, driverName, ref string, opts, labels map[string]string) (volume.Volume, error) {
 	// Validate the name in a platform-specific manner
 
 	// volume name validation is specific to the host os and not on container image
@@ -434,6 +462,14 @@ func (s *VolumeStore) create(name, driverName string, opts, labels map[string]st
 		}
 	}
 
+	return s.register(name, ref, vd, v, labels, opts)
+}
+
+// register stores the labels and options of the volume v with the given name,
+// which was just created by the driver vd for the reference ref, and returns
+// it wrapped with them.
+// It is expected that callers of this function hold the name lock.
+func (s *VolumeStore) register(name, ref string, vd volume.Driver, v volume.Volume, labels, opts map[string]string) (volume.Volume, error) {
 	s.globalLock.Lock()
 	s.labels[name] = labels
 	s.options[name] = opts
@@ -445,6 +481,7 @@ func (s *VolumeStore) create(name, driverName string, opts, labels map[string]st
 		Driver:  vd.Name(),
 		Labels:  labels,
 		Options: opts,
+		Usage:   Usage{CreatedBy: ref},
 	}
 
 	if err := s.setMeta(name, metadata); err != nil {
@@ -620,10 +657,18 @@ func (s *VolumeStore) Dereference(v volume.Volume, ref string) {
 	defer s.locks.Unlock(name)
 
 	s.globalLock.Lock()
-	defer s.globalLock.Unlock()
-
+	var unreferenced time.Time
 	if s.refs[name] != nil {
 		delete(s.refs[name], ref)
+		if len(s.refs[name]) == 0 {
+			unreferenced = time.Now()
+			s.unreferenced[name] = unreferenced
+		}
+	}
+	s.globalLock.Unlock()
+
+	if !unreferenced.IsZero() {
+		s.setUnreferenced(name, unreferenced)
 	}
 }
 
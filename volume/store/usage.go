@@ -0,0 +1,100 @@
+package store // import "github.com/docker/docker/volume/store"
+
+import (
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/volume"
+	"github.com/sirupsen/logrus"
+)
+
+// maxConsumers is the number of containers kept in the usage history of a
+// volume.
+const maxConsumers = 10
+
+// Usage is the usage history of a volume, kept in the volume metadata.
+type Usage struct {
+	// CreatedBy is the container for which the volume was created, if any.
+	CreatedBy string `json:",omitempty"`
+	// LastMounted is the last time the volume was mounted.
+	LastMounted time.Time
+	// LastUnmounted is the last time the volume was unmounted.
+	LastUnmounted time.Time
+	// Consumers are the containers which mounted the volume most recently,
+	// most recent first.
+	Consumers []Consumer `json:",omitempty"`
+}
+
+// Consumer is a container in the usage history of a volume.
+type Consumer struct {
+	Container   string
+	LastMounted time.Time
+}
+
+// Usage returns the usage history of the volume v. It is empty if the volume
+// was never used since the daemon started recording it.
+func (s *VolumeStore) Usage(v volume.Volume) (Usage, error) {
+	meta, err := s.getMeta(normalizeVolumeName(v.Name()))
+	if err != nil && !errdefs.IsNotFound(err) {
+		return Usage{}, err
+	}
+	return meta.Usage, nil
+}
+
+// RecordMount records in the usage history of the volume with the given name
+// and driver that it was mounted for the given container.
+func (s *VolumeStore) RecordMount(name, driverName, container string) {
+	now := time.Now().UTC()
+	s.updateUsage(name, driverName, func(u *Usage) {
+		u.LastMounted = now
+		consumers := []Consumer{{Container: container, LastMounted: now}}
+		for _, c := range u.Consumers {
+			if c.Container != container && len(consumers) < maxConsumers {
+				consumers = append(consumers, c)
+			}
+		}
+		u.Consumers = consumers
+	})
+}
+
+// RecordUnmount records in the usage history of the volume with the given
+// name that it was unmounted.
+func (s *VolumeStore) RecordUnmount(name string) {
+	now := time.Now().UTC()
+	s.updateUsage(name, "", func(u *Usage) {
+		u.LastUnmounted = now
+	})
+}
+
+// updateUsage applies f to the usage history of the volume with the given
+// name. A volume unknown to the metadata database is added to it with the
+// given driver, or ignored if the driver is empty. Errors are only logged, a
+// failure to record the usage of a volume must not fail the operation using
+// it.
+func (s *VolumeStore) updateUsage(name, driverName string, f func(*Usage)) {
+	name = normalizeVolumeName(name)
+	s.locks.Lock(name)
+	defer s.locks.Unlock(name)
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		var meta volumeMetadata
+		if err := getMeta(tx, name, &meta); err != nil && !errdefs.IsNotFound(err) {
+			return err
+		}
+		if meta.Name == "" {
+			if driverName == "" {
+				return nil
+			}
+			// The volume was not created through the store, the driver is
+			// kept to restore it.
+			meta.Name = name
+			meta.Driver = driverName
+		}
+		f(&meta.Usage)
+		return setMeta(tx, name, meta)
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("volume", name).Warn("failed to record volume usage")
+	}
+}
@@ -0,0 +1,57 @@
+package store // import "github.com/docker/docker/volume/store"
+
+import (
+	"fmt"
+	"testing"
+
+	volumetestutils "github.com/docker/docker/volume/testutils"
+	"github.com/gotestyourself/gotestyourself/assert"
+	is "github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+func TestUsage(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := setupTest(t)
+	defer cleanup()
+	s.drivers.Register(volumetestutils.NewFakeDriver("fake"), "fake")
+
+	v, err := s.CreateWithRef("fake1", "fake", "creator", nil, nil)
+	assert.NilError(t, err)
+
+	u, err := s.Usage(v)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(u.CreatedBy, "creator"))
+	assert.Check(t, u.LastMounted.IsZero())
+	assert.Check(t, is.Len(u.Consumers, 0))
+
+	for i := 0; i < maxConsumers+2; i++ {
+		s.RecordMount(v.Name(), v.DriverName(), fmt.Sprintf("container%d", i))
+	}
+	s.RecordMount(v.Name(), v.DriverName(), "container5")
+	s.RecordUnmount(v.Name())
+
+	u, err = s.Usage(v)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(u.CreatedBy, "creator"))
+	assert.Check(t, !u.LastUnmounted.Before(u.LastMounted))
+	assert.Assert(t, is.Len(u.Consumers, maxConsumers))
+	assert.Check(t, is.Equal(u.Consumers[0].Container, "container5"))
+	assert.Check(t, u.Consumers[0].LastMounted.Equal(u.LastMounted))
+	assert.Check(t, is.Equal(u.Consumers[1].Container, fmt.Sprintf("container%d", maxConsumers+1)))
+	assert.Check(t, is.Equal(u.Consumers[maxConsumers-1].Container, "container2"))
+
+	// The usage history is kept when the volume is looked up again, and is
+	// removed with the volume.
+	v, err = s.Get("fake1")
+	assert.NilError(t, err)
+	u, err = s.Usage(v)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(u.Consumers, maxConsumers))
+
+	s.Dereference(v, "creator")
+	assert.NilError(t, s.Remove(v))
+	u, err = s.Usage(v)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(u, Usage{}))
+}
@@ -1,6 +1,8 @@
 package volume // import "github.com/docker/docker/volume"
 
 import (
+	"errors"
+	"io"
 	"time"
 )
 
@@ -15,6 +17,23 @@ const (
 	GlobalScope = "global"
 )
 
+const (
+	// AnonymousLabel is the label of the volumes created by the daemon for
+	// the VOLUME instructions of images, and for mounts without a source.
+	AnonymousLabel = "com.docker.volume.anonymous"
+	// TTLLabel is the label of a volume setting for how long it is kept
+	// once no container references it, as a duration.
+	TTLLabel = "com.docker.volume.ttl"
+)
+
+// ErrArchiveNotSupported is returned by the Export and Import methods of
+// an ArchiveVolume when its driver cannot export and import volumes.
+var ErrArchiveNotSupported = errors.New("volume driver does not support export and import")
+
+// ErrCloneNotSupported is returned by the methods of a CloneDriver when it
+// cannot clone and snapshot volumes.
+var ErrCloneNotSupported = errors.New("volume driver does not support clones and snapshots")
+
 // Driver is for creating and removing volumes.
 type Driver interface {
 	// Name returns the name of the volume driver.
@@ -32,6 +51,21 @@ type Driver interface {
 	Scope() string
 }
 
+// CloneDriver is a Driver which can create volumes as copies of its volumes,
+// and save snapshots of its volumes to create copies of them later.
+type CloneDriver interface {
+	Driver
+	// Clone creates a volume with the given name and options with a copy of
+	// the content of the volume src, or of its snapshot with the given name
+	// if snapshot is not empty.
+	Clone(src Volume, snapshot, name string, opts map[string]string) (Volume, error)
+	// Snapshot saves a copy of the content of the volume to a snapshot with
+	// the given name. The snapshots of a volume are removed with the volume.
+	Snapshot(v Volume, name string) error
+	// RemoveSnapshot removes the snapshot of the volume with the given name.
+	RemoveSnapshot(v Volume, name string) error
+}
+
 // Capability defines a set of capabilities that a driver is able to handle.
 type Capability struct {
 	// Scope is the scope of the driver, `global` or `local`
@@ -39,6 +73,12 @@ type Capability struct {
 	// A `local` scope indicates that the driver only manages volumes resources local to the host
 	// Scope is declared by the driver
 	Scope string
+	// Archive indicates that the driver can export and import the content
+	// of its volumes as tar archives.
+	Archive bool
+	// Clone indicates that the driver can create volumes as copies of its
+	// volumes and of their snapshots.
+	Clone bool
 }
 
 // Volume is a place to store data. It is backed by a specific driver, and can be mounted.
@@ -60,6 +100,17 @@ type Volume interface {
 	Status() map[string]interface{}
 }
 
+// ArchiveVolume is a Volume whose content can be exported and imported as
+// a tar archive, to back it up and restore it.
+type ArchiveVolume interface {
+	Volume
+	// Export returns an uncompressed tar archive of the content of the volume.
+	Export() (io.ReadCloser, error)
+	// Import replaces the content of the volume with the content of a tar
+	// archive, which may be compressed.
+	Import(content io.Reader) error
+}
+
 // DetailedVolume wraps a Volume with user-defined labels, options, and cluster scope (e.g., `local` or `global`)
 type DetailedVolume interface {
 	Labels() map[string]string